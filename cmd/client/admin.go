@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// adminAddress 管理接口地址, TLS 配置与网关一致
+var adminAddress string
+
+func DeadLetter() *cobra.Command {
+	deadLetterCommand := &cobra.Command{
+		Use:   "deadletter",
+		Short: "use to manage the cross chain requests in the dead letter queue via the admin server",
+	}
+	deadLetterCommand.PersistentFlags().StringVar(&adminAddress, "admin", "127.0.0.1:1001", "address of the admin server")
+	deadLetterCommand.AddCommand(listDeadLetters())
+	deadLetterCommand.AddCommand(getDeadLetter())
+	deadLetterCommand.AddCommand(retryDeadLetter())
+	deadLetterCommand.AddCommand(discardDeadLetter())
+	return deadLetterCommand
+}
+
+func listDeadLetters() *cobra.Command {
+	req := &pb.ListDeadLettersRequest{}
+	listCommand := &cobra.Command{
+		Use:   "list",
+		Short: "use to list the dead letters, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				resp, err := adminClient.ListDeadLetters(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				return printJSON(resp.DeadLetters)
+			})
+		},
+	}
+	flags := listCommand.Flags()
+	flags.StringVar(&req.ChannelID, "channel", "", "local channel id, all channels when empty")
+	flags.Uint32Var(&req.Offset, "offset", 0, "offset")
+	flags.Uint32Var(&req.Limit, "limit", 20, "limit, 0 means all")
+
+	return listCommand
+}
+
+func getDeadLetter() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "use to inspect a dead letter with its request and error history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := deadLetterRequest(args[0])
+			if err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				letter, err := adminClient.GetDeadLetter(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				request := letter.Request
+				letter.Request = nil
+				if err = printJSON(letter); err != nil {
+					return err
+				}
+				return printRequest(request)
+			})
+		},
+	}
+}
+
+func retryDeadLetter() *cobra.Command {
+	return &cobra.Command{
+		Use:   "retry <id>",
+		Short: "use to process a dead letter again, it is removed after success",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := deadLetterRequest(args[0])
+			if err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				resp, err := adminClient.RetryDeadLetter(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				if resp.Succeeded {
+					fmt.Printf("dead letter %d is processed and removed \n", req.Id)
+					return nil
+				}
+				fmt.Printf("dead letter %d failed again \n", req.Id)
+				return printJSON(resp.DeadLetter)
+			})
+		},
+	}
+}
+
+func discardDeadLetter() *cobra.Command {
+	return &cobra.Command{
+		Use:   "discard <id>",
+		Short: "use to discard a dead letter",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := deadLetterRequest(args[0])
+			if err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				if _, err := adminClient.DiscardDeadLetter(context.Background(), req); err != nil {
+					return err
+				}
+				fmt.Printf("dead letter %d is discarded \n", req.Id)
+				return nil
+			})
+		},
+	}
+}
+
+func deadLetterRequest(arg string) (*pb.DeadLetterRequest, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid dead letter id %s", arg)
+	}
+	return &pb.DeadLetterRequest{Id: id}, nil
+}
+
+func Inbound() *cobra.Command {
+	inboundCommand := &cobra.Command{
+		Use:   "inbound",
+		Short: "use to manage the executions of the cross chain requests from remote hubs via the admin server",
+	}
+	inboundCommand.PersistentFlags().StringVar(&adminAddress, "admin", "127.0.0.1:1001", "address of the admin server")
+	inboundCommand.AddCommand(listInboundExecutions())
+	inboundCommand.AddCommand(resolveInboundExecution())
+	return inboundCommand
+}
+
+func listInboundExecutions() *cobra.Command {
+	req := &pb.ListInboundExecutionsRequest{}
+	listCommand := &cobra.Command{
+		Use:   "list",
+		Short: "use to list the executions, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				resp, err := adminClient.ListInboundExecutions(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				return printJSON(resp.Executions)
+			})
+		},
+	}
+	flags := listCommand.Flags()
+	flags.StringVar(&req.State, "state", "executing", "executing, committed, executed or aborted, all states when empty")
+	flags.Uint32Var(&req.Offset, "offset", 0, "offset")
+	flags.Uint32Var(&req.Limit, "limit", 20, "limit, 0 means all")
+
+	return listCommand
+}
+
+func resolveInboundExecution() *cobra.Command {
+	req := &pb.ResolveInboundExecutionRequest{}
+	resolveCommand := &cobra.Command{
+		Use: "resolve <from> <transactionID> <stepID>",
+		Short: "use to resolve an execution with unknown outcome by the fabric transaction on the chain, " +
+			"an empty tx id marks it aborted and the request is executed again on retry",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.From, req.TransactionID, req.StepID = args[0], args[1], args[2]
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				execution, err := adminClient.ResolveInboundExecution(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				return printJSON(execution)
+			})
+		},
+	}
+	resolveCommand.Flags().StringVar(&req.TxID, "tx", "", "id of the fabric transaction executing the request")
+
+	return resolveCommand
+}
+
+// withAdminClient 使用配置中的 TLS 设置连接管理接口
+func withAdminClient(fn func(adminClient pb.AdminClient) error) error {
+	p, err := readProfile()
+	if err != nil {
+		return err
+	}
+	grpcClient, err := client.NewGRPCClient(
+		p.ClientConfig.ClientCertPath,
+		p.ClientConfig.ClientKeyPath,
+		p.ClientConfig.ClientRootCACertPath,
+		p.ClientConfig.ServerRootCAPath,
+		p.ClientConfig.IsGm,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create grpc client")
+	}
+	var conn *grpc.ClientConn
+	if conn, err = grpcClient.NewConnection(adminAddress); err != nil {
+		return errors.Wrapf(err, "failed to connect to %s", adminAddress)
+	}
+	defer conn.Close()
+	return fn(pb.NewAdminClient(conn))
+}
+
+// printRequest 输出死信中的跨链请求, 无法解码时输出原始长度
+func printRequest(data []byte) error {
+	request := &pb.NoTransactionCallRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		fmt.Printf("request: %d bytes \n", len(data))
+		return nil
+	}
+	// 包含证明体积较大, 只输出区块号
+	if request.Proof != nil && request.Proof.Header != nil {
+		fmt.Printf("proof: block %d \n", request.Proof.Header.Number)
+	}
+	request.Proof = nil
+	fmt.Println("request:")
+	return printJSON(request)
+}
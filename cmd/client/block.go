@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func Block() *cobra.Command {
+	blockCommand := &cobra.Command{
+		Use:   "block",
+		Short: "use to manage the block follower of a local channel via the admin server",
+	}
+	blockCommand.PersistentFlags().StringVar(&adminAddress, "admin", "127.0.0.1:1001", "address of the admin server")
+	blockCommand.AddCommand(getCursor())
+	blockCommand.AddCommand(setStartBlock())
+	blockCommand.AddCommand(rewindBlock())
+	blockCommand.AddCommand(replayBlocks())
+	return blockCommand
+}
+
+func getCursor() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cursor <channel>",
+		Short: "use to show the next block to be processed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				cursor, err := adminClient.GetCursor(context.Background(), &pb.CursorRequest{ChannelID: args[0]})
+				if err != nil {
+					return err
+				}
+				return printJSON(cursor)
+			})
+		},
+	}
+}
+
+func setStartBlock() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start <channel> <number|latest>",
+		Short: "use to set the next block to be processed, latest means the current ledger height",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.SetStartBlockRequest{ChannelID: args[0]}
+			if args[1] == "latest" {
+				req.Latest = true
+			} else {
+				number, err := parseBlockNumber(args[1])
+				if err != nil {
+					return err
+				}
+				req.BlockNumber = number
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				cursor, err := adminClient.SetStartBlock(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("the next block of %s is %d \n", cursor.ChannelID, cursor.NextBlock)
+				return nil
+			})
+		},
+	}
+}
+
+func rewindBlock() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewind <channel> <number>",
+		Short: "use to process the blocks again from the given block, the completed requests are not sent again",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := parseBlockNumber(args[1])
+			if err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				cursor, err := adminClient.GetCursor(context.Background(), &pb.CursorRequest{ChannelID: args[0]})
+				if err != nil {
+					return err
+				}
+				if number > cursor.NextBlock {
+					return errors.Errorf("block %d is not processed yet, the next block is %d", number, cursor.NextBlock)
+				}
+				req := &pb.SetStartBlockRequest{ChannelID: args[0], BlockNumber: number}
+				if cursor, err = adminClient.SetStartBlock(context.Background(), req); err != nil {
+					return err
+				}
+				fmt.Printf("the next block of %s is rewound to %d \n", cursor.ChannelID, cursor.NextBlock)
+				return nil
+			})
+		},
+	}
+}
+
+func replayBlocks() *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay <channel> <from> <to>",
+		Short: "use to process the cross chain requests in the blocks [from, to] again without moving the cursor",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := parseBlockNumber(args[1])
+			if err != nil {
+				return err
+			}
+			to, err := parseBlockNumber(args[2])
+			if err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				req := &pb.ReplayBlocksRequest{ChannelID: args[0], FromBlock: from, ToBlock: to}
+				resp, err := adminClient.ReplayBlocks(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d blocks and %d cross chain requests are replayed \n", resp.Blocks, resp.Requests)
+				return nil
+			})
+		},
+	}
+}
+
+func parseBlockNumber(arg string) (uint64, error) {
+	number, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid block number %s", arg)
+	}
+	return number, nil
+}
@@ -4,59 +4,326 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"time"
+
 	"github.com/bwmarrin/snowflake"
-	client2 "github.com/fabric-creed/fabric-hub/pkg/client"
-	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/config"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
-	"time"
+	"github.com/fabric-creed/fabric-hub/pkg/sdk"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Profile 客户端配置, 可写入 profile 文件, 命令行参数优先
+type Profile struct {
+	// 网关地址与端口
+	Address      string              `json:"address" yaml:"address"`
+	Port         uint32              `json:"port" yaml:"port"`
+	ClientConfig config.ClientConfig `json:"clientConfig" yaml:"clientConfig"`
+	// 来源链通道ID
+	From string `json:"from" yaml:"from"`
+	// 目的链通道ID
+	To string `json:"to" yaml:"to"`
+	// 来源链签名身份, 用于对请求进行签名
+	CSP config.CSP `json:"csp" yaml:"csp"`
+	// 目的链验签证书, 用于核实响应签名
+	RemoteCSP config.CSP `json:"remoteCSP" yaml:"remoteCSP"`
+	// 生成交易ID的 snowflake 节点号
+	NodeID int64 `json:"nodeID" yaml:"nodeID"`
+}
+
+var (
+	v           = viper.New()
+	profilePath string
+	// 调用超时时间, 包含重试, 网关据此设置 Fabric SDK 的请求超时
+	timeout time.Duration
 )
 
 func main() {
-	grpc, err := client2.NewGRPCClient(
-		"",
-		"",
-		"",
-		"./test/ca.crt",
-		true,
-	)
+	command := &cobra.Command{
+		Use:          "client",
+		Short:        "hub client, the flags override the profile",
+		SilenceUsage: true,
+	}
+	flags := command.PersistentFlags()
+	flags.StringVar(&profilePath, "profile", "", "profile file")
+	flags.DurationVar(&timeout, "timeout", 30*time.Second, "timeout of the call including retries, 0 means no timeout")
+	bindFlag := func(key, name, usage string) {
+		flags.String(name, "", usage)
+		v.BindPFlag(key, flags.Lookup(name))
+	}
+	bindFlag("address", "address", "address of the hub")
+	flags.Uint32("port", 1000, "port of the hub")
+	v.BindPFlag("port", flags.Lookup("port"))
+	bindFlag("clientConfig.clientCertPath", "tls-cert", "client tls certificate")
+	bindFlag("clientConfig.clientKeyPath", "tls-key", "client tls key")
+	bindFlag("clientConfig.clientRootCACertPath", "tls-client-ca", "ca of the client tls certificate")
+	bindFlag("clientConfig.serverRootCAPath", "tls-server-ca", "ca of the hub tls certificate, tls is disabled when empty")
+	flags.Bool("gm", false, "use GM tls")
+	v.BindPFlag("clientConfig.isGm", flags.Lookup("gm"))
+	bindFlag("from", "from", "from channel id")
+	bindFlag("to", "to", "to channel id")
+	bindFlag("csp.privateKey", "key", "private key used to sign the request")
+	bindFlag("csp.cert", "cert", "certificate of the signing key")
+	bindFlag("csp.keyStore", "keystore", "key store type: file, dir or external")
+	bindFlag("csp.keyStorePath", "keystore-path", "directory of the dir key store")
+	bindFlag("csp.ski", "ski", "hex SKI of the signing key")
+	bindFlag("csp.signerAddress", "signer-address", "socket of the external signer")
+	bindFlag("csp.passwordEnv", "password-env", "environment variable holding the key password")
+	bindFlag("remoteCSP.cert", "remote-cert", "certificate used to verify the response")
+	flags.Int64("node-id", 1, "snowflake node id of the transaction id")
+	v.BindPFlag("nodeID", flags.Lookup("node-id"))
+
+	command.AddCommand(Call())
+	command.AddCommand(SyncBlockHeaders())
+	command.AddCommand(Status())
+	command.AddCommand(DeadLetter())
+	command.AddCommand(Inbound())
+	command.AddCommand(Block())
+	command.AddCommand(Query())
+	// cobra 已输出错误信息
+	if err := command.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func loadProfile() (*Profile, error) {
+	p, err := readProfile()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	if p.Address == "" {
+		return nil, errors.New("the address of the hub is required")
+	}
+	return p, nil
+}
 
-	conn, err := grpc.NewConnection(fmt.Sprintf("%s:%d", "orderer1.example.com", 1000))
+// readProfile 读取配置, 不校验网关地址
+func readProfile() (*Profile, error) {
+	if profilePath != "" {
+		v.SetConfigFile(profilePath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read profile %s", profilePath)
+		}
+	}
+	var p Profile
+	if err := v.Unmarshal(&p); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal profile")
+	}
+	return &p, nil
+}
+
+// newHubClient 创建网关客户端, 来源链身份用于签名, 目的链证书用于验签
+func newHubClient(p *Profile) (*client.HubClient, error) {
+	grpcClient, err := client.NewGRPCClient(
+		p.ClientConfig.ClientCertPath,
+		p.ClientConfig.ClientKeyPath,
+		p.ClientConfig.ClientRootCACertPath,
+		p.ClientConfig.ServerRootCAPath,
+		p.ClientConfig.IsGm,
+	)
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrap(err, "failed to create grpc client")
 	}
-	defer conn.Close()
-	node, err := snowflake.NewNode(1)
+	hubClient, err := client.NewHubClient(p.Address, p.Port, grpcClient, client.WithTimeout(timeout))
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	payload := pb.FabricPayloadRequest{
-		ChannelName:   "mychannel",
-		ChainCodeName: "fabcar",
-		FncName:       "QueryCar",
-		Args:          []string{"CAR1"},
+	csp := make(map[string]*sw.SimpleCSP)
+	if p.From != "" && (p.CSP.PrivateKey != "" || p.CSP.KeyStore != "") {
+		if csp[p.From], err = p.CSP.NewSimpleCSP(); err != nil {
+			return nil, errors.Wrapf(err, "failed to load csp of %s", p.From)
+		}
 	}
-	data, err := json.Marshal(payload)
+	if p.To != "" && p.RemoteCSP.Cert != "" {
+		if csp[p.To], err = p.RemoteCSP.NewSimpleCSP(); err != nil {
+			return nil, errors.Wrapf(err, "failed to load csp of %s", p.To)
+		}
+	}
+	hubClient.SetCSP(csp)
+	return hubClient, nil
+}
+
+func newTransactionID(p *Profile) (string, error) {
+	node, err := snowflake.NewNode(p.NodeID)
 	if err != nil {
-		panic(err)
-	}
-	resp, err := pb.NewHubClient(conn).NoTransactionCall(context.Background(), &pb.NoTransactionCallRequest{
-		From:          "1411931467332157440",
-		To:            "1411931388202418176",
-		TransactionID: node.Generate().String(),
-		StepID:        "1",
-		Payload:       data,
-		Timestamp:     time.Now().Unix(),
-	})
+		return "", errors.Wrap(err, "failed to generate transaction id")
+	}
+	return node.Generate().String(), nil
+}
+
+func Call() *cobra.Command {
+	var (
+		payload  pb.FabricPayloadRequest
+		callback pb.FabricCallback
+		txID     string
+		stepID   string
+	)
+	callCommand := &cobra.Command{
+		Use:   "call",
+		Short: "use to invoke a chaincode of the to channel by NoTransactionCall",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := loadProfile()
+			if err != nil {
+				return err
+			}
+			if p.From == "" || p.To == "" {
+				return errors.New("--from and --to are required")
+			}
+			hubClient, err := newHubClient(p)
+			if err != nil {
+				return err
+			}
+			if txID == "" {
+				if txID, err = newTransactionID(p); err != nil {
+					return err
+				}
+			}
+			if callback.CallbackChannelName != "" {
+				payload.Callback = &callback
+			}
+			data, err := json.Marshal(&payload)
+			if err != nil {
+				return err
+			}
+			resp, err := hubClient.NoTransactionCall(context.Background(), &pb.NoTransactionCallRequest{
+				From:          p.From,
+				To:            p.To,
+				TransactionID: txID,
+				StepID:        stepID,
+				Payload:       data,
+				Timestamp:     time.Now().Unix(),
+			})
+			if err != nil {
+				return err
+			}
+			return printResponse(resp)
+		},
+	}
+	flags := callCommand.Flags()
+	flags.StringVar(&payload.ChannelName, "channel", "", "channel name of the to chain")
+	flags.StringVar(&payload.ChainCodeName, "chaincode", "", "chaincode name")
+	flags.StringVar(&payload.FncName, "fcn", "", "chaincode function")
+	flags.StringArrayVar(&payload.Args, "args", nil, "chaincode args, repeat the flag for more args")
+	flags.StringVar(&callback.CallbackChannelName, "callback-channel", "", "callback channel name of the from chain")
+	flags.StringVar(&callback.CallbackChainCodeName, "callback-chaincode", "", "callback chaincode name")
+	flags.StringVar(&callback.CallbackFncName, "callback-fcn", "", "callback chaincode function")
+	flags.StringArrayVar(&callback.CallbackArgs, "callback-args", nil, "callback chaincode args")
+	flags.StringVar(&txID, "tx-id", "", "transaction id, generated when empty")
+	flags.StringVar(&stepID, "step-id", "1", "step id of the transaction")
+	callCommand.MarkFlagRequired("channel")
+	callCommand.MarkFlagRequired("chaincode")
+	callCommand.MarkFlagRequired("fcn")
+
+	return callCommand
+}
+
+func SyncBlockHeaders() *cobra.Command {
+	req := &pb.SyncBlockHeadersRequest{}
+	headersCommand := &cobra.Command{
+		Use:   "headers",
+		Short: "use to fetch block headers of a channel",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, hubClient, err := prepare(&req.ChannelID)
+			if err != nil {
+				return err
+			}
+			resp, err := hubClient.SyncBlockHeaders(context.Background(), req)
+			if err != nil {
+				return err
+			}
+			return printJSON(resp)
+		},
+	}
+	flags := headersCommand.Flags()
+	flags.StringVar(&req.ChannelID, "channel-id", "", "channel id, defaults to --to")
+	flags.Uint64Var(&req.StartNumber, "start", 0, "first block number")
+	flags.Uint32Var(&req.Limit, "limit", 0, "max number of headers")
+
+	return headersCommand
+}
+
+func Status() *cobra.Command {
+	statusCommand := &cobra.Command{
+		Use:   "status",
+		Short: "use to query the connection and circuit breaker state of the remote hubs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := loadProfile()
+			if err != nil {
+				return err
+			}
+			hubClient, err := newHubClient(p)
+			if err != nil {
+				return err
+			}
+			resp, err := hubClient.QueryStatus(context.Background(), &pb.StatusRequest{})
+			if err != nil {
+				return err
+			}
+			return printJSON(resp)
+		},
+	}
+
+	return statusCommand
+}
+
+// prepare 读取配置并创建客户端, 未指定通道ID时使用目的链通道ID
+func prepare(channelID *string) (*Profile, *client.HubClient, error) {
+	p, err := loadProfile()
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
-	r, err := fabric.DecodeInvokeChainCodeResponse(resp.Payload)
+	if *channelID == "" {
+		*channelID = p.To
+	}
+	if *channelID == "" {
+		return nil, nil, errors.New("either --channel-id or --to is required")
+	}
+	hubClient, err := newHubClient(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, hubClient, nil
+}
+
+// printResponse 输出已通过验签的响应, 并解码执行结果与回调
+func printResponse(resp *pb.CommonResponseMessage) error {
+	fmt.Printf("from: %s \nto: %s \ntransactionID: %s \nstepID: %s \nkeyID: %s \nalgorithm: %s \n",
+		resp.From, resp.To, resp.TransactionID, resp.StepID, resp.KeyID, resp.Algorithm)
+	for _, signature := range resp.Signatures {
+		fmt.Printf("attestation %s keyID: %s, algorithm: %s \n", signature.Signer, signature.KeyID, signature.Algorithm)
+	}
+	if resp.Proof != nil {
+		fmt.Printf("endorsement: txID %s, block %d, %d endorsements \n",
+			resp.Proof.TxID, resp.Proof.BlockNumber, len(resp.Proof.Endorsements))
+	}
+
+	// 目的链执行失败时仍输出解码后的结果
+	if result, err := sdk.DecodeResponse(resp); result == nil {
+		fmt.Printf("payload: %s \n", string(resp.Payload))
+	} else {
+		if err != nil {
+			fmt.Printf("error: %v \n", err)
+		}
+		fmt.Printf("fabric txID: %s \nvalidation code: %d \nchaincode status: %d \npayload: %s \n",
+			result.TransactionID, result.TxValidationCode, result.ChaincodeStatus, string(result.Payload))
+	}
+	if len(resp.Callback) != 0 && string(resp.Callback) != "null" {
+		fmt.Printf("callback: %s \n", string(resp.Callback))
+	}
+	return nil
+}
+
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
-		panic(err)
+		return err
 	}
-	fmt.Println(string(r.PayloadData))
+	fmt.Println(string(data))
+	return nil
 }
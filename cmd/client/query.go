@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func Query() *cobra.Command {
+	queryCommand := &cobra.Command{
+		Use:   "query",
+		Short: "use to query the stored blocks and cross chain requests via the admin server",
+	}
+	queryCommand.PersistentFlags().StringVar(&adminAddress, "admin", "127.0.0.1:1001", "address of the admin server")
+	queryCommand.AddCommand(queryBlock())
+	queryCommand.AddCommand(walkBlocks())
+	queryCommand.AddCommand(queryTransaction())
+	queryCommand.AddCommand(listCrossChainRequests())
+	return queryCommand
+}
+
+func queryBlock() *cobra.Command {
+	var withOriginInfo bool
+	blockCommand := &cobra.Command{
+		Use:   "block <channel> <number|hash>",
+		Short: "use to get a stored block by number or hash, an empty channel queries the blocks stored before per channel stores",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.GetBlockRequest{ChannelID: args[0], WithOriginInfo: withOriginInfo}
+			req.BlockNumber, req.BlockHash = parseBlockRef(args[1])
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				b, err := adminClient.GetBlock(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				originInfo := b.OriginInfo
+				b.OriginInfo = ""
+				return printRecord(b, originInfo)
+			})
+		},
+	}
+	blockCommand.Flags().BoolVar(&withOriginInfo, "origin", false, "print the decoded block data")
+	return blockCommand
+}
+
+func walkBlocks() *cobra.Command {
+	req := &pb.WalkBlocksRequest{}
+	walkCommand := &cobra.Command{
+		Use:   "walk <channel> <number|hash>",
+		Short: "use to walk the stored blocks from the given block via the next or previous hash, an empty channel walks the blocks stored before per channel stores",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.ChannelID = args[0]
+			req.BlockNumber, req.BlockHash = parseBlockRef(args[1])
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				resp, err := adminClient.WalkBlocks(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				if err = printJSON(resp.Blocks); err != nil {
+					return err
+				}
+				if resp.NextPageHash != "" {
+					fmt.Printf("next page: %s \n", resp.NextPageHash)
+				}
+				return nil
+			})
+		},
+	}
+	flags := walkCommand.Flags()
+	flags.BoolVar(&req.Backward, "backward", false, "walk via the previous hash")
+	flags.Uint32Var(&req.Limit, "limit", 20, "limit")
+	return walkCommand
+}
+
+func queryTransaction() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tx <channel> <tx hash>",
+		Short: "use to get a cross chain transaction and the cross chain requests it sent, an empty channel queries the transactions stored before per channel stores",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.GetTransactionRequest{ChannelID: args[0], TransactionHash: args[1]}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				tx, err := adminClient.GetTransaction(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				originInfo := tx.OriginInfo
+				tx.OriginInfo = ""
+				return printRecord(tx, originInfo)
+			})
+		},
+	}
+}
+
+func listCrossChainRequests() *cobra.Command {
+	var (
+		req        = &pb.ListCrossChainRequestsRequest{}
+		start, end string
+	)
+	listCommand := &cobra.Command{
+		Use:   "requests",
+		Short: "use to list the cross chain requests, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if req.StartTime, err = parseTime(start); err != nil {
+				return err
+			}
+			if req.EndTime, err = parseTime(end); err != nil {
+				return err
+			}
+			return withAdminClient(func(adminClient pb.AdminClient) error {
+				resp, err := adminClient.ListCrossChainRequests(context.Background(), req)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("total: %d \n", resp.Total)
+				return printJSON(resp.Requests)
+			})
+		},
+	}
+	flags := listCommand.Flags()
+	flags.StringVar(&req.ChannelID, "channel", "", "local channel id, all channels when empty")
+	flags.StringVar(&req.From, "source", "", "from channel id")
+	flags.StringVar(&req.To, "target", "", "to channel id")
+	flags.StringVar(&req.State, "state", "", "detected, sent, responded, result-written, callback-done or failed")
+	flags.StringVar(&start, "start", "", "start time in RFC3339, inclusive")
+	flags.StringVar(&end, "end", "", "end time in RFC3339, exclusive")
+	flags.Uint32Var(&req.Offset, "offset", 0, "offset")
+	flags.Uint32Var(&req.Limit, "limit", 20, "limit")
+	return listCommand
+}
+
+// parseBlockRef 数字按区块号处理, 否则按区块哈希处理
+func parseBlockRef(arg string) (uint64, string) {
+	if number, err := strconv.ParseUint(arg, 10, 64); err == nil {
+		return number, ""
+	}
+	return 0, arg
+}
+
+func parseTime(arg string) (int64, error) {
+	if arg == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, arg)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid time %s", arg)
+	}
+	return t.Unix(), nil
+}
+
+// printRecord 输出记录, 解码后的源信息按 JSON 格式单独输出
+func printRecord(v interface{}, originInfo string) error {
+	if err := printJSON(v); err != nil {
+		return err
+	}
+	if originInfo == "" {
+		return nil
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(originInfo), "", "  "); err != nil {
+		return err
+	}
+	fmt.Println("origin info:")
+	fmt.Println(out.String())
+	return nil
+}
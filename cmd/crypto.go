@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/fabric-creed/cryptogm/x509"
+	"github.com/fabric-creed/fabric-hub/config"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// cspFlags 与配置文件中的 csp 一致, 也可通过 --config 与 --channel 从网关配置中读取
+type cspFlags struct {
+	csp        config.CSP
+	configPath string
+	channelID  string
+}
+
+func (f *cspFlags) register(cmd *cobra.Command, withKey bool) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.csp.Cert, "cert", "", "certificate of the signing key")
+	flags.StringVar(&f.configPath, "config", "", "hub config file, the csp of --channel is used")
+	flags.StringVar(&f.channelID, "channel", "", "channel id in the hub config file")
+	if !withKey {
+		return
+	}
+	flags.StringVar(&f.csp.PrivateKey, "key", "", "PEM private key")
+	flags.StringVar(&f.csp.KeyStore, "keystore", "", "key store type: file, dir or external")
+	flags.StringVar(&f.csp.KeyStorePath, "keystore-path", "", "directory of the dir key store")
+	flags.StringVar(&f.csp.SKI, "ski", "", "hex SKI of the signing key")
+	flags.StringVar(&f.csp.SignerAddress, "signer-address", "", "socket of the external signer")
+	flags.StringVar(&f.csp.PasswordEnv, "password-env", "", "environment variable holding the key password")
+	flags.StringVar(&f.csp.PasswordFile, "password-file", "", "file holding the key password")
+}
+
+// load 命令行参数优先于配置文件
+func (f *cspFlags) load() (*sw.SimpleCSP, error) {
+	c, err := f.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return c.NewSimpleCSP()
+}
+
+func (f *cspFlags) resolve() (config.CSP, error) {
+	c := f.csp
+	if c.Cert != "" || c.PrivateKey != "" || c.KeyStore != "" {
+		return c, nil
+	}
+	if f.configPath == "" {
+		return c, errors.New("either --cert, --key, --keystore or --config is required")
+	}
+	v := viper.New()
+	v.SetConfigFile(f.configPath)
+	if err := v.ReadInConfig(); err != nil {
+		return c, errors.Wrapf(err, "failed to read config %s", f.configPath)
+	}
+	var vc config.ViperConfig
+	if err := v.Unmarshal(&vc); err != nil {
+		return c, errors.Wrapf(err, "failed to unmarshal config %s", f.configPath)
+	}
+	configured, ok := vc.FindCSP(f.channelID)
+	if !ok {
+		return c, errors.Errorf("channel %s is not found in %s", f.channelID, f.configPath)
+	}
+	return configured, nil
+}
+
+func Sign() *cobra.Command {
+	var (
+		csp      cspFlags
+		in, out  string
+		encoding string
+	)
+	signCommand := &cobra.Command{
+		Use:   "sign",
+		Short: "use to sign a payload file the same way as the hub",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := csp.load()
+			if err != nil {
+				return err
+			}
+			if s.PrivateKey == nil {
+				return errors.New("the signing key is not configured")
+			}
+			payload, err := ioutil.ReadFile(in)
+			if err != nil {
+				return err
+			}
+			// 签名输出到终端时默认以 hex 编码, 写入文件时默认保存原始字节
+			if encoding == "" {
+				encoding = encodingHex
+				if out != "" {
+					encoding = encodingRaw
+				}
+			}
+			if out == "" && encoding == encodingRaw {
+				return errors.New("raw signature can not be printed, use --out or --encoding hex|base64")
+			}
+			signature, err := s.Sign(payload)
+			if err != nil {
+				return errors.Wrap(err, "failed to sign payload")
+			}
+			if signature, err = encodeSignature(signature, encoding); err != nil {
+				return err
+			}
+			if out == "" {
+				fmt.Println(string(signature))
+			} else if err = ioutil.WriteFile(out, signature, 0644); err != nil {
+				return err
+			}
+			fmt.Printf("keyID: %s \nalgorithm: %s \n", s.KeyID(), s.Algorithm())
+			return nil
+		},
+	}
+	csp.register(signCommand, true)
+	flags := signCommand.Flags()
+	flags.StringVar(&in, "in", "", "payload file")
+	flags.StringVar(&out, "out", "", "signature file, printed when empty")
+	flags.StringVar(&encoding, "encoding", "", "signature encoding: raw, hex or base64, hex when printed and raw when written to --out by default")
+	signCommand.MarkFlagRequired("in")
+
+	return signCommand
+}
+
+func Verify() *cobra.Command {
+	var (
+		csp      cspFlags
+		in, sig  string
+		keyID    string
+		encoding string
+	)
+	verifyCommand := &cobra.Command{
+		Use:   "verify",
+		Short: "use to verify the signature of a payload file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := csp.load()
+			if err != nil {
+				return err
+			}
+			payload, err := ioutil.ReadFile(in)
+			if err != nil {
+				return err
+			}
+			signature, err := ioutil.ReadFile(sig)
+			if err != nil {
+				return err
+			}
+			if signature, err = decodeSignature(signature, encoding); err != nil {
+				return err
+			}
+			valid, err := s.VerifyWithKeyID(keyID, "", signature, payload)
+			if err != nil {
+				return errors.Wrap(err, "failed to verify signature")
+			}
+			if !valid {
+				return errors.New("signature is invalid")
+			}
+			fmt.Println("signature is valid")
+			return nil
+		},
+	}
+	csp.register(verifyCommand, false)
+	flags := verifyCommand.Flags()
+	flags.StringVar(&in, "in", "", "payload file")
+	flags.StringVar(&sig, "sig", "", "signature file")
+	flags.StringVar(&keyID, "key-id", "", "key id of the signature, any configured key is tried when empty")
+	flags.StringVar(&encoding, "encoding", encodingRaw, "encoding of the signature file: raw, hex or base64")
+	verifyCommand.MarkFlagRequired("in")
+	verifyCommand.MarkFlagRequired("sig")
+
+	return verifyCommand
+}
+
+func Inspect() *cobra.Command {
+	var (
+		csp     cspFlags
+		in, typ string
+	)
+	inspectCommand := &cobra.Command{
+		Use:   "inspect",
+		Short: "use to decode a hub message in protobuf or JSON and check its signature",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(in)
+			if err != nil {
+				return err
+			}
+			var msg proto.Message
+			switch typ {
+			case "request":
+				msg = &pb.NoTransactionCallRequest{}
+			case "response":
+				msg = &pb.CommonResponseMessage{}
+			default:
+				return errors.Errorf("unknown message type %s", typ)
+			}
+			if err = decodeMessage(data, msg); err != nil {
+				return err
+			}
+			out, err := json.MarshalIndent(msg, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+
+			var payload, signature []byte
+			var keyID, algorithm, channelID string
+			switch m := msg.(type) {
+			case *pb.NoTransactionCallRequest:
+				payload, signature, keyID, algorithm, channelID = m.Payload, m.Signer, m.KeyID, m.Algorithm, m.From
+			case *pb.CommonResponseMessage:
+				payload, signature, keyID, algorithm, channelID = m.Payload, m.Signer, m.KeyID, m.Algorithm, m.To
+			}
+			// 请求由来源链签名, 响应由目的链签名
+			if csp.channelID == "" {
+				csp.channelID = channelID
+			}
+			c, err := csp.resolve()
+			if err != nil {
+				return err
+			}
+			s, err := c.NewSimpleCSP()
+			if err != nil {
+				return err
+			}
+			if c.Cert != "" {
+				if err = printCertificate(c.Cert); err != nil {
+					return err
+				}
+			}
+			if m, ok := msg.(*pb.CommonResponseMessage); ok {
+				for _, signature := range m.Signatures {
+					fmt.Printf("attestation %s keyID: %s, algorithm: %s \n", signature.Signer, signature.KeyID, signature.Algorithm)
+				}
+			}
+			fmt.Printf("message keyID: %s, algorithm: %s \n", keyID, algorithm)
+			fmt.Printf("local keyID: %s, algorithm: %s \n", s.KeyID(), s.Algorithm())
+			valid, err := s.VerifyWithKeyID(keyID, algorithm, signature, payload)
+			if err != nil {
+				return errors.Wrap(err, "failed to verify signature")
+			}
+			if !valid {
+				return errors.New("signature is invalid")
+			}
+			fmt.Println("signature is valid")
+			return nil
+		},
+	}
+	csp.register(inspectCommand, false)
+	flags := inspectCommand.Flags()
+	flags.StringVar(&in, "in", "", "message file")
+	flags.StringVar(&typ, "type", "request", "message type: request (NoTransactionCallRequest) or response (CommonResponseMessage)")
+	inspectCommand.MarkFlagRequired("in")
+
+	return inspectCommand
+}
+
+const (
+	encodingRaw    = "raw"
+	encodingHex    = "hex"
+	encodingBase64 = "base64"
+)
+
+func encodeSignature(signature []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case encodingRaw:
+		return signature, nil
+	case encodingHex:
+		return []byte(hex.EncodeToString(signature)), nil
+	case encodingBase64:
+		return []byte(base64.StdEncoding.EncodeToString(signature)), nil
+	default:
+		return nil, errors.Errorf("unknown signature encoding %s", encoding)
+	}
+}
+
+func decodeSignature(data []byte, encoding string) ([]byte, error) {
+	var (
+		signature []byte
+		err       error
+	)
+	switch encoding {
+	case encodingRaw:
+		return data, nil
+	case encodingHex:
+		signature, err = hex.DecodeString(string(bytes.TrimSpace(data)))
+	case encodingBase64:
+		signature, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	default:
+		return nil, errors.Errorf("unknown signature encoding %s", encoding)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signature")
+	}
+	return signature, nil
+}
+
+// decodeMessage 以 { 开头的按 JSON 解析, 否则按 protobuf 解析
+func decodeMessage(data []byte, msg proto.Message) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, msg); err != nil {
+			return errors.Wrap(err, "failed to decode JSON message")
+		}
+		return nil
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrap(err, "failed to decode protobuf message")
+	}
+	return nil
+}
+
+func printCertificate(path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return errors.Errorf("failed to find any PEM data in %s", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse certificate %s", path)
+	}
+	key, err := sw.ParsePublicByCertificate(raw)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("subject: %s \nissuer: %s \nSKI: %s \nsubject key id: %s \n",
+		cert.Subject.String(), cert.Issuer.String(), sw.KeyID(key), hex.EncodeToString(cert.SubjectKeyId))
+	return nil
+}
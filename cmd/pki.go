@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/fabric-creed/fabric-hub/pkg/common/crypto/tlsgen"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type pkiOptions struct {
+	output      string
+	host        string
+	isGM        bool
+	attestors   []string
+	passwordEnv string
+	port        int64
+}
+
+func GeneratePKI() *cobra.Command {
+	opts := &pkiOptions{}
+	pkiCommand := &cobra.Command{
+		Use:   "pki",
+		Short: "use to generate the certificates, keys and a starter config of a hub",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return generatePKI(opts)
+		},
+	}
+	flags := pkiCommand.Flags()
+	flags.StringVarP(&opts.output, "output", "o", "./crypto", "output directory, the paths in config.yaml are relative to the working directory")
+	flags.StringVar(&opts.host, "host", "localhost", "host name or ip of the hub server certificate")
+	flags.BoolVar(&opts.isGM, "gm", false, "generate SM2 certificates instead of ECDSA")
+	flags.StringSliceVar(&opts.attestors, "attestors", nil, "names of the attestor signing identities, e.g. org1,org2")
+	flags.StringVar(&opts.passwordEnv, "password-env", "", "encrypt the signing keys with the password in this environment variable")
+	flags.Int64Var(&opts.port, "port", 1000, "port of the hub grpc server")
+
+	return pkiCommand
+}
+
+// pkiFiles 生成的文件路径, 同时用于渲染 config.yaml
+type pkiFiles struct {
+	CACert           string
+	CAKey            string
+	IntermediateCert string
+	IntermediateKey  string
+	CAChain          string
+	ServerCert       string
+	ServerKey        string
+	ClientCert       string
+	ClientKey        string
+	SignerCert       string
+	SignerKey        string
+	Attestors        []pkiAttestor
+	PasswordEnv      string
+	IsGM             bool
+	Host             string
+	Port             int64
+	LocalChannelID   int64
+	RemoteChannelID  int64
+}
+
+type pkiAttestor struct {
+	Name string
+	Cert string
+	Key  string
+}
+
+func generatePKI(opts *pkiOptions) error {
+	var password []byte
+	if opts.passwordEnv != "" {
+		password = []byte(os.Getenv(opts.passwordEnv))
+		if len(password) == 0 {
+			return errors.Errorf("the environment variable %s is empty", opts.passwordEnv)
+		}
+	}
+	for _, dir := range []string{"ca", "tls", "csp"} {
+		if err := os.MkdirAll(filepath.Join(opts.output, dir), 0755); err != nil {
+			return err
+		}
+	}
+	files := &pkiFiles{
+		CACert:           filepath.Join(opts.output, "ca", "ca.crt"),
+		CAKey:            filepath.Join(opts.output, "ca", "ca.key"),
+		IntermediateCert: filepath.Join(opts.output, "ca", "intermediate-ca.crt"),
+		IntermediateKey:  filepath.Join(opts.output, "ca", "intermediate-ca.key"),
+		CAChain:          filepath.Join(opts.output, "ca", "ca-chain.crt"),
+		ServerCert:       filepath.Join(opts.output, "tls", "server.crt"),
+		ServerKey:        filepath.Join(opts.output, "tls", "server.key"),
+		ClientCert:       filepath.Join(opts.output, "tls", "client.crt"),
+		ClientKey:        filepath.Join(opts.output, "tls", "client.key"),
+		SignerCert:       filepath.Join(opts.output, "csp", "signer.crt"),
+		SignerKey:        filepath.Join(opts.output, "csp", "signer.key"),
+		PasswordEnv:      opts.passwordEnv,
+		IsGM:             opts.isGM,
+		Host:             opts.host,
+		Port:             opts.port,
+	}
+
+	rootCA, err := tlsgen.NewCA(opts.isGM)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate ca")
+	}
+	intermediateCA, err := rootCA.NewIntermediateCA(opts.isGM)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate intermediate ca")
+	}
+	// 证书链同时包含中间 CA 与根 CA, 作为服务端与客户端的信任根
+	chain := append(append([]byte{}, intermediateCA.CertBytes()...), rootCA.CertBytes()...)
+	if err = writeFiles(map[string][]byte{
+		files.CACert:           rootCA.CertBytes(),
+		files.CAKey:            rootCA.KeyBytes(),
+		files.IntermediateCert: intermediateCA.CertBytes(),
+		files.IntermediateKey:  intermediateCA.KeyBytes(),
+		files.CAChain:          chain,
+	}); err != nil {
+		return err
+	}
+
+	server, err := intermediateCA.NewServerCertKeyPair(opts.host, opts.isGM)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate server certificate")
+	}
+	client, err := intermediateCA.NewClientCertKeyPair(opts.isGM)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate client certificate")
+	}
+	if err = writeFiles(map[string][]byte{
+		files.ServerCert: server.Cert,
+		files.ServerKey:  server.Key,
+		files.ClientCert: client.Cert,
+		files.ClientKey:  client.Key,
+	}); err != nil {
+		return err
+	}
+
+	if err = writeSigningIdentity(intermediateCA, opts.isGM, password, files.SignerCert, files.SignerKey); err != nil {
+		return err
+	}
+	for _, name := range opts.attestors {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		attestor := pkiAttestor{
+			Name: name,
+			Cert: filepath.Join(opts.output, "csp", name+".crt"),
+			Key:  filepath.Join(opts.output, "csp", name+".key"),
+		}
+		if err = writeSigningIdentity(intermediateCA, opts.isGM, password, attestor.Cert, attestor.Key); err != nil {
+			return err
+		}
+		files.Attestors = append(files.Attestors, attestor)
+	}
+
+	nodeID, err := lower16BitPrivateIP()
+	if err != nil {
+		nodeID = 0
+	}
+	node, err := snowflake.NewNode(nodeID)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate id")
+	}
+	files.LocalChannelID = node.Generate().Int64()
+	files.RemoteChannelID = node.Generate().Int64()
+
+	configPath := filepath.Join(opts.output, "config.yaml")
+	f, err := os.OpenFile(configPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err = configTemplate.Execute(f, files); err != nil {
+		return errors.Wrap(err, "failed to render config.yaml")
+	}
+
+	fmt.Printf("pki files are written to %s, starter config: %s \n", opts.output, configPath)
+	return nil
+}
+
+// writeSigningIdentity 签发 csp 使用的签名身份, 指定口令时私钥以加密的 PKCS#8 保存
+func writeSigningIdentity(ca tlsgen.CA, isGM bool, password []byte, certPath, keyPath string) error {
+	pair, err := ca.NewClientCertKeyPair(isGM)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing identity")
+	}
+	key := pair.Key
+	if len(password) != 0 {
+		if key, err = pair.EncryptedKey(password); err != nil {
+			return errors.Wrap(err, "failed to encrypt signing key")
+		}
+	}
+	return writeFiles(map[string][]byte{
+		certPath: pair.Cert,
+		keyPath:  key,
+	})
+}
+
+func writeFiles(files map[string][]byte) error {
+	for path, data := range files {
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(path, ".key") {
+			mode = 0600
+		}
+		if err := ioutil.WriteFile(path, data, mode); err != nil {
+			return errors.Wrapf(err, "failed to write %s", path)
+		}
+	}
+	return nil
+}
+
+var configTemplate = template.Must(template.New("config").Parse(`dbPath: ./store
+
+# 需要连接到远端网关的配置, 地址、证书与通道需按对端网关修改
+remoteFabricNamespace:
+  - name: remote-fabric
+    address: remote.example.com
+    port: 1000
+    clientConfig:
+      useTLS: true
+      clientCertPath: {{.ClientCert}}
+      clientKeyPath: {{.ClientKey}}
+      clientRootCACertPath: {{.CAChain}}
+      # 替换为对端网关的 CA 证书链
+      serverRootCAPath: {{.CAChain}}
+      isGm: {{.IsGM}}
+    # 替换为对端网关的签名证书
+    csp:
+      cert: {{.SignerCert}}
+    channels:
+      - name: mychannel
+        id: {{.RemoteChannelID}}
+
+# 本地通道相关配置
+localFabricNamespace:
+  - name: local-fabric
+    # 用于对消息进行签名
+    csp:
+      privateKey: {{.SignerKey}}
+      cert: {{.SignerCert}}
+{{- if .PasswordEnv}}
+      passwordEnv: {{.PasswordEnv}}
+{{- end}}
+    fabricConfigPath: ./fabric.yaml
+    organization: org1
+    user: Admin
+    channels:
+      - name: mychannel
+        id: {{.LocalChannelID}}
+        proxyChainCodeName: proxy
+        routerChainCodeName: router
+    isGM: {{.IsGM}}
+{{- if .Attestors}}
+    # 各组织的签名身份, 对跨链响应进行多签
+    attestors:
+{{- range .Attestors}}
+      - name: {{.Name}}
+        csp:
+          privateKey: {{.Key}}
+          cert: {{.Cert}}
+{{- if $.PasswordEnv}}
+          passwordEnv: {{$.PasswordEnv}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+# 网关grpc server配置
+serverConfig:
+  useTLS: true
+  serverKeyPath: {{.ServerKey}}
+  serverCertPath: {{.ServerCert}}
+  serverRootCAPath: {{.CAChain}}
+  requireClientAuth: true
+  clientRootCAPath:
+    - {{.CAChain}}
+  port: {{.Port}}
+`))
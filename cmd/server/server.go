@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"github.com/fabric-creed/fabric-hub/config"
 	"github.com/fabric-creed/fabric-hub/global"
 	"github.com/fabric-creed/fabric-hub/pkg/adopter"
 	"github.com/fabric-creed/fabric-hub/pkg/adopter/fabric"
 	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/cursor"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/deadletter"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/inbound"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
 	"github.com/fabric-creed/fabric-hub/pkg/service"
 	"github.com/fabric-creed/grpc/reflection"
@@ -13,48 +21,129 @@ import (
 )
 
 func main() {
-	so, err := cgrpc.ServerSecureOptions(
-		global.Config.GRPCServerConfig.UseTLS,
-		global.Config.GRPCServerConfig.ServerCertPath,
-		global.Config.GRPCServerConfig.ServerKeyPath,
-		global.Config.GRPCServerConfig.ServerCertPath,
-		global.Config.GRPCServerConfig.RequireClientAuth,
-		global.Config.GRPCServerConfig.ClientRootCAPath,
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	grpcServer, err := cgrpc.NewGRPCServer(fmt.Sprintf(":%d", global.Config.GRPCServerConfig.Port),
-		cgrpc.ServerConfig{
-			SecOpts:           so,
-			ConnectionTimeout: cgrpc.DefaultConnectionTimeout,
-			KaOpts:            cgrpc.DefaultKeepaliveOptions,
-		})
+	grpcServer, err := newGRPCServer(global.Config.GRPCServerConfig)
 	if err != nil {
 		panic(err)
 	}
 
-	pb.RegisterHubServer(grpcServer.Server(), service.NewHubService(
+	hubService := service.NewHubService(
 		service.WithCSP(global.Config.CSPManager),
 		service.WithChannelManager(global.Config.LocalChannelManager),
 		service.WithFabricManager(global.Config.FabricClientManager),
 		service.WithHubClient(global.Config.HubClientManager),
-	))
+		service.WithAttestors(global.Config.AttestorManager),
+		service.WithHeaderChains(global.Config.HeaderChainManager),
+		service.WithEndorsementVerifiers(global.Config.EndorsementVerifierManager),
+		service.WithDBPath(global.Config.DBPath),
+	)
+	pb.RegisterHubServer(grpcServer.Server(), hubService)
 	reflection.Register(grpcServer.Server())
 
 	log.Printf("grpc server is starting, listen on %d \n", global.Config.GRPCServerConfig.Port)
 
+	// 只在启动时压缩存在删除操作的数据文件, 需在打开数据文件前执行
+	if global.Config.CompactOnStart {
+		for _, name := range []string{block.DBName, deadletter.DBName, inbound.DBName} {
+			if err = database.CompactDB(global.Config.DBPath, name); err != nil {
+				panic(err)
+			}
+			log.Printf("%s is compacted \n", name)
+		}
+	}
+	// 本地通道ID字段由 ChannelName 重命名为 ChannelID, 迁移旧版本保存的记录
+	for name, migrate := range map[string]func() (int, error){
+		cursor.DBName:     cursor.NewController(global.Config.DBPath).MigrateChannelID,
+		outbox.DBName:     outbox.NewController(global.Config.DBPath).MigrateChannelID,
+		deadletter.DBName: deadletter.NewController(global.Config.DBPath).MigrateChannelID,
+	} {
+		n, err := migrate()
+		if err != nil {
+			panic(err)
+		}
+		if n > 0 {
+			log.Printf("%d records of %s are migrated \n", n, name)
+		}
+	}
+
+	tasks := make(map[string]*adopter.CrossChainTask)
 	for id, channel := range global.Config.LocalChannelManager {
 		fab := global.Config.FabricClientManager[id]
-		fabAdopter := fabric.NewFabric(global.Config.DBPath, channel.Name, channel.RouterChainCodeName, channel.IsGM,
+		options := []fabric.Option{
 			fabric.WithFabricClient(fab),
-			fabric.WithHubClientMap(global.Config.HubClientManager))
+			fabric.WithHubClientMap(global.Config.HubClientManager),
+			fabric.WithStartBlock(channel.StartBlock, channel.StartFromLatest),
+		}
+		// 按通道划分前保存的区块不区分通道, 只有单通道时沿用
+		if len(global.Config.LocalChannelManager) == 1 {
+			options = append(options, fabric.WithLegacyBlockStore())
+		}
+		switch channel.BlockSource {
+		case "", fabric.BlockSourcePoll:
+		case fabric.BlockSourceEvent:
+			options = append(options, fabric.WithBlockEvents(channel.MaxEventGap))
+		default:
+			panic(fmt.Errorf("unknown block source %s of channel %s", channel.BlockSource, id))
+		}
+		switch channel.BlockStore {
+		case "", fabric.BlockStoreFull:
+		case fabric.BlockStoreCheckpoint:
+			options = append(options, fabric.WithCheckpointStore())
+		default:
+			panic(fmt.Errorf("unknown block store %s of channel %s", channel.BlockStore, id))
+		}
+		options = append(options, fabric.WithBlockRetention(channel.RetainBlocks, channel.RetainDays))
+		// 不同网络的通道可能同名, 存储与管理接口按网关配置中的通道ID区分
+		fabAdopter := fabric.NewFabric(global.Config.DBPath, id, channel.Name, channel.RouterChainCodeName, channel.IsGM, options...)
 
-		go adopter.NewCrossChainTask(fabAdopter).Run()
+		tasks[id] = adopter.NewCrossChainTask(fabAdopter, adopter.WithWorkers(channel.Workers))
+		go tasks[id].Run(context.Background())
+	}
+
+	for _, task := range global.Config.HeaderSyncTasks {
+		go task.Run(context.Background())
+	}
+
+	// 管理接口独立监听, 不对远端网关开放
+	if global.Config.AdminServerConfig.Port != 0 {
+		adminServer, err := newGRPCServer(global.Config.AdminServerConfig)
+		if err != nil {
+			panic(err)
+		}
+		pb.RegisterAdminServer(adminServer.Server(), service.NewAdminService(global.Config.DBPath,
+			service.WithCrossChainTasks(tasks),
+			service.WithHubService(hubService),
+		))
+		log.Printf("admin grpc server is starting, listen on %s:%d \n",
+			global.Config.AdminServerConfig.Host, global.Config.AdminServerConfig.Port)
+		go func() {
+			if err := adminServer.Start(); err != nil {
+				panic(err)
+			}
+		}()
 	}
 
 	if err := grpcServer.Start(); err != nil {
 		panic(err)
 	}
 }
+
+func newGRPCServer(c config.ServerConfig) (*cgrpc.GRPCServer, error) {
+	so, err := cgrpc.ServerSecureOptions(
+		c.UseTLS,
+		c.ServerCertPath,
+		c.ServerKeyPath,
+		c.ServerRootCAPath,
+		c.RequireClientAuth,
+		c.ClientRootCAPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return cgrpc.NewGRPCServer(fmt.Sprintf("%s:%d", c.Host, c.Port),
+		cgrpc.ServerConfig{
+			SecOpts:           so,
+			ConnectionTimeout: cgrpc.DefaultConnectionTimeout,
+			KaOpts:            cgrpc.DefaultKeepaliveOptions,
+		})
+}
@@ -11,6 +11,10 @@ import (
 func main() {
 	command := &cobra.Command{}
 	command.AddCommand(GenerateID())
+	command.AddCommand(GeneratePKI())
+	command.AddCommand(Sign())
+	command.AddCommand(Verify())
+	command.AddCommand(Inspect())
 	err := command.Execute()
 	if err != nil {
 		panic(err)
@@ -3,12 +3,16 @@ package config
 type ViperConfig struct {
 	// 存储路径
 	DBPath string `json:"dbPath" yaml:"dbPath"`
+	// 启动时压缩区块、死信及入站执行记录数据文件, 回收已删除数据的空间, 运行期间不压缩
+	CompactOnStart bool `json:"compactOnStart" yaml:"compactOnStart"`
 	// 远端跨链网关
 	RemoteFabricNamespace []RemoteFabricNamespace `json:"remoteFabricNamespace" yaml:"remoteFabricNamespace"`
 	// 本地跨链网关可负责的fabric
 	LocalFabricNamespace []LocalFabricNamespace `json:"localFabricNamespace" yaml:"localFabricNamespace"`
 	// 服务配置
 	ServerConfig ServerConfig `json:"serverConfig" yaml:"serverConfig"`
+	// 管理接口服务配置, 端口为 0 时不开启
+	AdminServerConfig ServerConfig `json:"adminServerConfig" yaml:"adminServerConfig"`
 }
 
 type RemoteFabricNamespace struct {
@@ -18,6 +22,22 @@ type RemoteFabricNamespace struct {
 	ClientConfig ClientConfig `json:"clientConfig" yaml:"clientConfig"`
 	Channels     []Channel    `json:"channels" yaml:"channels"`
 	CSP          CSP          `json:"csp" yaml:"csp"`
+	// 远端网关的多个节点, 配置后忽略 address 与 port
+	Endpoints []Endpoint `json:"endpoints" yaml:"endpoints"`
+	// 节点调用失败后被摘除的时间, 单位秒, 为 0 时使用默认值
+	EjectTimeout int `json:"ejectTimeout" yaml:"ejectTimeout"`
+	// 多签门限策略, 为空时只校验网关签名. 各签名均由远端网关发起, 门限不能防范远端网关被攻破
+	Attestation Attestation `json:"attestation" yaml:"attestation"`
+	// 背书证明校验, 为空时不校验远端节点背书
+	Endorsement Endorsement `json:"endorsement" yaml:"endorsement"`
+	// 区块头同步, 用于维护远端通道经核实的区块头链
+	HeaderSync HeaderSync `json:"headerSync" yaml:"headerSync"`
+	// 调用远端网关的重试策略, 为空时使用默认值
+	Retry Retry `json:"retry" yaml:"retry"`
+	// 远端网关的熔断策略, 为空时使用默认值
+	CircuitBreaker CircuitBreaker `json:"circuitBreaker" yaml:"circuitBreaker"`
+	// 调用远端网关的默认超时时间, 单位毫秒, 调用方未设置截止时间时生效, 为 0 时不限制
+	Timeout int `json:"timeout" yaml:"timeout"`
 }
 
 type LocalFabricNamespace struct {
@@ -34,6 +54,92 @@ type LocalFabricNamespace struct {
 	CSP CSP `json:"csp" yaml:"csp"`
 	// 是否为国密
 	IsGM bool `json:"isGM" yaml:"isGM"`
+	// 各组织的签名身份, 对跨链响应进行多签. 全部签名均由本网关发起, 即使以 external 密钥库将私钥
+	// 交由各组织的签名进程保存, 网关被攻破时仍可请求对任意响应签名, 多签只能防范单个私钥泄露
+	Attestors []Attestor `json:"attestors" yaml:"attestors"`
+}
+
+type Attestor struct {
+	// 签名身份名称, 一般为组织名称, 需与对端配置一致
+	Name string `json:"name" yaml:"name"`
+	CSP  CSP    `json:"csp" yaml:"csp"`
+}
+
+type Attestation struct {
+	// 至少需要的有效签名数
+	Threshold int `json:"threshold" yaml:"threshold"`
+	// 各组织签名身份的验签证书
+	Signers []Attestor `json:"signers" yaml:"signers"`
+}
+
+type Endorsement struct {
+	// 远端通道各组织的 MSP
+	MSPs []MSP `json:"msps" yaml:"msps"`
+	// 至少需要背书的组织数, 为 0 时需要全部组织背书
+	Threshold int `json:"threshold" yaml:"threshold"`
+	// 是否为国密
+	IsGM bool `json:"isGM" yaml:"isGM"`
+}
+
+type MSP struct {
+	ID string `json:"id" yaml:"id"`
+	// 根证书路径
+	RootCerts []string `json:"rootCerts" yaml:"rootCerts"`
+	// 中间证书路径
+	IntermediateCerts []string `json:"intermediateCerts" yaml:"intermediateCerts"`
+}
+
+type HeaderSync struct {
+	Enable bool `json:"enable" yaml:"enable"`
+	// 同步间隔, 单位秒
+	Interval int `json:"interval" yaml:"interval"`
+	// 是否为国密
+	IsGM bool `json:"isGM" yaml:"isGM"`
+	// 各通道的信任锚点, 启用时每个通道都需配置, 信任创世区块时区块号为 0
+	Anchors []TrustAnchor `json:"anchors" yaml:"anchors"`
+	// 排序节点组织, 用于核实区块签名, 启用时必须配置
+	Orderer Endorsement `json:"orderer" yaml:"orderer"`
+}
+
+type Endpoint struct {
+	Address string `json:"address" yaml:"address"`
+	Port    uint32 `json:"port" yaml:"port"`
+	// 优先级, 数值越小越优先
+	Priority int `json:"priority" yaml:"priority"`
+	// 同一优先级内的权重, 为 0 时视为 1
+	Weight int `json:"weight" yaml:"weight"`
+	// TLS 校验证书时使用的服务端名称, 为空时使用地址
+	ServerNameOverride string `json:"serverNameOverride" yaml:"serverNameOverride"`
+}
+
+type Retry struct {
+	// 最大尝试次数, 包含首次调用
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// 首次重试间隔, 单位毫秒
+	InitialBackoff int `json:"initialBackoff" yaml:"initialBackoff"`
+	// 最大重试间隔, 单位毫秒
+	MaxBackoff int `json:"maxBackoff" yaml:"maxBackoff"`
+	// 重试间隔的增长倍数
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+	// 重试间隔的随机抖动比例, 如 0.2
+	Jitter float64 `json:"jitter" yaml:"jitter"`
+	// 可重试的 grpc 状态码, 如 UNAVAILABLE、DEADLINE_EXCEEDED, 非幂等的 NoTransactionCall 在请求发出后不重试
+	RetryableCodes []string `json:"retryableCodes" yaml:"retryableCodes"`
+}
+
+type CircuitBreaker struct {
+	// 连续失败多少次后熔断, 为负数时不熔断
+	FailureThreshold int `json:"failureThreshold" yaml:"failureThreshold"`
+	// 熔断持续时间, 单位秒, 到期后放行一次探测调用
+	OpenTimeout int `json:"openTimeout" yaml:"openTimeout"`
+}
+
+type TrustAnchor struct {
+	ChannelID string `json:"channelID" yaml:"channelID"`
+	// 区块号
+	Number uint64 `json:"number" yaml:"number"`
+	// 区块哈希(hex)
+	Hash string `json:"hash" yaml:"hash"`
 }
 
 type Channel struct {
@@ -45,29 +151,47 @@ type Channel struct {
 	ProxyChainCodeName string `json:"proxyChainCodeName" yaml:"proxyChainCodeName"`
 	// 路由合约名称
 	RouterChainCodeName string `json:"routerChainCodeName" yaml:"routerChainCodeName"`
+	// 并发处理跨链请求的工作协程数, 为 0 时使用默认值
+	Workers int `json:"workers" yaml:"workers"`
+	// 区块获取方式: poll(默认, 轮询 QueryBlock) 或 event(订阅区块事件)
+	BlockSource string `json:"blockSource" yaml:"blockSource"`
+	// 区块事件模式下落后超过该区块数时先通过 QueryBlock 追赶, 为 0 时使用默认值
+	MaxEventGap uint64 `json:"maxEventGap" yaml:"maxEventGap"`
+	// 未保存处理进度时的起始区块, 为 0 时从区块 1 开始
+	StartBlock uint64 `json:"startBlock" yaml:"startBlock"`
+	// 未保存处理进度时是否从账本当前高度开始, 跳过历史区块
+	StartFromLatest bool `json:"startFromLatest" yaml:"startFromLatest"`
+	// 区块保存方式: full(默认, 保存完整区块) 或 checkpoint(只保存处理进度的检查点及跨链交易)
+	BlockStore string `json:"blockStore" yaml:"blockStore"`
+	// 完整区块的保留区块数, 为 0 时不按区块数清理
+	RetainBlocks uint64 `json:"retainBlocks" yaml:"retainBlocks"`
+	// 完整区块的保留天数, 为 0 时不按天数清理
+	RetainDays int `json:"retainDays" yaml:"retainDays"`
 	// 是否为国密
 	IsGM bool
 }
 
 type ServerConfig struct {
 	// 开启
-	UseTLS bool `json:useTLS" yaml:"useTLS"`
+	UseTLS bool `json:"useTLS" yaml:"useTLS"`
 	// key 路径
-	ServerKeyPath string `json:serverKeyPath" yaml:"serverKeyPath"`
+	ServerKeyPath string `json:"serverKeyPath" yaml:"serverKeyPath"`
 	// cert 路径
-	ServerCertPath string `json:serverCertPath" yaml:"serverCertPath"`
+	ServerCertPath string `json:"serverCertPath" yaml:"serverCertPath"`
 	// Ca cert 路径
-	ServerRootCAPath string `json:serverRootCAPath" yaml:"serverRootCAPath"`
+	ServerRootCAPath string `json:"serverRootCAPath" yaml:"serverRootCAPath"`
 	// 是否开启双向校验
 	RequireClientAuth bool `json:"requireClientAuth" yaml:"requireClientAuth"`
 	// client ca 路径
 	ClientRootCAPath []string `json:"clientRootCAPath" yaml:"clientRootCAPath"`
 	// 端口
 	Port int64 `json:"port" yaml:"port"`
+	// 监听地址, 为空时监听全部地址, 管理接口默认只监听本机
+	Host string `json:"host" yaml:"host"`
 }
 
 type ClientConfig struct {
-	UseTLS bool `json:useTLS" yaml:"useTLS"`
+	UseTLS bool `json:"useTLS" yaml:"useTLS"`
 	// key 路径
 	ClientKeyPath string `json:"clientKeyPath" yaml:"clientKeyPath"`
 	// cert 路径
@@ -83,4 +207,26 @@ type ClientConfig struct {
 type CSP struct {
 	Cert       string `json:"cert" yaml:"cert"`
 	PrivateKey string `json:"privateKey" yaml:"privateKey"`
+	// 密钥库类型: file(默认)、dir、external
+	KeyStore string `json:"keyStore" yaml:"keyStore"`
+	// dir 密钥库目录, 私钥文件以 SKI 命名
+	KeyStorePath string `json:"keyStorePath" yaml:"keyStorePath"`
+	// 签名私钥的 SKI(hex), 为空时使用证书公钥的 SKI
+	SKI string `json:"ski" yaml:"ski"`
+	// 外部签名进程的 socket 地址
+	SignerAddress string `json:"signerAddress" yaml:"signerAddress"`
+	// 加密私钥口令所在的环境变量
+	PasswordEnv string `json:"passwordEnv" yaml:"passwordEnv"`
+	// 加密私钥口令文件
+	PasswordFile string `json:"passwordFile" yaml:"passwordFile"`
+	// 额外的验签证书, 用于密钥轮换期间同时接受新旧密钥
+	VerifyKeys []VerifyKey `json:"verifyKeys" yaml:"verifyKeys"`
+}
+
+type VerifyKey struct {
+	Cert string `json:"cert" yaml:"cert"`
+	// 生效时间, RFC3339 格式, 为空表示不限制
+	NotBefore string `json:"notBefore" yaml:"notBefore"`
+	// 失效时间, RFC3339 格式, 为空表示不限制
+	NotAfter string `json:"notAfter" yaml:"notAfter"`
 }
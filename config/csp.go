@@ -0,0 +1,90 @@
+package config
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/pkg/errors"
+)
+
+// NewSimpleCSP 根据配置选择密钥库, 加密私钥的口令来自环境变量或口令文件
+func (c CSP) NewSimpleCSP() (*sw.SimpleCSP, error) {
+	password, err := sw.ReadPassword(c.PasswordEnv, c.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+	var ski []byte
+	if c.SKI != "" {
+		ski, err = hex.DecodeString(c.SKI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ski %s", c.SKI)
+		}
+	}
+	csp, err := sw.NewSimpleCSPWithOpts(sw.KeyStoreOpts{
+		Type:          c.KeyStore,
+		KeyPath:       c.PrivateKey,
+		CertPath:      c.Cert,
+		Dir:           c.KeyStorePath,
+		SKI:           ski,
+		SignerAddress: c.SignerAddress,
+		Password:      password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vk := range c.VerifyKeys {
+		key, err := vk.NewVerifyKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid verify key %s", vk.Cert)
+		}
+		if err = csp.AddVerifyKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return csp, nil
+}
+
+func (vk VerifyKey) NewVerifyKey() (*sw.VerifyKey, error) {
+	cert, err := ioutil.ReadFile(vk.Cert)
+	if err != nil {
+		return nil, err
+	}
+	key, err := sw.ParsePublicByCertificate(cert)
+	if err != nil {
+		return nil, err
+	}
+	verifyKey := &sw.VerifyKey{Key: key}
+	if vk.NotBefore != "" {
+		if verifyKey.NotBefore, err = time.Parse(time.RFC3339, vk.NotBefore); err != nil {
+			return nil, err
+		}
+	}
+	if vk.NotAfter != "" {
+		if verifyKey.NotAfter, err = time.Parse(time.RFC3339, vk.NotAfter); err != nil {
+			return nil, err
+		}
+	}
+	return verifyKey, nil
+}
+
+// FindCSP 返回本地或远端通道所在 namespace 的 CSP
+func (c ViperConfig) FindCSP(channelID string) (CSP, bool) {
+	for _, namespace := range c.LocalFabricNamespace {
+		for _, channel := range namespace.Channels {
+			if channel.ID == channelID {
+				return namespace.CSP, true
+			}
+		}
+	}
+	for _, namespace := range c.RemoteFabricNamespace {
+		for _, channel := range namespace.Channels {
+			if channel.ID == channelID {
+				return namespace.CSP, true
+			}
+		}
+	}
+	return CSP{}, false
+}
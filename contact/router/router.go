@@ -50,6 +50,11 @@ func (r *RouterContract) ChainCodeInvoke(ctx contractapi.TransactionContextInter
 		return "", errors.Wrapf(err, "failed to put state by %s, data:%s",
 			fmt.Sprintf(RequestKey, transactionID, stepID), string(requestData))
 	}
+	// 网关从合约事件中获取跨链请求. Fabric 只保留交易最外层合约设置的事件,
+	// 经其他合约调用本合约时该事件不会写入交易, 跨链请求须直接调用路由合约发起
+	if err = stub.SetEvent(CrossChainRequestEvent, requestData); err != nil {
+		return "", errors.Wrap(err, "failed to set cross chain request event")
+	}
 
 	return transactionID, nil
 }
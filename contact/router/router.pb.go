@@ -3,6 +3,8 @@ package main
 const (
 	RequestKey        = "request-%s-%s"
 	CallbackResultKey = "callbackResult-%s-%s"
+	// 跨链请求事件名称, 网关通过该事件发现跨链请求
+	CrossChainRequestEvent = "CrossChainRequest"
 )
 
 type ChainCodeInvokeRequest struct {
@@ -13,7 +15,7 @@ type ChainCodeInvokeRequest struct {
 	// 交易ID
 	TransactionID string `json:"transactionId"`
 	// 步骤ID
-	StepID string `json:"transactionId"`
+	StepID string `json:"stepID"`
 	// 跨链内容
 	Payload string `json:"payload"`
 	// 签名
@@ -1,26 +1,39 @@
 package global
 
 import (
+	"encoding/hex"
 	"fmt"
 	"github.com/fabric-creed/fabric-hub/config"
 	"github.com/fabric-creed/fabric-hub/pkg/client"
 	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
 	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/lightclient"
+	"github.com/fabric-creed/grpc/codes"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"io/ioutil"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var Config = Configuration{
-	LocalChannelManager: make(map[string]config.Channel, 0),
-	HubClientManager:    make(map[string]*client.HubClient, 0),
-	CSPManager:          make(map[string]*sw.SimpleCSP, 0),
-	FabricClientManager: make(map[string]*fabric.Client, 0),
+	LocalChannelManager:        make(map[string]config.Channel, 0),
+	HubClientManager:           make(map[string]*client.HubClient, 0),
+	CSPManager:                 make(map[string]*sw.SimpleCSP, 0),
+	FabricClientManager:        make(map[string]*fabric.Client, 0),
+	AttestorManager:            make(map[string][]*client.Attestor, 0),
+	AttestationPolicyManager:   make(map[string]*client.AttestationPolicy, 0),
+	EndorsementVerifierManager: make(map[string]*client.EndorsementVerifier, 0),
+	HeaderChainManager:         make(map[string]*lightclient.HeaderChain, 0),
 }
 
 type Configuration struct {
 	// bbolt 存储路径
 	DBPath string
+	// 启动时压缩区块数据文件
+	CompactOnStart bool
 	// fabric客户端管理器
 	FabricClientManager map[string]*fabric.Client
 	// hub客户端管理器
@@ -29,8 +42,20 @@ type Configuration struct {
 	LocalChannelManager map[string]config.Channel
 	// grpc server配置
 	GRPCServerConfig config.ServerConfig
+	// 管理接口 grpc server配置
+	AdminServerConfig config.ServerConfig
 	// 各链的公私钥对,用于签名和验牵
 	CSPManager map[string]*sw.SimpleCSP
+	// 本地通道各组织的签名身份
+	AttestorManager map[string][]*client.Attestor
+	// 远端通道的多签门限策略
+	AttestationPolicyManager map[string]*client.AttestationPolicy
+	// 远端通道的背书证明校验器
+	EndorsementVerifierManager map[string]*client.EndorsementVerifier
+	// 远端通道经核实的区块头链
+	HeaderChainManager map[string]*lightclient.HeaderChain
+	// 区块头同步任务
+	HeaderSyncTasks []*lightclient.SyncTask
 }
 
 func init() {
@@ -49,6 +74,7 @@ func init() {
 	if vc.DBPath == "" {
 		Config.DBPath = "./store"
 	}
+	Config.CompactOnStart = vc.CompactOnStart
 
 	parseRemoteNamespaceConfig(vc.RemoteFabricNamespace)
 
@@ -57,6 +83,10 @@ func init() {
 	setHubClientCSP()
 
 	Config.GRPCServerConfig = vc.ServerConfig
+	Config.AdminServerConfig = vc.AdminServerConfig
+	if Config.AdminServerConfig.Host == "" {
+		Config.AdminServerConfig.Host = "127.0.0.1"
+	}
 }
 
 func parseRemoteNamespaceConfig(namespaces []config.RemoteFabricNamespace) {
@@ -76,27 +106,85 @@ func parseRemoteNamespaceConfig(namespaces []config.RemoteFabricNamespace) {
 			namespace.ClientConfig.IsGm,
 		)
 		if err != nil {
-			panic(errors.Wrapf(err, "failed to create grpc client:%s", namespace.ClientConfig))
+			panic(errors.Wrapf(err, "failed to create grpc client:%+v", namespace.ClientConfig))
+		}
+
+		// 同一namespace下的通道共享与远端网关各节点的长连接及熔断器
+		endpoints, err := client.NewEndpointPool(namespace.Name, grpcClient, newEndpoints(namespace),
+			time.Duration(namespace.EjectTimeout)*time.Second)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid endpoints in %s namespace", namespace.Name))
+		}
+		retryPolicy, err := newRetryPolicy(namespace.Retry)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid retry in %s namespace", namespace.Name))
+		}
+		hubClientOpts := []client.Option{
+			client.WithEndpoints(endpoints),
+			client.WithRetryPolicy(retryPolicy),
+			client.WithTimeout(time.Duration(namespace.Timeout) * time.Millisecond),
+		}
+		if breaker := newCircuitBreaker(namespace.Name, namespace.CircuitBreaker); breaker != nil {
+			hubClientOpts = append(hubClientOpts, client.WithCircuitBreaker(breaker))
 		}
 
 		// 一个namespace需要一个csp,cert必填
 		if namespace.CSP.Cert == "" {
 			panic(errors.New(fmt.Sprintf("the cert in  %s namespace is empty", namespace.Name)))
 		}
-		ks, err := sw.NewSimpleCSP(namespace.CSP.PrivateKey, namespace.CSP.Cert)
+		ks, err := namespace.CSP.NewSimpleCSP()
+		if err != nil {
+			panic(errors.Wrapf(err, "failed to new key store in %s namespace", namespace.Name))
+		}
+
+		policy, err := newAttestationPolicy(namespace.Attestation)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid attestation in %s namespace", namespace.Name))
+		}
+
+		verifier, err := newEndorsementVerifier(namespace.Endorsement)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid endorsement in %s namespace", namespace.Name))
+		}
+
+		anchors, orderer, err := parseHeaderSyncConfig(namespace.HeaderSync)
 		if err != nil {
-			panic(errors.Wrapf(err, "failed to new key store in % namespace", namespace.Name))
+			panic(errors.Wrapf(err, "invalid header sync in %s namespace", namespace.Name))
 		}
 
 		for _, channel := range namespace.Channels {
 			if channel.ID == "" {
 				panic(fmt.Errorf("the channel id is empty in remote namespace %s", namespace.Name))
 			}
-			Config.HubClientManager[channel.ID], err = client.NewHubClient(namespace.Address, namespace.Port, grpcClient)
+			Config.HubClientManager[channel.ID], err = client.NewHubClient(namespace.Address, namespace.Port, grpcClient, hubClientOpts...)
 			if err != nil {
 				panic(errors.Wrapf(err, "failed to new hub client, address:%s, namespace:%s", namespace.Address, namespace.Name))
 			}
 			Config.CSPManager[channel.ID] = ks
+			if policy != nil {
+				Config.AttestationPolicyManager[channel.ID] = policy
+			}
+			if verifier != nil {
+				// 各通道的代理合约不同, 背书提案按通道核对
+				channelVerifier := *verifier
+				channelVerifier.ProxyChainCodeName = channel.ProxyChainCodeName
+				Config.EndorsementVerifierManager[channel.ID] = &channelVerifier
+			}
+			if namespace.HeaderSync.Enable {
+				if anchors[channel.ID] == nil {
+					panic(fmt.Errorf("the trust anchor of channel %s is not configured in remote namespace %s",
+						channel.ID, namespace.Name))
+				}
+				if channel.RouterChainCodeName == "" {
+					panic(fmt.Errorf("the router chaincode of channel %s is not configured in remote namespace %s",
+						channel.ID, namespace.Name))
+				}
+				chain := lightclient.NewHeaderChain(Config.DBPath, channel.ID, namespace.HeaderSync.IsGM, anchors[channel.ID], orderer)
+				chain.RouterChainCodeName = channel.RouterChainCodeName
+				Config.HeaderChainManager[channel.ID] = chain
+				Config.HeaderSyncTasks = append(Config.HeaderSyncTasks, lightclient.NewSyncTask(chain,
+					Config.HubClientManager[channel.ID], time.Duration(namespace.HeaderSync.Interval)*time.Second))
+			}
 		}
 
 		nameMap[namespace.Name] = namespace.Name
@@ -111,7 +199,7 @@ func parseLocalNamespaceConfig(namespaces []config.LocalFabricNamespace) {
 			panic(fmt.Errorf("local namespace %s is existed", namespace.Name))
 		}
 		// 读取配置文件，并实例化client
-		client, err := fabric.NewClient(
+		fabClient, err := fabric.NewClient(
 			fabric.WithConfigPath(namespace.FabricConfigPath),
 			fabric.WithOrganization(namespace.Organization),
 			fabric.WithUsername(namespace.User),
@@ -121,12 +209,27 @@ func parseLocalNamespaceConfig(namespaces []config.LocalFabricNamespace) {
 				namespace.Name, namespace.FabricConfigPath))
 		}
 
-		if namespace.CSP.PrivateKey == "" {
+		if namespace.CSP.PrivateKey == "" && (namespace.CSP.KeyStore == "" || namespace.CSP.KeyStore == sw.FileKeyStore) {
 			panic(errors.New(fmt.Sprintf("the key in  %s namespace is empty", namespace.Name)))
 		}
-		ks, err := sw.NewSimpleCSP(namespace.CSP.PrivateKey, namespace.CSP.Cert)
+		ks, err := namespace.CSP.NewSimpleCSP()
 		if err != nil {
-			panic(errors.Wrapf(err, "failed to new key store in % namespace", namespace.Name))
+			panic(errors.Wrapf(err, "failed to new key store in %s namespace", namespace.Name))
+		}
+		if ks.PrivateKey == nil {
+			panic(errors.New(fmt.Sprintf("the signing key in %s namespace is not found", namespace.Name)))
+		}
+
+		var attestors []*client.Attestor
+		for _, attestor := range namespace.Attestors {
+			csp, err := attestor.CSP.NewSimpleCSP()
+			if err != nil {
+				panic(errors.Wrapf(err, "failed to new key store of attestor %s in %s namespace", attestor.Name, namespace.Name))
+			}
+			if csp.PrivateKey == nil {
+				panic(fmt.Errorf("the signing key of attestor %s in %s namespace is not found", attestor.Name, namespace.Name))
+			}
+			attestors = append(attestors, &client.Attestor{Name: attestor.Name, CSP: csp})
 		}
 
 		for _, channel := range namespace.Channels {
@@ -134,9 +237,10 @@ func parseLocalNamespaceConfig(namespaces []config.LocalFabricNamespace) {
 				panic(fmt.Errorf("the channel id is empty in local namespace %s", namespace.Name))
 			}
 			channel.IsGM = namespace.IsGM
-			Config.FabricClientManager[channel.ID] = client
+			Config.FabricClientManager[channel.ID] = fabClient
 			Config.CSPManager[channel.ID] = ks
 			Config.LocalChannelManager[channel.ID] = channel
+			Config.AttestorManager[channel.ID] = attestors
 		}
 
 		nameMap[namespace.Name] = namespace.Name
@@ -146,6 +250,158 @@ func parseLocalNamespaceConfig(namespaces []config.LocalFabricNamespace) {
 func setHubClientCSP() {
 	for k, v := range Config.HubClientManager {
 		v.SetCSP(Config.CSPManager)
+		v.SetAttestationPolicy(Config.AttestationPolicyManager)
+		v.SetEndorsementVerifier(Config.EndorsementVerifierManager)
 		Config.HubClientManager[k] = v
 	}
 }
+
+// newEndpoints 未配置多个节点时使用 address 与 port
+func newEndpoints(namespace config.RemoteFabricNamespace) []client.Endpoint {
+	if len(namespace.Endpoints) == 0 {
+		return []client.Endpoint{{Address: namespace.Address, Port: namespace.Port}}
+	}
+	var endpoints []client.Endpoint
+	for _, e := range namespace.Endpoints {
+		endpoints = append(endpoints, client.Endpoint{
+			Address:            e.Address,
+			Port:               e.Port,
+			Priority:           e.Priority,
+			Weight:             e.Weight,
+			ServerNameOverride: e.ServerNameOverride,
+		})
+	}
+	return endpoints
+}
+
+// newRetryPolicy 未配置的字段使用默认重试策略
+func newRetryPolicy(c config.Retry) (client.RetryPolicy, error) {
+	policy := client.DefaultRetryPolicy
+	if c.MaxAttempts > 0 {
+		policy.MaxAttempts = c.MaxAttempts
+	}
+	if c.InitialBackoff > 0 {
+		policy.InitialBackoff = time.Duration(c.InitialBackoff) * time.Millisecond
+	}
+	if c.MaxBackoff > 0 {
+		policy.MaxBackoff = time.Duration(c.MaxBackoff) * time.Millisecond
+	}
+	if c.Multiplier > 0 {
+		policy.Multiplier = c.Multiplier
+	}
+	if c.Jitter > 0 {
+		policy.Jitter = c.Jitter
+	}
+	if len(c.RetryableCodes) != 0 {
+		policy.RetryableCodes = nil
+		for _, name := range c.RetryableCodes {
+			var code codes.Code
+			if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+				return policy, errors.Wrapf(err, "invalid retryable code %s", name)
+			}
+			policy.RetryableCodes = append(policy.RetryableCodes, code)
+		}
+	}
+	return policy, nil
+}
+
+// newCircuitBreaker 熔断阈值为负数时返回 nil, 即不熔断
+func newCircuitBreaker(name string, c config.CircuitBreaker) *client.CircuitBreaker {
+	if c.FailureThreshold < 0 {
+		return nil
+	}
+	threshold, timeout := c.FailureThreshold, client.DefaultOpenTimeout
+	if threshold == 0 {
+		threshold = client.DefaultFailureThreshold
+	}
+	if c.OpenTimeout > 0 {
+		timeout = time.Duration(c.OpenTimeout) * time.Second
+	}
+	return client.NewCircuitBreaker(name, threshold, timeout)
+}
+
+// newAttestationPolicy 未配置签名身份时返回 nil, 即不启用多签校验
+func newAttestationPolicy(c config.Attestation) (*client.AttestationPolicy, error) {
+	if len(c.Signers) == 0 {
+		return nil, nil
+	}
+	signers := make(map[string]*sw.SimpleCSP, len(c.Signers))
+	for _, signer := range c.Signers {
+		if signer.Name == "" {
+			return nil, errors.New("the name of signer is empty")
+		}
+		if _, ok := signers[signer.Name]; ok {
+			return nil, errors.Errorf("signer %s is duplicated", signer.Name)
+		}
+		csp, err := signer.CSP.NewSimpleCSP()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to new key store of signer %s", signer.Name)
+		}
+		signers[signer.Name] = csp
+	}
+	return client.NewAttestationPolicy(c.Threshold, signers)
+}
+
+// newEndorsementVerifier 未配置 MSP 时返回 nil, 即不校验背书证明
+func newEndorsementVerifier(c config.Endorsement) (*client.EndorsementVerifier, error) {
+	if len(c.MSPs) == 0 {
+		return nil, nil
+	}
+	var msps []*client.MSP
+	for _, m := range c.MSPs {
+		rootCerts, err := readFiles(m.RootCerts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read root certs of msp %s", m.ID)
+		}
+		intermediateCerts, err := readFiles(m.IntermediateCerts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read intermediate certs of msp %s", m.ID)
+		}
+		item, err := client.NewMSP(m.ID, rootCerts, intermediateCerts)
+		if err != nil {
+			return nil, err
+		}
+		msps = append(msps, item)
+	}
+	threshold := c.Threshold
+	if threshold == 0 {
+		threshold = len(msps)
+	}
+	return client.NewEndorsementVerifier(msps, threshold, c.IsGM)
+}
+
+func readFiles(paths []string) ([][]byte, error) {
+	var files [][]byte
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, data)
+	}
+	return files, nil
+}
+
+// parseHeaderSyncConfig 解析各通道的信任锚点与排序节点组织. 区块头由不受信任的远端网关提供,
+// 启用时必须配置排序节点组织, 否则远端网关可在信任锚点之后伪造头链
+func parseHeaderSyncConfig(c config.HeaderSync) (map[string]*lightclient.TrustAnchor, *client.EndorsementVerifier, error) {
+	anchors := make(map[string]*lightclient.TrustAnchor, len(c.Anchors))
+	if !c.Enable {
+		return anchors, nil, nil
+	}
+	if len(c.Orderer.MSPs) == 0 {
+		return nil, nil, errors.New("the orderer msps are required when header sync is enabled")
+	}
+	for _, anchor := range c.Anchors {
+		hash, err := hex.DecodeString(anchor.Hash)
+		if err != nil || len(hash) == 0 {
+			return nil, nil, errors.Errorf("invalid hash of trust anchor %s", anchor.ChannelID)
+		}
+		anchors[anchor.ChannelID] = &lightclient.TrustAnchor{Number: anchor.Number, Hash: hash}
+	}
+	orderer, err := newEndorsementVerifier(c.Orderer)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid orderer")
+	}
+	return anchors, orderer, nil
+}
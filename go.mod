@@ -5,7 +5,7 @@ go 1.16
 require (
 	github.com/DataDog/zstd v1.4.1 // indirect
 	github.com/asdine/storm/v3 v3.0.0
-	github.com/bwmarrin/snowflake v0.3.0 // indirect
+	github.com/bwmarrin/snowflake v0.3.0
 	github.com/fabric-creed/cryptogm v0.0.0-20210621021614-9b28f0c0045b
 	github.com/fabric-creed/fabric-protos-go v0.0.0-20210621061524-cae0a59d99d3
 	github.com/fabric-creed/fabric-sdk-go v1.0.1-gm
@@ -19,8 +19,8 @@ require (
 	github.com/spf13/viper v1.3.2
 	github.com/stretchr/testify v1.6.1
 	github.com/vmihailenco/msgpack v4.0.4+incompatible // indirect
-	go.etcd.io/bbolt v1.3.6 // indirect
-	golang.org/x/crypto v0.0.0-20210616213533-5ff15b29337e // indirect
+	go.etcd.io/bbolt v1.3.6
+	golang.org/x/crypto v0.0.0-20210616213533-5ff15b29337e
 	golang.org/x/sys v0.0.0-20210616094352-59db8d763f22 // indirect
 	golang.org/x/text v0.3.6 // indirect
 	google.golang.org/appengine v1.6.0 // indirect
@@ -0,0 +1,26 @@
+// Package testutil 各包测试共用的辅助函数, 只供测试使用
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+)
+
+// NewTestCSP 返回使用新生成的 P-256 密钥签名与验签的 CSP
+func NewTestCSP(t *testing.T) *sw.SimpleCSP {
+	t.Helper()
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv := sw.NewEcdsaPrivateKey(raw)
+	pub, err := priv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &sw.SimpleCSP{CSP: &sw.CSP{}, PrivateKey: priv, PublicKey: pub}
+}
@@ -1,21 +1,36 @@
 package adopter
 
 import (
+	"context"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"sync"
 	"time"
 )
 
+// DefaultWorkers 默认并发处理跨链请求的工作协程数
+const DefaultWorkers = 4
+
 type CrossChain interface {
-	// 解析最新区块信息
-	FetchNextBlock() (*BlockInfo, error)
-	// 处理跨链请求
-	HandleCrossChainRequest(request interface{}) (*CrossChainResponse, error)
+	// 解析最新区块信息, ctx 取消时返回
+	FetchNextBlock(ctx context.Context) (*BlockInfo, error)
+	// 处理跨链请求, ctx 的截止时间传递至远端网关及 Fabric SDK
+	HandleCrossChainRequest(ctx context.Context, request interface{}) (*CrossChainResponse, error)
 	// 处理跨链回调及请求记录
-	HandleCrossChainCallbackRequest(payload CrossChainResponse) error
+	HandleCrossChainCallbackRequest(ctx context.Context, payload CrossChainResponse) error
 	// 保存最新区块信息
 	SaveLatestBlock(blockData []byte) error
 }
 
+// Sequencer 可选实现, 返回跨链请求的顺序键. 相同键的请求按区块内顺序串行处理,
+// 不同键的请求并发处理. 未实现时区块内的请求全部串行处理
+type Sequencer interface {
+	SequenceKey(request interface{}) string
+}
+
 type BlockInfo struct {
 	BlockData          []byte
 	CrossChainRequests []interface{}
@@ -32,34 +47,61 @@ type CrossChainResponse struct {
 
 type CrossChainTask struct {
 	cc CrossChain
+	// 处理单个跨链请求的重试策略, 超过次数后跳过该请求, 不阻塞后续请求
+	retryPolicy client.RetryPolicy
+	// 并发处理跨链请求的工作协程数
+	workers int
+	// 按顺序键串行处理, 避免跟随区块、重新处理区块与重试死信并发处理同一请求
+	keys *keyLock
+}
+
+type TaskOption func(t *CrossChainTask)
+
+func WithRetryPolicy(policy client.RetryPolicy) TaskOption {
+	return func(t *CrossChainTask) {
+		t.retryPolicy = policy
+	}
+}
+
+// WithWorkers 设置工作协程数, 不大于 0 时使用默认值
+func WithWorkers(workers int) TaskOption {
+	return func(t *CrossChainTask) {
+		if workers > 0 {
+			t.workers = workers
+		}
+	}
 }
 
-func NewCrossChainTask(cc CrossChain) *CrossChainTask {
-	return &CrossChainTask{cc: cc}
+func NewCrossChainTask(cc CrossChain, opts ...TaskOption) *CrossChainTask {
+	t := &CrossChainTask{
+		cc:          cc,
+		retryPolicy: client.DefaultRetryPolicy,
+		workers:     DefaultWorkers,
+		keys:        newKeyLock(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
-func (t *CrossChainTask) Run() error {
+// Run 持续处理跨链请求, 直到 ctx 取消
+func (t *CrossChainTask) Run(ctx context.Context) error {
 	for {
-		block, err := t.cc.FetchNextBlock()
+		block, err := t.cc.FetchNextBlock(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err != nil {
 			logrus.Errorf("failed to parse cross chain request, err:%s", err.Error())
 			time.Sleep(1 * time.Second)
 			continue
 		}
-		for _, request := range block.CrossChainRequests {
-		retry:
-			response, err := t.cc.HandleCrossChainRequest(request)
-			if err != nil {
-				logrus.Errorf("failed to handle cross chain request, err:%s", err.Error())
-				time.Sleep(2 * time.Second)
-				goto retry
-			}
-			err = t.cc.HandleCrossChainCallbackRequest(*response)
-			if err != nil {
-				logrus.Errorf("failed to handle cross chain callback request, err:%s", err.Error())
-				time.Sleep(2 * time.Second)
-				goto retry
-			}
+		// 等待区块内的请求全部处理完成后才保存区块
+		t.handleBlock(ctx, block.CrossChainRequests)
+		// 取消时区块未处理完, 不保存区块, 重启后重新处理
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
 		err = t.cc.SaveLatestBlock(block.BlockData)
@@ -70,3 +112,199 @@ func (t *CrossChainTask) Run() error {
 		}
 	}
 }
+
+// handleBlock 按顺序键将请求分组, 各组由工作协程并发处理, 组内按区块内顺序处理
+func (t *CrossChainTask) handleBlock(ctx context.Context, requests []interface{}) {
+	var (
+		keys   []string
+		groups = make(map[string][]interface{})
+	)
+	for _, request := range requests {
+		key := t.sequenceKey(request)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], request)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, t.workers)
+	)
+	for _, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string, requests []interface{}) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			for _, request := range requests {
+				unlock := t.keys.lock(key)
+				// 取消时不保存区块, 重启后重新处理, 无需进入死信队列
+				if err := t.handle(ctx, request, nil); err == nil {
+					t.resolveDeadLetter(request)
+				} else if ctx.Err() == nil {
+					t.deadLetter(request, err)
+				}
+				unlock()
+			}
+		}(key, groups[key])
+	}
+	wg.Wait()
+}
+
+// handle 处理单个跨链请求及其回调, 失败时按重试策略只重试失败的步骤.
+// 重试次数用尽或遇到不可重试的错误时返回 *HandleError
+func (t *CrossChainTask) handle(ctx context.Context, request interface{}, response *CrossChainResponse) error {
+	var (
+		err     error
+		history []string
+	)
+	attempt := 1
+	for ; attempt <= t.retryPolicy.Attempts(); attempt++ {
+		if attempt > 1 {
+			backoff := t.retryPolicy.Backoff(attempt - 1)
+			logrus.Warnf("retry cross chain request %d after %s, err:%s", attempt-1, backoff, err.Error())
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+		err = nil
+		if response == nil {
+			response, err = t.cc.HandleCrossChainRequest(ctx, request)
+			// 请求已处理过
+			if err == nil && response == nil {
+				return nil
+			}
+		}
+		if err == nil {
+			if err = t.cc.HandleCrossChainCallbackRequest(ctx, *response); err == nil {
+				return nil
+			}
+		}
+		history = append(history, err.Error())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if t.permanent(err) {
+			break
+		}
+	}
+	if attempt > t.retryPolicy.Attempts() {
+		attempt = t.retryPolicy.Attempts()
+	}
+	return &HandleError{Attempts: attempt, History: history, Response: response, Err: err}
+}
+
+// permanent 判断错误是否不可重试, 远端网关返回的 grpc 错误按重试策略判断
+func (t *CrossChainTask) permanent(err error) bool {
+	if IsPermanent(err) {
+		return true
+	}
+	if _, ok := status.FromError(err); ok {
+		return !t.retryPolicy.Retryable(err)
+	}
+	return false
+}
+
+// deadLetter 将处理失败的请求保存到死信队列, 未实现死信队列时只记录日志
+func (t *CrossChainTask) deadLetter(request interface{}, err error) {
+	var failure *HandleError
+	if !errors.As(err, &failure) {
+		logrus.Errorf("failed to handle cross chain request, err:%s", err.Error())
+		return
+	}
+	dlq, ok := t.cc.(DeadLetterQueue)
+	if !ok {
+		logrus.Errorf("failed to handle cross chain request after %d attempts, skip it, err:%s",
+			failure.Attempts, failure.Err.Error())
+		return
+	}
+	if err = dlq.PutDeadLetter(request, failure); err != nil {
+		logrus.Errorf("failed to put cross chain request into dead letter queue, skip it, err:%s", err.Error())
+		return
+	}
+	logrus.Warnf("cross chain request is moved to dead letter queue after %d attempts, err:%s",
+		failure.Attempts, failure.Err.Error())
+}
+
+// resolveDeadLetter 删除重新处理成功的请求之前的死信, 失败时只记录日志
+func (t *CrossChainTask) resolveDeadLetter(request interface{}) {
+	dlq, ok := t.cc.(DeadLetterQueue)
+	if !ok {
+		return
+	}
+	if err := dlq.ResolveDeadLetter(request); err != nil {
+		logrus.Errorf("failed to resolve dead letter of cross chain request, err:%s", err.Error())
+	}
+}
+
+// RetryDeadLetter 重新处理死信中的跨链请求, 已收到响应时只重新处理回调, 失败时返回 *HandleError
+func (t *CrossChainTask) RetryDeadLetter(ctx context.Context, letter *database.DeadLetter) error {
+	dlq, ok := t.cc.(DeadLetterQueue)
+	if !ok {
+		return errors.New("the dead letter queue is not supported")
+	}
+	request, response, err := dlq.DecodeDeadLetter(letter)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode dead letter")
+	}
+	unlock := t.keys.lock(t.sequenceKey(request))
+	err = t.handle(ctx, request, response)
+	unlock()
+	var failure *HandleError
+	if errors.As(err, &failure) {
+		if uerr := dlq.UpdateDeadLetter(letter, failure); uerr != nil {
+			return errors.Wrap(uerr, "failed to update dead letter")
+		}
+	}
+	return err
+}
+
+// sequenceKey 未实现 Sequencer 时所有请求使用同一顺序键
+func (t *CrossChainTask) sequenceKey(request interface{}) string {
+	if sequencer, ok := t.cc.(Sequencer); ok {
+		return sequencer.SequenceKey(request)
+	}
+	return ""
+}
+
+// keyLock 按键加锁, 不再使用的键及时释放
+type keyLock struct {
+	mutex sync.Mutex
+	locks map[string]*keyLockEntry
+}
+
+type keyLockEntry struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyLock() *keyLock {
+	return &keyLock{locks: make(map[string]*keyLockEntry)}
+}
+
+// lock 获取键的锁, 返回释放函数
+func (l *keyLock) lock(key string) func() {
+	l.mutex.Lock()
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &keyLockEntry{}
+		l.locks[key] = entry
+	}
+	entry.refs++
+	l.mutex.Unlock()
+
+	entry.Lock()
+	return func() {
+		entry.Unlock()
+		l.mutex.Lock()
+		if entry.refs--; entry.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mutex.Unlock()
+	}
+}
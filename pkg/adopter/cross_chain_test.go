@@ -0,0 +1,280 @@
+package adopter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequest struct {
+	key   string
+	index int
+	delay time.Duration
+}
+
+type testCrossChain struct {
+	blocks chan *BlockInfo
+	saved  chan []byte
+
+	lock    sync.Mutex
+	handled map[string][]int
+	running int
+	peak    int
+}
+
+func (c *testCrossChain) FetchNextBlock(ctx context.Context) (*BlockInfo, error) {
+	select {
+	case block := <-c.blocks:
+		return block, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *testCrossChain) HandleCrossChainRequest(ctx context.Context, request interface{}) (*CrossChainResponse, error) {
+	req := request.(testRequest)
+	c.lock.Lock()
+	c.running++
+	if c.running > c.peak {
+		c.peak = c.running
+	}
+	c.lock.Unlock()
+
+	time.Sleep(req.delay)
+
+	c.lock.Lock()
+	c.running--
+	c.handled[req.key] = append(c.handled[req.key], req.index)
+	c.lock.Unlock()
+	return &CrossChainResponse{}, nil
+}
+
+func (c *testCrossChain) HandleCrossChainCallbackRequest(ctx context.Context, payload CrossChainResponse) error {
+	return nil
+}
+
+func (c *testCrossChain) SaveLatestBlock(blockData []byte) error {
+	c.saved <- blockData
+	return nil
+}
+
+func (c *testCrossChain) SequenceKey(request interface{}) string {
+	return request.(testRequest).key
+}
+
+func TestCrossChainTask(t *testing.T) {
+	cc := &testCrossChain{
+		blocks:  make(chan *BlockInfo, 1),
+		saved:   make(chan []byte, 1),
+		handled: make(map[string][]int),
+	}
+	// 同一顺序键的请求后提交的耗时更短, 需仍按区块内顺序完成
+	var requests []interface{}
+	for i := 0; i < 3; i++ {
+		for _, key := range []string{"a", "b", "c"} {
+			requests = append(requests, testRequest{key: key, index: i, delay: time.Duration(3-i) * 10 * time.Millisecond})
+		}
+	}
+	cc.blocks <- &BlockInfo{BlockData: []byte("1"), CrossChainRequests: requests}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task := NewCrossChainTask(cc, WithWorkers(2), WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+	go task.Run(ctx)
+
+	select {
+	case data := <-cc.saved:
+		assert.Equal(t, []byte("1"), data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the block is not saved")
+	}
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	// 区块保存时全部请求已处理
+	for _, key := range []string{"a", "b", "c"} {
+		assert.Equal(t, []int{0, 1, 2}, cc.handled[key])
+	}
+	assert.Equal(t, 2, cc.peak)
+}
+
+type testDeadLetterQueue struct {
+	testCrossChain
+	requestErr  error
+	callbackErr error
+	requests    int
+	callbacks   int
+	letters     []*HandleError
+	resolved    int
+}
+
+func (q *testDeadLetterQueue) HandleCrossChainRequest(ctx context.Context, request interface{}) (*CrossChainResponse, error) {
+	q.requests++
+	if q.requestErr != nil {
+		return nil, q.requestErr
+	}
+	return &CrossChainResponse{ErrorMessage: "ok"}, nil
+}
+
+func (q *testDeadLetterQueue) HandleCrossChainCallbackRequest(ctx context.Context, payload CrossChainResponse) error {
+	q.callbacks++
+	return q.callbackErr
+}
+
+func (q *testDeadLetterQueue) PutDeadLetter(request interface{}, failure *HandleError) error {
+	q.letters = append(q.letters, failure)
+	return nil
+}
+
+func (q *testDeadLetterQueue) DecodeDeadLetter(letter *database.DeadLetter) (interface{}, *CrossChainResponse, error) {
+	failure := q.letters[letter.PrimaryID]
+	return testRequest{}, failure.Response, nil
+}
+
+func (q *testDeadLetterQueue) UpdateDeadLetter(letter *database.DeadLetter, failure *HandleError) error {
+	letter.Attempts += failure.Attempts
+	letter.Errors = append(letter.Errors, failure.History...)
+	return nil
+}
+
+func (q *testDeadLetterQueue) ResolveDeadLetter(request interface{}) error {
+	q.resolved++
+	return nil
+}
+
+func TestCrossChainTaskDeadLetter(t *testing.T) {
+	q := &testDeadLetterQueue{}
+	task := NewCrossChainTask(q, WithWorkers(1), WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}))
+	ctx := context.Background()
+
+	// 不可重试的错误直接进入死信队列
+	q.requestErr = Permanent(errors.New("malformed"))
+	task.handleBlock(ctx, []interface{}{testRequest{}})
+	assert.Equal(t, 1, q.requests)
+	assert.Len(t, q.letters, 1)
+	assert.Equal(t, 1, q.letters[0].Attempts)
+	assert.True(t, IsPermanent(q.letters[0].Err))
+
+	// 可重试的错误在重试次数用尽后进入死信队列, 请求成功后只重试回调
+	q.requests, q.requestErr = 0, nil
+	q.callbackErr = errors.New("unavailable")
+	task.handleBlock(ctx, []interface{}{testRequest{}})
+	assert.Equal(t, 1, q.requests)
+	assert.Equal(t, 3, q.callbacks)
+	assert.Len(t, q.letters, 2)
+	assert.Equal(t, 3, q.letters[1].Attempts)
+	assert.Equal(t, []string{"unavailable", "unavailable", "unavailable"}, q.letters[1].History)
+	assert.NotNil(t, q.letters[1].Response)
+
+	// 重新处理死信时不再发送已成功的请求
+	letter := &database.DeadLetter{PrimaryID: 1, Attempts: 3}
+	err := task.RetryDeadLetter(ctx, letter)
+	assert.NotNil(t, err)
+	assert.Equal(t, 6, letter.Attempts)
+	assert.Equal(t, 1, q.requests)
+
+	q.callbackErr = nil
+	assert.Nil(t, task.RetryDeadLetter(ctx, letter))
+	assert.Equal(t, 1, q.requests)
+	assert.Equal(t, 7, q.callbacks)
+
+	// 跟随区块时处理成功, 删除之前的死信
+	assert.Equal(t, 0, q.resolved)
+	task.handleBlock(ctx, []interface{}{testRequest{}})
+	assert.Len(t, q.letters, 2)
+	assert.Equal(t, 1, q.resolved)
+}
+
+type testBlockCursor struct {
+	testCrossChain
+	next  uint64
+	delay time.Duration
+}
+
+func (c *testBlockCursor) NextBlock() (uint64, error) {
+	return c.next, nil
+}
+
+func (c *testBlockCursor) SetStartBlock(number uint64, latest bool) (uint64, error) {
+	c.next = number
+	return number, nil
+}
+
+func (c *testBlockCursor) FetchBlock(number uint64) (*BlockInfo, error) {
+	if number >= c.next {
+		return nil, errors.Errorf("block %d is not produced yet", number)
+	}
+	return &BlockInfo{CrossChainRequests: []interface{}{testRequest{key: "a", index: int(number), delay: c.delay}}}, nil
+}
+
+func TestCrossChainTaskReplay(t *testing.T) {
+	task := NewCrossChainTask(&testCrossChain{handled: make(map[string][]int)})
+	_, _, err := task.Replay(context.Background(), 1, 2)
+	assert.Equal(t, ErrBlockCursorNotSupported, err)
+
+	cc := &testBlockCursor{testCrossChain: testCrossChain{handled: make(map[string][]int)}, next: 5}
+	task = NewCrossChainTask(cc)
+	blocks, requests, err := task.Replay(context.Background(), 2, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, blocks)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, []int{2, 3, 4}, cc.handled["a"])
+	// 重新处理不改变下一个处理的区块
+	next, err := task.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), next)
+
+	blocks, _, err = task.Replay(context.Background(), 4, 6)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, blocks)
+}
+
+func TestCrossChainTaskSerializeKey(t *testing.T) {
+	cc := &testCrossChain{handled: make(map[string][]int)}
+	task := NewCrossChainTask(cc, WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+	// 跟随区块与重新处理区块并发处理同一顺序键的请求时不重叠
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task.handleBlock(context.Background(), []interface{}{
+				testRequest{key: "a", index: i, delay: 20 * time.Millisecond},
+			})
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, cc.peak)
+	assert.Len(t, cc.handled["a"], 3)
+	assert.Empty(t, task.keys.locks)
+}
+
+func TestCrossChainTaskReplaySerialized(t *testing.T) {
+	cc := &testBlockCursor{testCrossChain: testCrossChain{handled: make(map[string][]int)}, next: 5, delay: 20 * time.Millisecond}
+	task := NewCrossChainTask(cc, WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+	// 重新处理与跟随区块并发处理同一顺序键的请求时不重叠
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		task.handleBlock(context.Background(), []interface{}{
+			testRequest{key: "a", index: 5, delay: 20 * time.Millisecond},
+			testRequest{key: "a", index: 6, delay: 20 * time.Millisecond},
+		})
+	}()
+	_, requests, err := task.Replay(context.Background(), 2, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, requests)
+	wg.Wait()
+	assert.Equal(t, 1, cc.peak)
+	assert.Len(t, cc.handled["a"], 5)
+}
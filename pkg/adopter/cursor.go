@@ -0,0 +1,58 @@
+package adopter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// BlockCursor 可选实现, 查询及设置下一个处理的区块, 并按区块号获取区块用于重新处理
+type BlockCursor interface {
+	// 下一个处理的区块
+	NextBlock() (uint64, error)
+	// 设置下一个处理的区块, latest 为 true 时从账本当前高度开始, 返回设置后的区块
+	SetStartBlock(number uint64, latest bool) (uint64, error)
+	// 按区块号获取区块, 用于重新处理, 已失败的请求同样重新处理
+	FetchBlock(number uint64) (*BlockInfo, error)
+}
+
+var ErrBlockCursorNotSupported = errors.New("the block cursor is not supported")
+
+func (t *CrossChainTask) NextBlock() (uint64, error) {
+	bc, ok := t.cc.(BlockCursor)
+	if !ok {
+		return 0, ErrBlockCursorNotSupported
+	}
+	return bc.NextBlock()
+}
+
+// SetStartBlock 设置下一个处理的区块, 用于跳过历史区块或回退重新处理
+func (t *CrossChainTask) SetStartBlock(number uint64, latest bool) (uint64, error) {
+	bc, ok := t.cc.(BlockCursor)
+	if !ok {
+		return 0, ErrBlockCursorNotSupported
+	}
+	return bc.SetStartBlock(number, latest)
+}
+
+// Replay 重新处理 [from, to] 范围内的区块, 不改变下一个处理的区块. 与 Run 并发执行,
+// 同一顺序键的请求与跟随区块的处理互斥, 已完成的请求由适配器的处理记录去重, 不会重复调用远端网关
+func (t *CrossChainTask) Replay(ctx context.Context, from, to uint64) (blocks, requests int, err error) {
+	bc, ok := t.cc.(BlockCursor)
+	if !ok {
+		return 0, 0, ErrBlockCursorNotSupported
+	}
+	for number := from; number <= to; number++ {
+		block, err := bc.FetchBlock(number)
+		if err != nil {
+			return blocks, requests, errors.WithMessagef(err, "failed to fetch block %d", number)
+		}
+		t.handleBlock(ctx, block.CrossChainRequests)
+		if ctx.Err() != nil {
+			return blocks, requests, ctx.Err()
+		}
+		blocks++
+		requests += len(block.CrossChainRequests)
+	}
+	return blocks, requests, nil
+}
@@ -0,0 +1,65 @@
+package adopter
+
+import (
+	"fmt"
+
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/pkg/errors"
+)
+
+// PermanentError 不可重试的错误, 如请求格式错误、确定性的合约错误, 请求直接进入死信队列
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent 将错误标记为不可重试
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// HandleError 跨链请求处理失败的记录
+type HandleError struct {
+	// 尝试次数
+	Attempts int
+	// 每次尝试的错误信息
+	History []string
+	// 已收到的响应, 请求成功但回调失败时不为空, 重新处理时只重试回调
+	Response *CrossChainResponse
+	Err      error
+}
+
+func (e *HandleError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %s", e.Attempts, e.Err.Error())
+}
+
+func (e *HandleError) Unwrap() error {
+	return e.Err
+}
+
+// DeadLetterQueue 可选实现, 保存重试次数用尽或不可重试的跨链请求, 未实现时只记录日志后跳过
+type DeadLetterQueue interface {
+	// PutDeadLetter 保存跨链请求及其错误记录
+	PutDeadLetter(request interface{}, failure *HandleError) error
+	// DecodeDeadLetter 还原死信中的跨链请求及已收到的响应, 用于重新处理
+	DecodeDeadLetter(letter *database.DeadLetter) (interface{}, *CrossChainResponse, error)
+	// UpdateDeadLetter 重新处理失败后累计尝试次数与错误记录
+	UpdateDeadLetter(letter *database.DeadLetter, failure *HandleError) error
+	// ResolveDeadLetter 跟随或重放区块时重新处理成功, 删除请求对应的死信, 不存在时忽略
+	ResolveDeadLetter(request interface{}) error
+}
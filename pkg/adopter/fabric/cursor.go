@@ -0,0 +1,150 @@
+package fabric
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	cc "github.com/fabric-creed/fabric-hub/pkg/adopter"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/cursor"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var _ cc.BlockCursor = (*Fabric)(nil)
+
+// NextBlock 返回下一个处理的区块, 正在处理的区块保存后更新
+func (f *Fabric) NextBlock() (uint64, error) {
+	return f.startBlockNumber()
+}
+
+// SetStartBlock 设置下一个处理的区块, latest 为 true 时从账本当前高度开始.
+// 设置后中断当前的等待, 正在处理的区块处理完成后从新的区块继续
+func (f *Fabric) SetStartBlock(number uint64, latest bool) (uint64, error) {
+	if latest {
+		height, err := f.ledgerHeight()
+		if err != nil {
+			return 0, err
+		}
+		number = height
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if err := cursor.NewController(f.dbPath).Save(f.id, number); err != nil {
+		return 0, errors.Wrapf(err, "failed to save the next block of %s", f.id)
+	}
+	f.pending = &number
+	if f.interrupt != nil {
+		f.interrupt()
+	}
+	return number, nil
+}
+
+// FetchBlock 按区块号获取区块, 用于重新处理. 重新处理由运维发起,
+// 处理记录为失败状态的请求同样重新处理
+func (f *Fabric) FetchBlock(number uint64) (*cc.BlockInfo, error) {
+	data, err := f.queryBlock(number)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.Errorf("block %d is not produced yet", number)
+	}
+	for i, request := range data.CrossChainRequests {
+		if fccr, ok := request.(FabricCrossChainRequest); ok {
+			fccr.Redrive = true
+			data.CrossChainRequests[i] = fccr
+		}
+	}
+	return data, nil
+}
+
+// startBlockNumber 依次使用已保存的处理进度、通道已保存的最新区块、按通道划分前保存的最新区块与配置的起始区块
+func (f *Fabric) startBlockNumber() (uint64, error) {
+	c, err := cursor.NewController(f.dbPath).FetchCursor(f.id)
+	if err == nil {
+		return c.NextBlock, nil
+	}
+	if err != storm.ErrNotFound {
+		return 0, errors.Wrapf(err, "failed to fetch the next block of %s", f.id)
+	}
+	// 兼容未保存处理进度的数据
+	latest, err := block.NewController(f.dbPath, f.id).FetchLatestBlockNum()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to fetch latest block num")
+	}
+	if latest == 0 && f.legacyBlocks {
+		if latest, err = block.FetchLegacyLatestBlockNum(f.dbPath); err != nil {
+			return 0, errors.Wrap(err, "failed to fetch legacy latest block num")
+		}
+	}
+	if latest > 0 {
+		return latest + 1, nil
+	}
+	if f.startFromLatest {
+		return f.ledgerHeight()
+	}
+	if f.startBlock > 0 {
+		return f.startBlock, nil
+	}
+	return 1, nil
+}
+
+func (f *Fabric) ledgerHeight() (uint64, error) {
+	ledger, err := f.ledger()
+	if err != nil {
+		return 0, err
+	}
+	return ledger.QueryHeight()
+}
+
+// prepareCursor 应用管理接口设置的下一个处理的区块, 首次调用时初始化
+func (f *Fabric) prepareCursor() error {
+	f.lock.Lock()
+	pending := f.pending
+	f.pending = nil
+	f.lock.Unlock()
+	if pending != nil {
+		logrus.Infof("the next block of %s is set to %d", f.id, *pending)
+		f.nextBlock = *pending
+		f.started = true
+		f.closeSubscription()
+		return nil
+	}
+	if f.started {
+		return nil
+	}
+	next, err := f.startBlockNumber()
+	if err != nil {
+		return err
+	}
+	f.nextBlock = next
+	f.started = true
+	return nil
+}
+
+// fetchContext 返回可被 SetStartBlock 中断的 ctx
+func (f *Fabric) fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	f.lock.Lock()
+	f.interrupt = cancel
+	f.lock.Unlock()
+	return fetchCtx, func() {
+		f.lock.Lock()
+		f.interrupt = nil
+		f.lock.Unlock()
+		cancel()
+	}
+}
+
+// saveCursor 保存已处理区块的检查点, 管理接口已设置下一个处理的区块时不覆盖
+func (f *Fabric) saveCursor(pbBlock *fabric.Block) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.pending != nil {
+		return nil
+	}
+	return cursor.NewController(f.dbPath).SaveCheckpoint(f.id,
+		pbBlock.Header.Number, pbBlock.BlockHash, pbBlock.Header.PreviousHash)
+}
@@ -0,0 +1,77 @@
+package fabric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreScopedByChannelID(t *testing.T) {
+	f, hub, executor := newTestFabric(t)
+	// 不同网络的同名通道
+	other := NewFabric(f.dbPath, "other", "mychannel", "router", false, WithHubClientMap(f.hubClientMap))
+	other.executor = executor
+	l := &testLedger{}
+	l.append(nil)
+	l.append([][]byte{newTestEnvelope(t, "tx1", newTestCallRequest("to", "t1"))})
+	f.querier, other.querier = l, l
+
+	// 区块与处理进度按通道ID保存
+	info, err := f.FetchBlock(1)
+	assert.Nil(t, err)
+	assert.Nil(t, f.SaveLatestBlock(info.BlockData))
+	next, err := f.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), next)
+	next, err = other.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), next)
+	_, err = block.NewController(f.dbPath, "local").FetchBlockByNumber(1)
+	assert.Nil(t, err)
+	_, err = block.NewController(f.dbPath, "other").FetchBlockByNumber(1)
+	assert.Equal(t, storm.ErrNotFound, err)
+
+	// 相同交易ID与步骤ID的处理记录互不影响
+	ctx := context.Background()
+	response, err := f.HandleCrossChainRequest(ctx, newTestRequest("to", "t1"))
+	assert.Nil(t, err)
+	assert.Nil(t, f.HandleCrossChainCallbackRequest(ctx, *response))
+	assert.Equal(t, database.OutboxCallbackDone, outboxState(t, f, "t1"))
+	_, err = outbox.NewController(f.dbPath).FetchByKey(outbox.Key("other", "t1", "1"))
+	assert.Equal(t, storm.ErrNotFound, err)
+	response, err = other.HandleCrossChainRequest(ctx, newTestRequest("to", "t1"))
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, database.OutboxResponded, outboxState(t, other, "t1"))
+	assert.Equal(t, 2, hub.count())
+}
+
+func TestStartBlockLegacyStore(t *testing.T) {
+	dbPath := t.TempDir()
+	// 按通道划分前保存的区块不区分通道
+	legacy := database.OpenDB(dbPath, block.DBName, new(database.Block))
+	assert.Nil(t, legacy.Save(&database.Block{BlockNumber: 7, BlockHash: "h7"}))
+
+	// 只有单通道时沿用旧数据
+	f := NewFabric(dbPath, "local", "mychannel", "router", false, WithLegacyBlockStore())
+	next, err := f.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(8), next)
+
+	f = NewFabric(dbPath, "local", "mychannel", "router", false, WithStartBlock(3, false))
+	next, err = f.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), next)
+
+	// 通道已保存的区块优先
+	assert.Nil(t, block.NewController(dbPath, "local").CreateBlock(&database.Block{BlockNumber: 9, BlockHash: "h9"}))
+	f = NewFabric(dbPath, "local", "mychannel", "router", false, WithLegacyBlockStore())
+	next, err = f.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), next)
+}
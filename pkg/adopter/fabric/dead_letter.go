@@ -0,0 +1,226 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/asdine/storm/v3"
+	cc "github.com/fabric-creed/fabric-hub/pkg/adopter"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/deadletter"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var _ cc.DeadLetterQueue = (*Fabric)(nil)
+
+// deadLetterResponse 死信中保存的响应
+type deadLetterResponse struct {
+	// pb.CommonResponseMessage 的 protobuf 编码
+	Response     []byte `json:"response"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// PutDeadLetter 同一跨链请求重新处理失败时更新已有的死信, 累计尝试次数与错误记录
+func (f *Fabric) PutDeadLetter(request interface{}, failure *cc.HandleError) error {
+	fccr, ok := request.(FabricCrossChainRequest)
+	if !ok {
+		return errors.New("invalid fabric cross chain request")
+	}
+	req, ok := fccr.Request.(*pb.NoTransactionCallRequest)
+	if !ok {
+		return errors.New("invalid cross chain request")
+	}
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cross chain request")
+	}
+	ctl := deadletter.NewController(f.dbPath)
+	letter, err := ctl.FetchByRequest(f.id, req.TransactionID, req.StepID)
+	if err != nil && err != storm.ErrNotFound {
+		return errors.Wrap(err, "failed to fetch dead letter")
+	}
+	exists := err == nil
+	if !exists {
+		letter = &database.DeadLetter{
+			ChannelID:     f.id,
+			TransactionID: req.TransactionID,
+			StepID:        req.StepID,
+		}
+	}
+	letter.From = req.From
+	letter.To = req.To
+	letter.TransactionHash = fccr.TxHash
+	letter.BlockNumber = fccr.BlockNumber
+	letter.BlockHash = fccr.BlockHash
+	letter.OriginInfo = fccr.OriginInfo
+	letter.Request = data
+	letter.Attempts += failure.Attempts
+	letter.Errors = append(letter.Errors, failure.History...)
+	letter.Permanent = cc.IsPermanent(failure.Err)
+	if failure.Response != nil {
+		if letter.Response, err = encodeResponse(failure.Response); err != nil {
+			return err
+		}
+	}
+
+	// 未收到响应且不可重试时将错误信息写入路由合约, 告知来源链请求已失败
+	if letter.Permanent && failure.Response == nil {
+		f.writeFailure(req, failure.Err)
+	}
+	f.failOutbox(req.TransactionID, req.StepID, failure.Err.Error())
+	if exists {
+		return ctl.Update(letter)
+	}
+	return ctl.Create(letter)
+}
+
+// writeFailure 将请求的错误信息写入路由合约, 失败时只记录日志, 死信重新处理成功后由结果覆盖
+func (f *Fabric) writeFailure(req *pb.NoTransactionCallRequest, cause error) {
+	cl, err := f.channel()
+	if err == nil {
+		err = f.invokeResult(context.Background(), cl, req.From, req.To, req.TransactionID, req.StepID, nil, nil, cause.Error())
+	}
+	if err != nil {
+		logrus.Errorf("failed to write the failure of cross chain request %s/%s to router, err:%s",
+			req.TransactionID, req.StepID, err.Error())
+	}
+}
+
+// ResolveDeadLetter 跨链请求重新处理成功后删除其死信
+func (f *Fabric) ResolveDeadLetter(request interface{}) error {
+	fccr, ok := request.(FabricCrossChainRequest)
+	if !ok {
+		return errors.New("invalid fabric cross chain request")
+	}
+	req, ok := fccr.Request.(*pb.NoTransactionCallRequest)
+	if !ok {
+		return errors.New("invalid cross chain request")
+	}
+	ctl := deadletter.NewController(f.dbPath)
+	letter, err := ctl.FetchByRequest(f.id, req.TransactionID, req.StepID)
+	if err == storm.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch dead letter")
+	}
+	return ctl.Delete(letter.PrimaryID)
+}
+
+func (f *Fabric) DecodeDeadLetter(letter *database.DeadLetter) (interface{}, *cc.CrossChainResponse, error) {
+	if len(letter.Request) == 0 {
+		return nil, nil, errors.Errorf("the cross chain request of transaction %s is malformed and can not be retried", letter.TransactionHash)
+	}
+	req := &pb.NoTransactionCallRequest{}
+	if err := proto.Unmarshal(letter.Request, req); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal cross chain request")
+	}
+	request := FabricCrossChainRequest{
+		TxHash:      letter.TransactionHash,
+		BlockNumber: letter.BlockNumber,
+		BlockHash:   letter.BlockHash,
+		OriginInfo:  letter.OriginInfo,
+		Request:     req,
+		Redrive:     true,
+	}
+	if len(letter.Response) == 0 {
+		return request, nil, nil
+	}
+	response, err := decodeResponse(letter.Response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return request, response, nil
+}
+
+func (f *Fabric) UpdateDeadLetter(letter *database.DeadLetter, failure *cc.HandleError) error {
+	letter.Attempts += failure.Attempts
+	letter.Errors = append(letter.Errors, failure.History...)
+	letter.Permanent = cc.IsPermanent(failure.Err)
+	if failure.Response != nil {
+		data, err := encodeResponse(failure.Response)
+		if err != nil {
+			return err
+		}
+		letter.Response = data
+	}
+
+	f.failOutbox(letter.TransactionID, letter.StepID, failure.Err.Error())
+	return deadletter.NewController(f.dbPath).Update(letter)
+}
+
+// putMalformedRequest 路由合约事件内容有误时写入不可重试的死信, 供运维排查.
+// 重新处理区块时不重复写入, 写入失败时只记录日志
+func (f *Fabric) putMalformedRequest(pbBlock *fabric.Block, txHash string, originInfo []byte, cause error) {
+	logrus.Errorf("skip malformed cross chain request of transaction %s in block %d, err:%s",
+		txHash, pbBlock.Header.Number, cause.Error())
+	ctl := deadletter.NewController(f.dbPath)
+	_, err := ctl.FetchByTransactionHash(f.id, txHash)
+	if err == nil {
+		return
+	}
+	if err != storm.ErrNotFound {
+		logrus.Errorf("failed to fetch the dead letter of transaction %s, err:%s", txHash, err.Error())
+		return
+	}
+	err = ctl.Create(&database.DeadLetter{
+		ChannelID:       f.id,
+		TransactionHash: txHash,
+		BlockNumber:     pbBlock.Header.Number,
+		BlockHash:       pbBlock.BlockHash,
+		OriginInfo:      originInfo,
+		Errors:          []string{cause.Error()},
+		Permanent:       true,
+	})
+	if err != nil {
+		logrus.Errorf("failed to put the malformed cross chain request of transaction %s, err:%s", txHash, err.Error())
+	}
+}
+
+func encodeResponse(response *cc.CrossChainResponse) ([]byte, error) {
+	stored := deadLetterResponse{ErrorMessage: response.ErrorMessage}
+	if msg, ok := response.Response.(*pb.CommonResponseMessage); ok {
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal response")
+		}
+		stored.Response = data
+	}
+	return json.Marshal(stored)
+}
+
+func decodeResponse(data []byte) (*cc.CrossChainResponse, error) {
+	var stored deadLetterResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+	response := &cc.CrossChainResponse{ErrorMessage: stored.ErrorMessage}
+	if len(stored.Response) != 0 {
+		msg := &pb.CommonResponseMessage{}
+		if err := proto.Unmarshal(stored.Response, msg); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal response")
+		}
+		response.Response = msg
+	}
+	return response, nil
+}
+
+// classifyError 合约返回的确定性错误重试也不会成功, 标记为不可重试
+func classifyError(err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch {
+	case s.Group == status.ChaincodeStatus,
+		s.Group == status.EndorserServerStatus && s.Code >= 400 && s.Code < 600,
+		s.Group == status.EndorserClientStatus && s.Code == status.ChaincodeNameNotFound.ToInt32():
+		return cc.Permanent(err)
+	}
+	return err
+}
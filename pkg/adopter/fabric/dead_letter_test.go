@@ -0,0 +1,139 @@
+package fabric
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cc "github.com/fabric-creed/fabric-hub/pkg/adopter"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/deadletter"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterFromHandle(t *testing.T) {
+	f, hub, executor := newTestFabric(t)
+	l := &testLedger{}
+	l.append(nil)
+	l.append([][]byte{
+		newTestEnvelope(t, "tx1", newTestCallRequest("unknown", "t1")),
+		newTestEnvelope(t, "tx2", newTestCallRequest("to", "t2")),
+	})
+	f.querier = l
+
+	// 远端持续不可用, 重试耗尽后写入死信队列
+	hub.set(status.Error(codes.Unavailable, "unavailable"))
+	task := cc.NewCrossChainTask(f, cc.WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}))
+	blocks, requests, err := task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, blocks)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2, hub.count())
+
+	letters, err := deadletter.NewController(f.dbPath).FetchDeadLetters("local", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, letters, 2)
+	found := make(map[string]database.DeadLetter)
+	for _, letter := range letters {
+		found[letter.TransactionID] = letter
+	}
+
+	// 未配置目的链时不重试
+	unknown := found["t1"]
+	assert.True(t, unknown.Permanent)
+	assert.Equal(t, 1, unknown.Attempts)
+	assert.Equal(t, "tx1", unknown.TransactionHash)
+	assert.Equal(t, uint64(1), unknown.BlockNumber)
+	assert.Equal(t, database.OutboxFailed, outboxState(t, f, "t1"))
+
+	unavailable := found["t2"]
+	assert.False(t, unavailable.Permanent)
+	assert.Equal(t, 2, unavailable.Attempts)
+	assert.Len(t, unavailable.Errors, 2)
+	assert.Equal(t, "tx2", unavailable.TransactionHash)
+	assert.Equal(t, database.OutboxFailed, outboxState(t, f, "t2"))
+
+	// 只有不可重试的失败写入路由合约
+	assert.Equal(t, []string{FuncChainCodeInvokeResult}, executor.executed())
+	args := executor.lastArgs(FuncChainCodeInvokeResult)
+	assert.Equal(t, "t1", string(args[2]))
+	assert.Empty(t, args[4])
+	assert.Contains(t, string(args[6]), "the unknown client is not found")
+}
+
+func TestDeadLetterMalformedRequest(t *testing.T) {
+	f, hub, _ := newTestFabric(t)
+	l := &testLedger{}
+	l.append(nil)
+	l.append([][]byte{
+		newTestEventEnvelope(t, "tx1", []byte(`{"from":"from"}`)),
+		newTestEnvelope(t, "tx2", newTestCallRequest("to", "t2")),
+	})
+	f.querier = l
+	task := cc.NewCrossChainTask(f)
+
+	// 事件内容有误的交易写入死信队列, 不影响同一区块的其他请求
+	_, requests, err := task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, hub.count())
+	_, requests, err = task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+
+	// 重新处理区块时不重复写入
+	letters, err := deadletter.NewController(f.dbPath).FetchDeadLetters("local", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+	letter := letters[0]
+	assert.Equal(t, "tx1", letter.TransactionHash)
+	assert.True(t, letter.Permanent)
+	assert.Len(t, letter.Errors, 1)
+	assert.Equal(t, l.blocks[1].Data.Data[0], letter.OriginInfo)
+
+	// 无法重新处理
+	assert.NotNil(t, task.RetryDeadLetter(context.Background(), &letter))
+}
+
+func TestReplayRedrivesFailed(t *testing.T) {
+	f, hub, _ := newTestFabric(t)
+	l := &testLedger{}
+	l.append(nil)
+	l.append([][]byte{newTestEnvelope(t, "tx1", newTestCallRequest("to", "t1"))})
+	f.querier = l
+	task := cc.NewCrossChainTask(f, cc.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+
+	hub.set(status.Error(codes.InvalidArgument, "invalid"))
+	_, _, err := task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, database.OutboxFailed, outboxState(t, f, "t1"))
+
+	// 再次失败时更新同一条死信
+	_, _, err = task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	ctl := deadletter.NewController(f.dbPath)
+	letters, err := ctl.FetchDeadLetters("local", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, 2, letters[0].Attempts)
+	assert.Len(t, letters[0].Errors, 2)
+
+	// 重新处理区块时失败的请求同样重新发送, 成功后删除死信
+	hub.set(nil)
+	_, _, err = task.Replay(context.Background(), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, hub.count())
+	assert.Equal(t, database.OutboxCallbackDone, outboxState(t, f, "t1"))
+	letters, err = ctl.FetchDeadLetters("local", 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, letters, 0)
+}
@@ -1,20 +1,25 @@
 package fabric
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/fabric-creed/fabric-hub/global"
+	"github.com/asdine/storm/v3"
 	cc "github.com/fabric-creed/fabric-hub/pkg/adopter"
 	"github.com/fabric-creed/fabric-hub/pkg/client"
 	"github.com/fabric-creed/fabric-hub/pkg/database"
 	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/lightclient"
 	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
 	"github.com/fabric-creed/fabric-hub/pkg/modules/transaction"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
 	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,20 +28,70 @@ const (
 	FuncChainCodeInvokeResult = "ChainCodeInvokeResult"
 )
 
+const (
+	// 轮询 QueryBlock 获取区块
+	BlockSourcePoll = "poll"
+	// 订阅区块事件获取区块, 需要具有接收完整区块事件的权限
+	BlockSourceEvent = "event"
+)
+
+const (
+	// 保存完整区块
+	BlockStoreFull = "full"
+	// 只保存处理进度的检查点及跨链交易, 不保存完整区块
+	BlockStoreCheckpoint = "checkpoint"
+)
+
 type Fabric struct {
-	dbPath              string
-	fab                 *fabric.Client
-	hubClientMap        map[string]*client.HubClient
-	channelID           string
+	dbPath       string
+	fab          *fabric.Client
+	hubClientMap map[string]*client.HubClient
+	// 网关配置中的通道ID, 作为区块、交易、处理进度与处理记录的存储范围
+	id string
+	// Fabric 通道名称, 不同网络的通道可能同名
+	channelName         string
 	isGM                bool
-	blockNum            uint64
 	routerChainCodeName string
+	// 通道合约调用与账本查询, 为 nil 时由 fab 创建
+	executor channelExecutor
+	querier  fabric.BlockQuerier
+	// 是否通过区块事件获取区块
+	blockEvents  bool
+	maxEventGap  uint64
+	subscription *fabric.BlockSubscription
+	// 未保存处理进度时的起始区块, startFromLatest 为 true 时从账本当前高度开始
+	startBlock      uint64
+	startFromLatest bool
+	// 是否沿用按通道划分前保存的区块进度, 旧数据不区分通道, 只适用于单通道的网关
+	legacyBlocks bool
+	// 是否只保存检查点, 不保存完整区块
+	checkpointOnly bool
+	// 完整区块的保留区块数及天数, 为 0 时不按该条件清理
+	retainBlocks uint64
+	retainDays   int
+	prunedAt     time.Time
+	// 上次清理后是否仍有过期区块
+	pruneBacklog bool
+	// 下一个处理的区块, 首次获取区块时初始化
+	nextBlock uint64
+	started   bool
+	// lock 保护管理接口设置的下一个处理的区块及中断当前等待的函数
+	lock      sync.Mutex
+	pending   *uint64
+	interrupt context.CancelFunc
+}
+
+// channelExecutor 调用通道合约, 由 *fabric.Channel 实现
+type channelExecutor interface {
+	ChannelExecute(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error)
 }
 
-func NewFabric(dbPath string, channelID, routerChainCodeName string, isGM bool, options ...Option) *Fabric {
+// NewFabric 创建本地通道的适配器, id 为网关配置中的通道ID, channelName 为 Fabric 通道名称
+func NewFabric(dbPath string, id, channelName, routerChainCodeName string, isGM bool, options ...Option) *Fabric {
 	fabric := &Fabric{
 		dbPath:              dbPath,
-		channelID:           channelID,
+		id:                  id,
+		channelName:         channelName,
 		isGM:                isGM,
 		routerChainCodeName: routerChainCodeName,
 	}
@@ -60,122 +115,273 @@ func WithHubClientMap(hubClientMap map[string]*client.HubClient) Option {
 	}
 }
 
-func (f *Fabric) FetchNextBlock() (*cc.BlockInfo, error) {
-	if f.blockNum == 0 {
-		blockNum, err := block.NewController(f.dbPath).FetchLatestBlockNum()
-		if err != nil {
-			logrus.Errorf("failed to fetch latest block num, err:%s", err.Error())
+// WithBlockEvents 通过区块事件获取区块, 落后超过 maxGap 个区块时先通过 QueryBlock 追赶
+func WithBlockEvents(maxGap uint64) Option {
+	return func(f *Fabric) {
+		f.blockEvents = true
+		f.maxEventGap = maxGap
+	}
+}
+
+// WithStartBlock 设置未保存处理进度时的起始区块, latest 为 true 时从账本当前高度开始
+func WithStartBlock(number uint64, latest bool) Option {
+	return func(f *Fabric) {
+		f.startBlock = number
+		f.startFromLatest = latest
+	}
+}
+
+// WithLegacyBlockStore 未保存处理进度时沿用按通道划分前保存的最新区块
+func WithLegacyBlockStore() Option {
+	return func(f *Fabric) {
+		f.legacyBlocks = true
+	}
+}
+
+func (f *Fabric) channel() (channelExecutor, error) {
+	if f.executor != nil {
+		return f.executor, nil
+	}
+	return f.fab.Channel(f.channelName)
+}
+
+func (f *Fabric) ledger() (fabric.BlockQuerier, error) {
+	if f.querier != nil {
+		return f.querier, nil
+	}
+	return f.fab.Ledger(f.channelName, f.isGM)
+}
+
+// FetchNextBlock 获取下一个区块, 管理接口设置下一个处理的区块时中断等待并从新的区块继续
+func (f *Fabric) FetchNextBlock(ctx context.Context) (*cc.BlockInfo, error) {
+	for {
+		if err := f.prepareCursor(); err != nil {
+			logrus.Errorf("failed to prepare the next block, err:%s", err.Error())
 			return nil, err
 		}
-		f.blockNum = blockNum
+		fetchCtx, cancel := f.fetchContext(ctx)
+		var (
+			data *cc.BlockInfo
+			err  error
+		)
+		if f.blockEvents {
+			data, err = f.nextEventBlock(fetchCtx)
+		} else {
+			data, err = f.nextQueryBlock(fetchCtx)
+		}
+		cancel()
+		if err != nil && ctx.Err() == nil && fetchCtx.Err() != nil {
+			continue
+		}
+		return data, err
 	}
+}
+
+// nextQueryBlock 轮询 QueryBlock 获取下一个区块
+func (f *Fabric) nextQueryBlock(ctx context.Context) (*cc.BlockInfo, error) {
 	for {
-		data, err := f.queryBlock(f.blockNum + 1)
+		data, err := f.queryBlock(f.nextBlock)
 		if err != nil {
-			if !strings.Contains(err.Error(), "Entry not found in index") {
-				logrus.Errorf("failed to handle(%v): %v", f.blockNum+1, err)
-			}
-			time.Sleep(2 * time.Second)
-		} else {
-			logrus.Infof("succeeded to handle(%v)", f.blockNum+1)
-			f.blockNum++
+			logrus.Errorf("failed to handle(%v): %v", f.nextBlock, err)
+		} else if data != nil {
+			logrus.Infof("succeeded to handle(%v)", f.nextBlock)
+			f.nextBlock++
 			return data, nil
 		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
 	}
 }
 
-func (f *Fabric) HandleCrossChainRequest(request interface{}) (*cc.CrossChainResponse, error) {
-	response := &cc.CrossChainResponse{}
-	tctl := transaction.NewController(f.dbPath)
-	if fccr, ok := request.(FabricCrossChainRequest); ok {
-		// 首先判断txHash是否已经存在了,存在则跳过
-		_, err := tctl.FetchTransactionByTransactionHash(fccr.TxHash)
-		if err == nil {
-			return nil, nil
+// nextEventBlock 从区块事件获取下一个区块, 从下一个处理的区块开始订阅
+func (f *Fabric) nextEventBlock(ctx context.Context) (*cc.BlockInfo, error) {
+	if f.subscription == nil {
+		ledger, err := f.ledger()
+		if err != nil {
+			return nil, err
 		}
+		f.subscription = fabric.NewBlockSubscription(f.fab.EventSource(f.channelName), ledger, f.nextBlock, f.isGM,
+			fabric.WithMaxEventGap(f.maxEventGap))
+	}
+	pbBlock, err := f.subscription.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := f.blockInfo(pbBlock)
+	if err != nil {
+		// 重新订阅以便再次处理该区块
+		f.closeSubscription()
+		logrus.Errorf("failed to handle(%v): %v", f.nextBlock, err)
+		return nil, err
+	}
+	logrus.Infof("succeeded to handle(%v)", f.nextBlock)
+	f.nextBlock++
+	return data, nil
+}
 
-		switch fccr.Request.(type) {
-		case *pb.NoTransactionCallRequest:
-			req := fccr.Request.(*pb.NoTransactionCallRequest)
-			if _, ok := f.hubClientMap[req.To]; ok {
-				resp, err := f.hubClientMap[req.To].NoTransactionCall(req)
-				if err != nil {
-					logrus.Errorf("failed to call no transaction, err:%s", err.Error())
-					response.ErrorMessage = err.Error()
-					return response, nil
-				}
-				response.Response = resp
-			} else {
-				response.ErrorMessage = fmt.Sprintf("the %s client is not found", req.To)
-			}
-		default:
-			return nil, errors.New("invalid cross chain request")
-		}
+func (f *Fabric) closeSubscription() {
+	if f.subscription != nil {
+		f.subscription.Close()
+		f.subscription = nil
+	}
+}
 
-		err = tctl.Create(fccr.BlockNumber, fccr.BlockHash, fccr.TxHash, fccr.OriginInfo)
-		if err != nil {
-			logrus.Errorf("failed to create transaction, err:%s", err.Error())
-			return nil, err
+// HandleCrossChainRequest 按处理记录的状态继续处理, 已收到响应的请求不会重复发送
+func (f *Fabric) HandleCrossChainRequest(ctx context.Context, request interface{}) (*cc.CrossChainResponse, error) {
+	fccr, ok := request.(FabricCrossChainRequest)
+	if !ok {
+		return nil, cc.Permanent(errors.New("invalid fabric cross chain request"))
+	}
+	req, ok := fccr.Request.(*pb.NoTransactionCallRequest)
+	if !ok {
+		return nil, cc.Permanent(errors.New("invalid cross chain request"))
+	}
+	record, err := f.fetchOutbox(fccr, req)
+	if err != nil {
+		return nil, err
+	}
+	switch record.State {
+	case database.OutboxCallbackDone:
+		// 请求已处理过
+		return nil, nil
+	case database.OutboxFailed:
+		// 失败的请求只由死信队列重新处理
+		if !fccr.Redrive {
+			return nil, nil
 		}
+		if len(record.Response) != 0 {
+			return decodeResponse(record.Response)
+		}
+	case database.OutboxResponded, database.OutboxResultWritten:
+		return decodeResponse(record.Response)
+	case database.OutboxSent:
+		// 发送后未保存响应, 由远端网关保证重复请求的幂等
+		logrus.Warnf("the response of cross chain request %s is not saved, send it again", record.Key)
+	}
+
+	// 失败时返回错误, 由跨链任务按重试策略重试, 重试用尽或不可重试时进入死信队列并标记为失败
+	hubClient, ok := f.hubClientMap[req.To]
+	if !ok {
+		return nil, cc.Permanent(errors.Errorf("the %s client is not found", req.To))
+	}
+	if err = f.transitOutbox(record, database.OutboxSent); err != nil {
+		return nil, err
+	}
+	resp, err := hubClient.NoTransactionCall(ctx, req)
+	if err != nil {
+		// 保留 grpc 状态以便按重试策略判断是否可重试, 记录停留在已发送, 重试时再次发送
+		logrus.Errorf("failed to call no transaction, err:%s", err.Error())
+		return nil, err
+	}
+	response := &cc.CrossChainResponse{Response: resp}
+	if record.Response, err = encodeResponse(response); err != nil {
+		return nil, err
+	}
+	if err = f.transitOutbox(record, database.OutboxResponded); err != nil {
+		return nil, err
+	}
 
-		return response, nil
+	err = transaction.NewController(f.dbPath, f.id).Create(fccr.BlockNumber, fccr.BlockHash, fccr.TxHash, fccr.OriginInfo)
+	// 重新发送的请求已有交易记录
+	if err != nil && err != storm.ErrAlreadyExists {
+		logrus.Errorf("failed to create transaction, err:%s", err.Error())
+		return nil, err
 	}
 
-	return nil, errors.New("invalid fabric cross chain request")
+	return response, nil
+}
+
+// SequenceKey 同一来源链、目的链与交易ID的请求按顺序处理, 如同一跨链交易的多个步骤
+func (f *Fabric) SequenceKey(request interface{}) string {
+	if fccr, ok := request.(FabricCrossChainRequest); ok {
+		if req, ok := fccr.Request.(*pb.NoTransactionCallRequest); ok {
+			return fmt.Sprintf("%s-%s-%s", req.From, req.To, req.TransactionID)
+		}
+	}
+	return ""
 }
 
-func (f *Fabric) HandleCrossChainCallbackRequest(response cc.CrossChainResponse) error {
-	cl, err := f.fab.Channel(f.channelID)
+func (f *Fabric) HandleCrossChainCallbackRequest(ctx context.Context, response cc.CrossChainResponse) error {
+	cl, err := f.channel()
 	if err != nil {
 		return err
 	}
 	switch response.Response.(type) {
 	case *pb.CommonResponseMessage:
 		msg := response.Response.(*pb.CommonResponseMessage)
-		_, err = cl.ChannelExecute(channel.Request{
-			ChaincodeID: f.routerChainCodeName,
-			Fcn:         FuncChainCodeInvokeResult,
-			Args: [][]byte{
-				[]byte(msg.From),
-				[]byte(msg.To),
-				[]byte(msg.TransactionID),
-				[]byte(msg.StepID),
-				msg.Payload,
-				msg.Signer,
-				[]byte(response.ErrorMessage),
-			},
-			IsInit: false,
-		})
+		record, err := f.fetchOutboxByResponse(msg)
+		if err != nil {
+			return err
+		}
+		// 结果已写入路由合约时只重试业务回调
+		if record != nil && !record.ResultWrittenAt.IsZero() {
+			return f.callback(ctx, cl, msg, record)
+		}
+		err = f.invokeResult(ctx, cl, msg.From, msg.To, msg.TransactionID, msg.StepID, msg.Payload, msg.Signer, response.ErrorMessage)
 		if err != nil {
-			return errors.Wrap(err, "failed to call router invoke result")
+			return err
 		}
+		if err = f.transitOutbox(record, database.OutboxResultWritten); err != nil {
+			return err
+		}
+		return f.callback(ctx, cl, msg, record)
+	}
 
-		if msg.Callback != nil {
-			var callback pb.FabricCallback
-			err = json.Unmarshal(msg.Callback, &callback)
-			if err != nil {
-				return errors.Wrap(err, "failed to unmarshal fabric callback")
+	return nil
+}
+
+// invokeResult 将跨链调用结果写入路由合约, 请求失败时 payload 与 signer 为空, message 为错误信息
+func (f *Fabric) invokeResult(ctx context.Context, cl channelExecutor, from, to, transactionID, stepID string,
+	payload, signer []byte, message string) error {
+	_, err := cl.ChannelExecute(ctx, channel.Request{
+		ChaincodeID: f.routerChainCodeName,
+		Fcn:         FuncChainCodeInvokeResult,
+		Args: [][]byte{
+			[]byte(from),
+			[]byte(to),
+			[]byte(transactionID),
+			[]byte(stepID),
+			payload,
+			signer,
+			[]byte(message),
+		},
+		IsInit: false,
+	})
+	if err != nil {
+		return errors.Wrap(classifyError(err), "failed to call router invoke result")
+	}
+	return nil
+}
+
+// callback 执行响应中的业务回调
+func (f *Fabric) callback(ctx context.Context, cl channelExecutor, msg *pb.CommonResponseMessage, record *database.Outbox) error {
+	if msg.Callback != nil {
+		var callback pb.FabricCallback
+		err := json.Unmarshal(msg.Callback, &callback)
+		if err != nil {
+			return cc.Permanent(errors.Wrap(err, "failed to unmarshal fabric callback"))
+		}
+		if callback.CallbackChainCodeName != "" {
+			var args [][]byte
+			for i := range callback.CallbackArgs {
+				args = append(args, []byte(callback.CallbackArgs[i]))
 			}
-			if callback.CallbackChainCodeName != "" {
-				var args [][]byte
-				for i := range callback.CallbackArgs {
-					args = append(args, []byte(callback.CallbackArgs[i]))
-				}
-				_, err = cl.ChannelExecute(channel.Request{
-					ChaincodeID: callback.CallbackChainCodeName,
-					Fcn:         callback.CallbackFncName,
-					Args:        args,
-					IsInit:      false,
-				})
-				if err != nil {
-					return errors.Wrapf(err, "failed to execute call back chain code:%s", callback.CallbackChainCodeName)
-				}
+			_, err = cl.ChannelExecute(ctx, channel.Request{
+				ChaincodeID: callback.CallbackChainCodeName,
+				Fcn:         callback.CallbackFncName,
+				Args:        args,
+				IsInit:      false,
+			})
+			if err != nil {
+				return errors.Wrapf(classifyError(err), "failed to execute call back chain code:%s", callback.CallbackChainCodeName)
 			}
-
 		}
 	}
 
-	return nil
+	return f.transitOutbox(record, database.OutboxCallbackDone)
 }
 
 func (f *Fabric) SaveLatestBlock(blockData []byte) error {
@@ -184,6 +390,20 @@ func (f *Fabric) SaveLatestBlock(blockData []byte) error {
 	if err != nil {
 		return err
 	}
+	if !f.checkpointOnly {
+		if err = f.saveBlock(&pbBlock); err != nil {
+			return err
+		}
+	}
+	if err = f.saveCursor(&pbBlock); err != nil {
+		return err
+	}
+	f.pruneBlocks(pbBlock.Header.Number)
+
+	return nil
+}
+
+func (f *Fabric) saveBlock(pbBlock *fabric.Block) error {
 	dbBlock := &database.Block{
 		BlockNumber:  pbBlock.Header.Number,
 		PreviousHash: pbBlock.Header.PreviousHash,
@@ -199,46 +419,91 @@ func (f *Fabric) SaveLatestBlock(blockData []byte) error {
 	dbBlock.OriginInfo = data
 	dbBlock.TxNum = len(pbBlock.Data.Data)
 
-	err = block.NewController(global.Config.DBPath).CreateBlock(dbBlock)
-	if err != nil {
+	err = block.NewController(f.dbPath, f.id).CreateBlock(dbBlock)
+	// 回退或重新处理的区块已保存
+	if err != nil && err != storm.ErrAlreadyExists {
 		return err
 	}
-
 	return nil
 }
 
+// queryBlock 查询指定区块, 区块尚未生成时返回 nil
 func (f *Fabric) queryBlock(blockNumber uint64) (*cc.BlockInfo, error) {
-	ledger, err := f.fab.Ledger(f.channelID, f.isGM)
+	ledger, err := f.ledger()
 	if err != nil {
 		return nil, err
 	}
+	height, err := ledger.QueryHeight()
+	if err != nil {
+		return nil, err
+	}
+	if blockNumber >= height {
+		return nil, nil
+	}
 	pbBlock, err := ledger.QueryBlock(blockNumber)
 	if err != nil {
 		return nil, err
 	}
-	if pbBlock.Header == nil {
+	return f.blockInfo(pbBlock)
+}
+
+// blockInfo 解析区块中的跨链请求
+func (f *Fabric) blockInfo(pbBlock *fabric.Block) (*cc.BlockInfo, error) {
+	if pbBlock == nil || pbBlock.Header == nil {
 		return nil, fmt.Errorf("block header should not be nil")
 	}
 
+	// 按原始区块的下标遍历交易, 解码后的区块丢弃了无法解析的交易, 下标与交易验证结果不再对应
+	rawBlock := &common.Block{}
+	if err := proto.Unmarshal(pbBlock.OriginData, rawBlock); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal origin block")
+	}
 	var requests []interface{}
-	if pbBlock.Data != nil {
-		for i := range pbBlock.Data.Data {
-			request, txHash, err := parseEnvelope(pbBlock.Data.Data[i])
+	if rawBlock.Data != nil {
+		for i, data := range rawBlock.Data.Data {
+			envelope, err := fabric.UnmarshalEnvelope(data)
 			if err != nil {
+				logrus.Errorf("failed to unmarshal transaction %d of block %d, err:%s", i, pbBlock.Header.Number, err.Error())
+				continue
+			}
+			request, txHash, err := f.parseEnvelope(envelope)
+			if err != nil && !cc.IsPermanent(err) {
 				return nil, err
 			}
-			if request != nil {
-				requests = append(requests, FabricCrossChainRequest{
-					TxHash:      txHash,
-					BlockNumber: pbBlock.Header.Number,
-					BlockHash:   pbBlock.BlockHash,
-					OriginInfo:  pbBlock.OriginData,
-					Request:     request,
-				})
+			if request == nil && err == nil {
+				continue
+			}
+			// 只转发验证通过的交易, 无效交易未改变账本状态
+			if code := pbBlock.Metadata.TxValidationCode(i); code != peer.TxValidationCode_VALID {
+				f.skipInvalidTransaction(pbBlock, txHash, code)
+				continue
+			}
+			// 事件内容有误时写入死信队列并跳过该交易, 不阻塞后续区块
+			if err != nil {
+				f.putMalformedRequest(pbBlock, txHash, data, err)
+				continue
+			}
+			// 附带来源链的包含证明, 供目的网关核实交易确已提交
+			if req, ok := request.(*pb.NoTransactionCallRequest); ok {
+				if req.Proof, err = lightclient.NewInclusionProof(rawBlock, i); err != nil {
+					return nil, err
+				}
 			}
+			requests = append(requests, FabricCrossChainRequest{
+				TxHash:      txHash,
+				BlockNumber: pbBlock.Header.Number,
+				BlockHash:   pbBlock.BlockHash,
+				OriginInfo:  data,
+				Request:     request,
+			})
 		}
 	}
-	blockData, err := json.Marshal(*pbBlock)
+	// 只保存检查点时不需要区块数据
+	saved := *pbBlock
+	if f.checkpointOnly {
+		saved = fabric.Block{BlockHash: pbBlock.BlockHash, BlockTime: pbBlock.BlockTime, Header: pbBlock.Header}
+	}
+	blockData, err := json.Marshal(saved)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +514,19 @@ func (f *Fabric) queryBlock(blockNumber uint64) (*cc.BlockInfo, error) {
 	}, nil
 }
 
-func parseEnvelope(envelope *fabric.Envelope) (interface{}, string, error) {
+// skipInvalidTransaction 记录被跳过的无效交易及其验证结果, 记录失败时只输出日志
+func (f *Fabric) skipInvalidTransaction(pbBlock *fabric.Block, txHash string, code peer.TxValidationCode) {
+	logrus.Warnf("skip cross chain request of invalid transaction %s in block %d, validation code:%s",
+		txHash, pbBlock.Header.Number, code)
+	err := transaction.NewController(f.dbPath, f.id).CreateInvalid(pbBlock.Header.Number, pbBlock.BlockHash, txHash, int32(code))
+	if err != nil && err != storm.ErrAlreadyExists {
+		logrus.Errorf("failed to record invalid transaction %s, err:%s", txHash, err.Error())
+	}
+}
+
+// parseEnvelope 从交易的合约事件中解析路由合约发出的跨链请求. Fabric 只保留交易最外层合约
+// 设置的事件, 经其他合约调用路由合约发起的跨链请求无法被发现. 事件内容有误时返回不可重试的错误
+func (f *Fabric) parseEnvelope(envelope *fabric.Envelope) (interface{}, string, error) {
 	if envelope.Payload == nil {
 		return nil, "", nil
 	}
@@ -269,47 +546,30 @@ func parseEnvelope(envelope *fabric.Envelope) (interface{}, string, error) {
 		return nil, txHash, nil
 	}
 	action := payload.Transaction.Actions[0]
-	if action.Payload == nil {
-		return nil, txHash, nil
-	}
-	if action.Payload.ChaincodeProposalPayload == nil {
+	if action.Payload == nil || action.Payload.Action == nil {
 		return nil, txHash, nil
 	}
-	chainCodeProposalPayload := action.Payload.ChaincodeProposalPayload
-	if chainCodeProposalPayload.Input == nil {
+	responsePayload := action.Payload.Action.ProposalResponsePayload
+	if responsePayload == nil || responsePayload.ChaincodeAction == nil {
 		return nil, txHash, nil
 	}
-	input := chainCodeProposalPayload.Input
-	if input.ChaincodeSpec == nil {
+	event := responsePayload.ChaincodeAction.Events
+	if event == nil || event.ChaincodeId != f.routerChainCodeName || event.EventName != fabric.CrossChainRequestEvent {
 		return nil, txHash, nil
 	}
 
-	if input.ChaincodeSpec.Input == nil {
-		return nil, txHash, nil
-	}
-	inputArgs := input.ChaincodeSpec.Input.Args
-	logrus.Infof("========input args:%v", inputArgs)
-	if len(inputArgs) == 0 {
-		return nil, txHash, nil
+	request, err := fabric.UnmarshalCrossChainRequest([]byte(event.Payload))
+	if err != nil {
+		return nil, txHash, cc.Permanent(errors.WithMessagef(err, "transaction %s", txHash))
 	}
-	switch inputArgs[0] {
-	case FuncChainCodeInvoke:
-		if len(inputArgs) != 7 {
-			return nil, txHash, errors.New("input args is not equal 7")
-		}
-		req := &pb.NoTransactionCallRequest{
-			From:          inputArgs[1],
-			To:            inputArgs[2],
-			TransactionID: inputArgs[3],
-			StepID:        inputArgs[4],
-			Payload:       []byte(inputArgs[5]),
-			Signer:        []byte(inputArgs[6]),
-			Timestamp:     time.Now().Unix(),
-		}
-		return req, txHash, nil
-	default:
-		return nil, txHash, nil
+	req := &pb.NoTransactionCallRequest{
+		From:          request.From,
+		To:            request.To,
+		TransactionID: request.TransactionID,
+		StepID:        request.StepID,
+		Payload:       []byte(request.Payload),
+		Signer:        []byte(request.Signer),
+		Timestamp:     request.Timestamp,
 	}
-
-	return nil, txHash, nil
+	return req, txHash, nil
 }
@@ -0,0 +1,177 @@
+package fabric
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/transaction"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/msp"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/ledger"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// testLedger 内存中的账本, 区块号即下标
+type testLedger struct {
+	lock   sync.Mutex
+	blocks []*common.Block
+}
+
+func (l *testLedger) QueryBlock(blockNumber uint64, options ...ledger.RequestOption) (*fabric.Block, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if blockNumber >= uint64(len(l.blocks)) {
+		return nil, errors.Errorf("block %d is not found", blockNumber)
+	}
+	return fabric.DecodeBlock(l.blocks[blockNumber], false)
+}
+
+func (l *testLedger) QueryHeight(options ...ledger.RequestOption) (uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return uint64(len(l.blocks)), nil
+}
+
+// append 追加区块, codes 为各交易的验证结果, 为空时全部有效
+func (l *testLedger) append(data [][]byte, codes ...peer.TxValidationCode) *common.Block {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	filter := make([]byte, len(data))
+	for i := range filter {
+		filter[i] = byte(peer.TxValidationCode_VALID)
+		if i < len(codes) {
+			filter[i] = byte(codes[i])
+		}
+	}
+	block := &common.Block{
+		Header: &common.BlockHeader{
+			Number:   uint64(len(l.blocks)),
+			DataHash: util.Hash(bytes.Join(data, nil), false),
+		},
+		Data: &common.BlockData{Data: data},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			common.BlockMetadataIndex_SIGNATURES:          {},
+			common.BlockMetadataIndex_LAST_CONFIG:         {},
+			common.BlockMetadataIndex_TRANSACTIONS_FILTER: filter,
+		}},
+	}
+	l.blocks = append(l.blocks, block)
+	return block
+}
+
+// newTestEnvelope 构造交易, request 不为 nil 时由路由合约发出跨链请求事件
+func newTestEnvelope(t *testing.T, txID string, request *pb.NoTransactionCallRequest) []byte {
+	if request == nil {
+		return newTestEventEnvelope(t, txID, nil)
+	}
+	payload, err := json.Marshal(map[string]string{
+		"from":          request.From,
+		"to":            request.To,
+		"transactionId": request.TransactionID,
+		"stepID":        request.StepID,
+		"payload":       string(request.Payload),
+	})
+	assert.Nil(t, err)
+	return newTestEventEnvelope(t, txID, payload)
+}
+
+// newTestEventEnvelope 构造交易, event 不为 nil 时由路由合约发出内容为 event 的跨链请求事件
+func newTestEventEnvelope(t *testing.T, txID string, event []byte) []byte {
+	spec, err := proto.Marshal(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+		ChaincodeId: &peer.ChaincodeID{Name: "router"},
+		Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte(FuncChainCodeInvoke)}},
+	}})
+	assert.Nil(t, err)
+	proposalPayload, err := proto.Marshal(&peer.ChaincodeProposalPayload{Input: spec})
+	assert.Nil(t, err)
+	action := &peer.ChaincodeAction{}
+	if event != nil {
+		action.Events, err = proto.Marshal(&peer.ChaincodeEvent{
+			ChaincodeId: "router",
+			TxId:        txID,
+			EventName:   fabric.CrossChainRequestEvent,
+			Payload:     event,
+		})
+		assert.Nil(t, err)
+	}
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert")})
+	assert.Nil(t, err)
+	extension, err := proto.Marshal(action)
+	assert.Nil(t, err)
+	responsePayload, err := proto.Marshal(&peer.ProposalResponsePayload{ProposalHash: []byte("hash"), Extension: extension})
+	assert.Nil(t, err)
+	actionPayload, err := proto.Marshal(&peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: proposalPayload,
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: responsePayload,
+			Endorsements:            []*peer.Endorsement{{Endorser: identity, Signature: []byte("sig")}},
+		},
+	})
+	assert.Nil(t, err)
+	tx, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: actionPayload}}})
+	assert.Nil(t, err)
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: "local",
+		TxId:      txID,
+		Timestamp: ptypes.TimestampNow(),
+	})
+	assert.Nil(t, err)
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: identity})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader},
+		Data:   tx,
+	})
+	assert.Nil(t, err)
+	envelope, err := proto.Marshal(&common.Envelope{Payload: payload, Signature: []byte("signature")})
+	assert.Nil(t, err)
+	return envelope
+}
+
+// newTestCallRequest 构造发往 to 的跨链请求
+func newTestCallRequest(to, transactionID string) *pb.NoTransactionCallRequest {
+	return &pb.NoTransactionCallRequest{
+		From:          "from",
+		To:            to,
+		TransactionID: transactionID,
+		StepID:        "1",
+		Payload:       []byte(`{}`),
+	}
+}
+
+func TestBlockInfoRawIndex(t *testing.T) {
+	f, _, _ := newTestFabric(t)
+	l := &testLedger{}
+	l.append(nil)
+	data := [][]byte{
+		// 无法解析的交易在解码后的区块中被丢弃
+		[]byte("\xff\xff"),
+		newTestEnvelope(t, "tx1", newTestCallRequest("to", "t1")),
+		newTestEnvelope(t, "tx2", newTestCallRequest("to", "t2")),
+	}
+	l.append(data, peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID)
+	f.querier = l
+
+	// 验证结果、来源信息与包含证明按原始区块的下标对应
+	info, err := f.FetchBlock(1)
+	assert.Nil(t, err)
+	assert.Len(t, info.CrossChainRequests, 1)
+	request := info.CrossChainRequests[0].(FabricCrossChainRequest)
+	assert.Equal(t, "tx2", request.TxHash)
+	assert.Equal(t, data[2], request.OriginInfo)
+	assert.Equal(t, uint32(2), request.Request.(*pb.NoTransactionCallRequest).Proof.TxIndex)
+
+	invalid, err := transaction.NewController(f.dbPath, f.id).FetchTransactionByTransactionHash("tx1")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(peer.TxValidationCode_MVCC_READ_CONFLICT), invalid.ValidationCode)
+}
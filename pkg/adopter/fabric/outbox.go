@@ -0,0 +1,91 @@
+package fabric
+
+import (
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// fetchOutbox 获取跨链请求的处理记录, 首次检测到时创建
+func (f *Fabric) fetchOutbox(fccr FabricCrossChainRequest, req *pb.NoTransactionCallRequest) (*database.Outbox, error) {
+	octl := outbox.NewController(f.dbPath)
+	key := outbox.Key(f.id, req.TransactionID, req.StepID)
+	record, err := octl.FetchByKey(key)
+	if err == nil {
+		return record, nil
+	}
+	if err != storm.ErrNotFound {
+		return nil, errors.Wrapf(err, "failed to fetch outbox %s", key)
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal cross chain request")
+	}
+	record = &database.Outbox{
+		Key:             key,
+		ChannelID:       f.id,
+		From:            req.From,
+		To:              req.To,
+		TransactionID:   req.TransactionID,
+		StepID:          req.StepID,
+		TransactionHash: fccr.TxHash,
+		BlockNumber:     fccr.BlockNumber,
+		Request:         data,
+	}
+	if err = octl.Create(record); err != nil {
+		// 同一请求被并发处理时已由另一方创建
+		if err == storm.ErrAlreadyExists {
+			if record, err = octl.FetchByKey(key); err == nil {
+				return record, nil
+			}
+		}
+		return nil, errors.Wrapf(err, "failed to create outbox %s", key)
+	}
+	return record, nil
+}
+
+// fetchOutboxByResponse 获取响应对应的处理记录, 记录不存在时返回 nil
+func (f *Fabric) fetchOutboxByResponse(msg *pb.CommonResponseMessage) (*database.Outbox, error) {
+	key := outbox.Key(f.id, msg.TransactionID, msg.StepID)
+	record, err := outbox.NewController(f.dbPath).FetchByKey(key)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to fetch outbox %s", key)
+	}
+	return record, nil
+}
+
+// transitOutbox 切换处理记录的状态, record 为 nil 时忽略
+func (f *Fabric) transitOutbox(record *database.Outbox, state database.OutboxState) error {
+	if record == nil {
+		return nil
+	}
+	if err := outbox.NewController(f.dbPath).Transit(record, state); err != nil {
+		return errors.Wrapf(err, "failed to transit outbox %s to %s", record.Key, state)
+	}
+	return nil
+}
+
+// failOutbox 将请求的处理记录标记为失败, 记录不存在时忽略
+func (f *Fabric) failOutbox(transactionID, stepID, message string) {
+	octl := outbox.NewController(f.dbPath)
+	key := outbox.Key(f.id, transactionID, stepID)
+	record, err := octl.FetchByKey(key)
+	if err != nil {
+		if err != storm.ErrNotFound {
+			logrus.Errorf("failed to fetch outbox %s, err:%s", key, err.Error())
+		}
+		return
+	}
+	record.Error = message
+	if err = octl.Transit(record, database.OutboxFailed); err != nil {
+		logrus.Errorf("failed to transit outbox %s to %s, err:%s", key, database.OutboxFailed, err.Error())
+	}
+}
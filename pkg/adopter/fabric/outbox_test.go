@@ -0,0 +1,290 @@
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/internal/testutil"
+	cc "github.com/fabric-creed/fabric-hub/pkg/adopter"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHub 远端网关, err 不为 nil 时调用失败
+type testHub struct {
+	pb.UnimplementedHubServer
+	csp *sw.SimpleCSP
+
+	lock  sync.Mutex
+	calls int
+	err   error
+}
+
+func (h *testHub) NoTransactionCall(ctx context.Context, req *pb.NoTransactionCallRequest) (*pb.CommonResponseMessage, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.calls++
+	if h.err != nil {
+		return nil, h.err
+	}
+	payload := []byte(`{"payload":"ok"}`)
+	sig, err := h.csp.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+	callback, err := json.Marshal(&pb.FabricCallback{CallbackChainCodeName: "business", CallbackFncName: "Done"})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CommonResponseMessage{
+		From:          req.From,
+		To:            req.To,
+		TransactionID: req.TransactionID,
+		StepID:        req.StepID,
+		Payload:       payload,
+		Signer:        sig,
+		KeyID:         h.csp.KeyID(),
+		Algorithm:     h.csp.Algorithm(),
+		Callback:      callback,
+	}, nil
+}
+
+func (h *testHub) set(err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.err = err
+}
+
+func (h *testHub) count() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.calls
+}
+
+// testExecutor 记录调用的合约方法及参数, failures 中的方法调用失败
+type testExecutor struct {
+	lock     sync.Mutex
+	calls    []string
+	args     [][][]byte
+	failures map[string]error
+}
+
+func (e *testExecutor) ChannelExecute(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.calls = append(e.calls, request.Fcn)
+	e.args = append(e.args, request.Args)
+	if err := e.failures[request.Fcn]; err != nil {
+		return channel.Response{}, err
+	}
+	return channel.Response{}, nil
+}
+
+func (e *testExecutor) fail(fcn string, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err == nil {
+		delete(e.failures, fcn)
+		return
+	}
+	e.failures[fcn] = err
+}
+
+func (e *testExecutor) executed() []string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]string{}, e.calls...)
+}
+
+// lastArgs 返回最近一次调用 fcn 的参数
+func (e *testExecutor) lastArgs(fcn string) [][]byte {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for i := len(e.calls) - 1; i >= 0; i-- {
+		if e.calls[i] == fcn {
+			return e.args[i]
+		}
+	}
+	return nil
+}
+
+// newTestFabric 创建连接到本地远端网关的 Fabric, 远端通道为 to
+func newTestFabric(t *testing.T) (*Fabric, *testHub, *testExecutor) {
+	csp := testutil.NewTestCSP(t)
+	hub := &testHub{csp: csp}
+	server, err := cgrpc.NewGRPCServer("127.0.0.1:0", cgrpc.ServerConfig{})
+	assert.Nil(t, err)
+	pb.RegisterHubServer(server.Server(), hub)
+	go server.Start()
+	t.Cleanup(server.Stop)
+
+	grpcClient, err := cgrpc.NewGRPCClient(cgrpc.ClientConfig{
+		KaOpts:  cgrpc.DefaultKeepaliveOptions,
+		Timeout: time.Second,
+	})
+	assert.Nil(t, err)
+	host, port, err := net.SplitHostPort(server.Address())
+	assert.Nil(t, err)
+	portNum, err := strconv.Atoi(port)
+	assert.Nil(t, err)
+	hubClient, err := client.NewHubClient(host, uint32(portNum), grpcClient,
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+	assert.Nil(t, err)
+	hubClient.SetCSP(map[string]*sw.SimpleCSP{"from": csp, "to": csp})
+
+	executor := &testExecutor{failures: make(map[string]error)}
+	f := NewFabric(t.TempDir(), "local", "mychannel", "router", false,
+		WithHubClientMap(map[string]*client.HubClient{"to": hubClient}))
+	f.executor = executor
+	return f, hub, executor
+}
+
+func newTestRequest(to, transactionID string) FabricCrossChainRequest {
+	return FabricCrossChainRequest{
+		TxHash:      "hash-" + transactionID,
+		BlockNumber: 1,
+		Request: &pb.NoTransactionCallRequest{
+			From:          "from",
+			To:            to,
+			TransactionID: transactionID,
+			StepID:        "1",
+			Payload:       []byte(`{}`),
+		},
+	}
+}
+
+func outboxState(t *testing.T, f *Fabric, transactionID string) database.OutboxState {
+	record, err := outbox.NewController(f.dbPath).FetchByKey(outbox.Key(f.id, transactionID, "1"))
+	assert.Nil(t, err)
+	return record.State
+}
+
+func TestOutboxStateMachine(t *testing.T) {
+	f, hub, executor := newTestFabric(t)
+	ctx := context.Background()
+	request := newTestRequest("to", "t1")
+
+	// 检测到请求后发送, 收到响应后保存
+	response, err := f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.NotNil(t, response.Response)
+	assert.Equal(t, database.OutboxResponded, outboxState(t, f, "t1"))
+	assert.Equal(t, 1, hub.count())
+
+	// 写入路由合约失败时停留在已响应, 重新处理时使用保存的响应
+	executor.fail(FuncChainCodeInvokeResult, errors.New("timeout"))
+	assert.NotNil(t, f.HandleCrossChainCallbackRequest(ctx, *response))
+	assert.Equal(t, database.OutboxResponded, outboxState(t, f, "t1"))
+	response, err = f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, hub.count())
+
+	// 结果已写入而业务回调失败时, 只重试业务回调
+	executor.fail(FuncChainCodeInvokeResult, nil)
+	executor.fail("Done", errors.New("timeout"))
+	assert.NotNil(t, f.HandleCrossChainCallbackRequest(ctx, *response))
+	assert.Equal(t, database.OutboxResultWritten, outboxState(t, f, "t1"))
+	executor.fail("Done", nil)
+	assert.Nil(t, f.HandleCrossChainCallbackRequest(ctx, *response))
+	assert.Equal(t, database.OutboxCallbackDone, outboxState(t, f, "t1"))
+	assert.Equal(t, []string{FuncChainCodeInvokeResult, FuncChainCodeInvokeResult, "Done", "Done"}, executor.executed())
+	// 路由合约收到原始的签名
+	msg := response.Response.(*pb.CommonResponseMessage)
+	assert.Equal(t, [][]byte{[]byte("from"), []byte("to"), []byte("t1"), []byte("1"), msg.Payload, msg.Signer, {}},
+		executor.lastArgs(FuncChainCodeInvokeResult))
+
+	// 已完成的请求不再处理
+	response, err = f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, 1, hub.count())
+}
+
+func TestOutboxResendFromSent(t *testing.T) {
+	f, hub, _ := newTestFabric(t)
+	ctx := context.Background()
+	request := newTestRequest("to", "t1")
+
+	// 远端暂时不可用时返回原始错误, 记录停留在已发送
+	hub.set(status.Error(codes.Unavailable, "unavailable"))
+	response, err := f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, response)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.False(t, cc.IsPermanent(err))
+	assert.Equal(t, database.OutboxSent, outboxState(t, f, "t1"))
+
+	// 重新处理时再次发送
+	hub.set(nil)
+	response, err = f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.NotNil(t, response.Response)
+	assert.Equal(t, database.OutboxResponded, outboxState(t, f, "t1"))
+	assert.Equal(t, 2, hub.count())
+}
+
+func TestOutboxSkipFailed(t *testing.T) {
+	f, hub, _ := newTestFabric(t)
+	ctx := context.Background()
+
+	// 未配置目的链时不可重试, 且不发送
+	_, err := f.HandleCrossChainRequest(ctx, newTestRequest("unknown", "t1"))
+	assert.True(t, cc.IsPermanent(err))
+	assert.Equal(t, database.OutboxDetected, outboxState(t, f, "t1"))
+
+	request := newTestRequest("to", "t2")
+	hub.set(status.Error(codes.InvalidArgument, "invalid"))
+	_, err = f.HandleCrossChainRequest(ctx, request)
+	assert.NotNil(t, err)
+	assert.Nil(t, f.PutDeadLetter(request, &cc.HandleError{Attempts: 1, Err: err}))
+	assert.Equal(t, database.OutboxFailed, outboxState(t, f, "t2"))
+
+	// 失败的请求在跟随区块或重新处理区块时跳过
+	hub.set(nil)
+	response, err := f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, 1, hub.count())
+
+	// 只由死信队列重新处理
+	request.Redrive = true
+	response, err = f.HandleCrossChainRequest(ctx, request)
+	assert.Nil(t, err)
+	assert.NotNil(t, response.Response)
+	assert.Equal(t, database.OutboxResponded, outboxState(t, f, "t2"))
+	assert.Equal(t, 2, hub.count())
+}
+
+func TestFetchOutboxConcurrently(t *testing.T) {
+	f, _, _ := newTestFabric(t)
+	request := newTestRequest("to", "t1")
+	req := request.Request.(*pb.NoTransactionCallRequest)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = f.fetchOutbox(request, req)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, database.OutboxDetected, outboxState(t, f, "t1"))
+}
@@ -4,6 +4,9 @@ type FabricCrossChainRequest struct {
 	TxHash      string
 	BlockNumber uint64
 	BlockHash   string
-	OriginInfo  []byte
-	Request     interface{}
+	// 跨链交易的原始 Envelope
+	OriginInfo []byte
+	Request    interface{}
+	// 由死信队列或重新处理区块触发, 处理记录为失败状态时仍继续处理
+	Redrive bool
 }
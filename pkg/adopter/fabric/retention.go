@@ -0,0 +1,59 @@
+package fabric
+
+import (
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPruneInterval 清理过期完整区块的最小间隔
+const DefaultPruneInterval = 10 * time.Minute
+
+// WithCheckpointStore 只保存处理进度的检查点及跨链交易, 不保存完整区块
+func WithCheckpointStore() Option {
+	return func(f *Fabric) {
+		f.checkpointOnly = true
+	}
+}
+
+// WithBlockRetention 设置完整区块的保留区块数及天数, 超过任一条件的区块被清理, 为 0 时不按该条件清理
+func WithBlockRetention(blocks uint64, days int) Option {
+	return func(f *Fabric) {
+		f.retainBlocks = blocks
+		f.retainDays = days
+	}
+}
+
+// pruneBlocks 按保留策略清理完整区块, 每次最多删除一批, 避免阻塞区块处理. 仍有过期区块时
+// 随下一个区块继续清理, 否则每个清理间隔最多执行一次. 失败时只记录日志
+func (f *Fabric) pruneBlocks(latest uint64) {
+	if f.retainBlocks == 0 && f.retainDays <= 0 {
+		return
+	}
+	if !f.pruneBacklog && time.Since(f.prunedAt) < DefaultPruneInterval {
+		return
+	}
+	f.prunedAt = time.Now()
+	f.pruneBacklog = false
+
+	var (
+		beforeNumber uint64
+		beforeTime   time.Time
+	)
+	if f.retainBlocks > 0 && latest >= f.retainBlocks {
+		beforeNumber = latest - f.retainBlocks + 1
+	}
+	if f.retainDays > 0 {
+		beforeTime = time.Now().AddDate(0, 0, -f.retainDays)
+	}
+	pruned, err := block.NewController(f.dbPath, f.id).Prune(beforeNumber, beforeTime, block.PruneBatch)
+	if err != nil {
+		logrus.Errorf("failed to prune blocks of %s, err:%s", f.id, err.Error())
+		return
+	}
+	if pruned > 0 {
+		logrus.Infof("%d blocks of %s are pruned", pruned, f.id)
+	}
+	f.pruneBacklog = pruned == block.PruneBatch
+}
@@ -0,0 +1,105 @@
+package fabric
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	f, _, _ := newTestFabric(t)
+	WithCheckpointStore()(f)
+	l := &testLedger{}
+	l.append(nil)
+	l.append([][]byte{newTestEnvelope(t, "tx1", newTestCallRequest("to", "t1"))})
+	f.querier = l
+
+	// 只保留区块头, 跨链请求照常处理
+	info, err := f.FetchBlock(1)
+	assert.Nil(t, err)
+	assert.Len(t, info.CrossChainRequests, 1)
+	var saved fabric.Block
+	assert.Nil(t, json.Unmarshal(info.BlockData, &saved))
+	assert.Equal(t, uint64(1), saved.Header.Number)
+	assert.Nil(t, saved.Data)
+
+	// 不保存完整区块, 处理进度照常推进
+	assert.Nil(t, f.SaveLatestBlock(info.BlockData))
+	_, err = block.NewController(f.dbPath, f.id).FetchBlockByNumber(1)
+	assert.Equal(t, storm.ErrNotFound, err)
+	next, err := f.NextBlock()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), next)
+}
+
+func TestPruneBlocks(t *testing.T) {
+	f, _, _ := newTestFabric(t)
+	WithBlockRetention(2, 0)(f)
+	controller := block.NewController(f.dbPath, f.id)
+	for i := uint64(1); i <= 5; i++ {
+		assert.Nil(t, controller.CreateBlock(&database.Block{
+			BlockNumber: i,
+			BlockHash:   fmt.Sprintf("h%d", i),
+			DataHash:    fmt.Sprintf("d%d", i),
+			BlockTime:   time.Now(),
+		}))
+	}
+
+	// 只保留最近的 2 个区块
+	f.pruneBlocks(5)
+	for i := uint64(1); i <= 5; i++ {
+		_, err := controller.FetchBlockByNumber(i)
+		if i < 4 {
+			assert.Equal(t, storm.ErrNotFound, err)
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+	assert.False(t, f.pruneBacklog)
+
+	// 清理间隔内不再清理, 仍有过期区块时随下一个区块继续清理
+	assert.Nil(t, controller.CreateBlock(&database.Block{BlockNumber: 6, BlockHash: "h6", DataHash: "d6"}))
+	f.pruneBlocks(6)
+	_, err := controller.FetchBlockByNumber(4)
+	assert.Nil(t, err)
+	f.pruneBacklog = true
+	f.pruneBlocks(6)
+	_, err = controller.FetchBlockByNumber(4)
+	assert.Equal(t, storm.ErrNotFound, err)
+}
+
+func TestPruneBatch(t *testing.T) {
+	controller := block.NewController(t.TempDir(), "local")
+	old := time.Now().AddDate(0, 0, -2)
+	for i := uint64(1); i <= 5; i++ {
+		assert.Nil(t, controller.CreateBlock(&database.Block{
+			BlockNumber: i,
+			BlockHash:   fmt.Sprintf("h%d", i),
+			DataHash:    fmt.Sprintf("d%d", i),
+			BlockTime:   old,
+		}))
+	}
+
+	// 每次最多删除 limit 个区块
+	pruned, err := controller.Prune(0, time.Now().AddDate(0, 0, -1), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, pruned)
+	pruned, err = controller.Prune(0, time.Now().AddDate(0, 0, -1), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pruned)
+	pruned, err = controller.Prune(0, time.Now().AddDate(0, 0, -1), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pruned)
+
+	// 未设置条件时不删除
+	pruned, err = controller.Prune(0, time.Time{}, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pruned)
+}
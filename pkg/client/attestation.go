@@ -0,0 +1,89 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/pkg/errors"
+)
+
+// Attestor 为独立的签名身份, 一般每个联盟组织一个. 各签名均由同一网关发起,
+// 门限只能防范单个私钥泄露, 不能防范该网关被攻破
+type Attestor struct {
+	Name string
+	CSP  *sw.SimpleCSP
+}
+
+// AttestationDigest 返回签名身份签名的摘要, 覆盖响应的来源链、目的链、交易ID、步骤ID与执行结果,
+// 防止将签名挪用到其他请求的响应. 各字段带长度前缀, 不同字段的拼接不会产生相同的摘要
+func AttestationDigest(msg *pb.CommonResponseMessage) []byte {
+	hash := sha256.New()
+	for _, field := range [][]byte{[]byte(msg.From), []byte(msg.To), []byte(msg.TransactionID), []byte(msg.StepID), msg.Payload} {
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+		hash.Write(size[:])
+		hash.Write(field)
+	}
+	return hash.Sum(nil)
+}
+
+// Attest 使用各签名身份分别对响应的摘要进行签名
+func Attest(attestors []*Attestor, msg *pb.CommonResponseMessage) ([]*pb.Signature, error) {
+	digest := AttestationDigest(msg)
+	var signatures []*pb.Signature
+	for _, attestor := range attestors {
+		sig, err := attestor.CSP.Sign(digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to sign response by attestor %s", attestor.Name)
+		}
+		signatures = append(signatures, &pb.Signature{
+			Signer:    attestor.Name,
+			KeyID:     attestor.CSP.KeyID(),
+			Algorithm: attestor.CSP.Algorithm(),
+			Signature: sig,
+		})
+	}
+	return signatures, nil
+}
+
+// AttestationPolicy 要求消息至少携带 Threshold 个不同签名身份的有效签名
+type AttestationPolicy struct {
+	Threshold int
+	Signers   map[string]*sw.SimpleCSP
+}
+
+func NewAttestationPolicy(threshold int, signers map[string]*sw.SimpleCSP) (*AttestationPolicy, error) {
+	if threshold <= 0 || threshold > len(signers) {
+		return nil, errors.Errorf("threshold %d is out of range [1, %d]", threshold, len(signers))
+	}
+	return &AttestationPolicy{
+		Threshold: threshold,
+		Signers:   signers,
+	}, nil
+}
+
+// Evaluate 校验响应携带的签名, 未知身份与无效签名不计数, 同一身份只计一次
+func (p *AttestationPolicy) Evaluate(msg *pb.CommonResponseMessage) error {
+	digest := AttestationDigest(msg)
+	accepted := make(map[string]struct{}, len(msg.Signatures))
+	for _, signature := range msg.Signatures {
+		if _, ok := accepted[signature.Signer]; ok {
+			continue
+		}
+		csp, ok := p.Signers[signature.Signer]
+		if !ok {
+			continue
+		}
+		valid, err := csp.VerifyWithKeyID(signature.KeyID, signature.Algorithm, signature.Signature, digest)
+		if err != nil || !valid {
+			continue
+		}
+		accepted[signature.Signer] = struct{}{}
+		if len(accepted) >= p.Threshold {
+			return nil
+		}
+	}
+	return errors.Errorf("only %d of the required %d signatures are valid", len(accepted), p.Threshold)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/internal/testutil"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttestationPolicy(t *testing.T) {
+	msg := &pb.CommonResponseMessage{From: "a", To: "b", TransactionID: "tx", StepID: "1", Payload: []byte("payload")}
+	orgs := map[string]*sw.SimpleCSP{
+		"org1": testutil.NewTestCSP(t),
+		"org2": testutil.NewTestCSP(t),
+		"org3": testutil.NewTestCSP(t),
+	}
+	policy, err := NewAttestationPolicy(2, orgs)
+	assert.Nil(t, err)
+	signed := func(signatures []*pb.Signature) *pb.CommonResponseMessage {
+		resp := proto.Clone(msg).(*pb.CommonResponseMessage)
+		resp.Signatures = signatures
+		return resp
+	}
+
+	one, err := Attest([]*Attestor{{Name: "org1", CSP: orgs["org1"]}}, msg)
+	assert.Nil(t, err)
+	assert.NotNil(t, policy.Evaluate(signed(one)))
+	// 同一身份重复签名只计一次
+	assert.NotNil(t, policy.Evaluate(signed(append(one, one...))))
+
+	// 未知身份不计数
+	unknown, err := Attest([]*Attestor{{Name: "org4", CSP: testutil.NewTestCSP(t)}}, msg)
+	assert.Nil(t, err)
+	assert.NotNil(t, policy.Evaluate(signed(append(one, unknown...))))
+
+	// 冒用身份名称的签名无效
+	forged, err := Attest([]*Attestor{{Name: "org2", CSP: testutil.NewTestCSP(t)}}, msg)
+	assert.Nil(t, err)
+	assert.NotNil(t, policy.Evaluate(signed(append(one, forged...))))
+
+	two, err := Attest([]*Attestor{{Name: "org1", CSP: orgs["org1"]}, {Name: "org3", CSP: orgs["org3"]}}, msg)
+	assert.Nil(t, err)
+	assert.Nil(t, policy.Evaluate(signed(two)))
+
+	// 签名绑定请求与结果, 不能挪用到其他响应
+	tampered := signed(two)
+	tampered.Payload = []byte("tampered")
+	assert.NotNil(t, policy.Evaluate(tampered))
+	for _, modify := range []func(resp *pb.CommonResponseMessage){
+		func(resp *pb.CommonResponseMessage) { resp.From = "c" },
+		func(resp *pb.CommonResponseMessage) { resp.To = "c" },
+		func(resp *pb.CommonResponseMessage) { resp.TransactionID = "other" },
+		func(resp *pb.CommonResponseMessage) { resp.StepID = "2" },
+		// 字段边界不同的拼接不产生相同的摘要
+		func(resp *pb.CommonResponseMessage) { resp.TransactionID, resp.StepID = "tx1", "" },
+	} {
+		replayed := signed(two)
+		modify(replayed)
+		assert.NotNil(t, policy.Evaluate(replayed))
+	}
+
+	_, err = NewAttestationPolicy(4, orgs)
+	assert.NotNil(t, err)
+}
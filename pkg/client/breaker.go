@@ -0,0 +1,127 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCircuitOpen 远端网关熔断期间直接失败
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 30 * time.Second
+)
+
+// CircuitBreaker 远端网关的熔断器, 同一 namespace 下的通道共享.
+// 连续失败达到阈值后熔断, 熔断到期后放行一次探测调用, 探测成功则恢复
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+
+	lock     sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	// 半开状态下是否已有探测调用
+	probing bool
+}
+
+func NewCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+// Allow 判断是否允许调用, 熔断期间返回 ErrCircuitOpen
+func (b *CircuitBreaker) Allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return errors.Wrapf(ErrCircuitOpen, "remote hub %s is unavailable", b.name)
+		}
+		b.transit(BreakerHalfOpen)
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return errors.Wrapf(ErrCircuitOpen, "remote hub %s is being probed", b.name)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *CircuitBreaker) Success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures = 0
+	b.probing = false
+	if b.state != BreakerClosed {
+		b.transit(BreakerClosed)
+	}
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.openedAt = time.Now()
+		if b.state != BreakerOpen {
+			b.transit(BreakerOpen)
+		}
+	}
+}
+
+// Release 放弃 Allow 放行的调用, 不改变熔断状态. 调用方主动取消时调用, 释放半开状态下的探测机会
+func (b *CircuitBreaker) Release() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.probing = false
+}
+
+// State 返回熔断状态与连续失败次数
+func (b *CircuitBreaker) State() (BreakerState, int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state, b.failures
+}
+
+func (b *CircuitBreaker) transit(state BreakerState) {
+	if state == BreakerOpen {
+		logrus.Warnf("circuit breaker of remote hub %s is %s after %d failures", b.name, state, b.failures)
+	} else {
+		logrus.Infof("circuit breaker of remote hub %s is %s", b.name, state)
+	}
+	b.state = state
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/grpc"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker("remote", 2, 50*time.Millisecond)
+	assert.Nil(t, b.Allow())
+	b.Failure()
+	assert.Nil(t, b.Allow())
+	b.Failure()
+	state, failures := b.State()
+	assert.Equal(t, BreakerOpen, state)
+	assert.Equal(t, 2, failures)
+	assert.True(t, errors.Is(b.Allow(), ErrCircuitOpen))
+
+	// 熔断到期后只放行一次探测调用, 探测失败重新熔断
+	time.Sleep(60 * time.Millisecond)
+	assert.Nil(t, b.Allow())
+	assert.True(t, errors.Is(b.Allow(), ErrCircuitOpen))
+	b.Failure()
+	state, _ = b.State()
+	assert.Equal(t, BreakerOpen, state)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Nil(t, b.Allow())
+	b.Success()
+	state, failures = b.State()
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 0, failures)
+}
+
+func TestHubClientCall(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	breaker := NewCircuitBreaker("remote", 3, time.Minute)
+	// 非阻塞建连, 调用由 fn 模拟
+	conn, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+	c, err := NewHubClient("127.0.0.1", 1, nil,
+		WithConnection(&Connection{address: "127.0.0.1:1", conn: conn}),
+		WithRetryPolicy(policy),
+		WithCircuitBreaker(breaker))
+	assert.Nil(t, err)
+
+	// 不可重试的错误只调用一次
+	calls := 0
+	err = c.call(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return status.Error(codes.InvalidArgument, "invalid")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	err = c.call(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		if calls < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+
+	// 非幂等的调用在请求发出后不重试
+	calls = 0
+	err = c.callNonIdempotent(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+	c.breaker.Success()
+
+	// 连续失败后熔断, 后续调用直接失败
+	calls = 0
+	err = c.call(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, calls)
+	err = c.call(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return nil
+	})
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "open", c.Status().Breaker)
+}
+
+func TestHubClientCallCanceled(t *testing.T) {
+	conn, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+	c, err := NewHubClient("127.0.0.1", 1, nil,
+		WithConnection(&Connection{address: "127.0.0.1:1", conn: conn}),
+		WithRetryPolicy(RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Minute,
+			MaxBackoff:     time.Minute,
+			RetryableCodes: []codes.Code{codes.Unavailable},
+		}),
+		WithCircuitBreaker(NewCircuitBreaker("remote", 3, time.Minute)),
+		WithTimeout(50*time.Millisecond))
+	assert.Nil(t, err)
+
+	// 超时后停止退避, 不再重试
+	ctx, cancel := c.withTimeout(context.Background())
+	defer cancel()
+	calls := 0
+	start := time.Now()
+	err = c.call(ctx, "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, calls)
+	assert.True(t, time.Since(start) < time.Second)
+
+	// 调用方已取消的调用不计入熔断器
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = c.call(canceled, "Test", func(conn *grpc.ClientConn) error {
+		calls++
+		return status.Error(codes.Canceled, "canceled")
+	})
+	assert.NotNil(t, err)
+	state, failures := c.breaker.State()
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 1, failures)
+}
+
+func TestHubClientProbeCanceled(t *testing.T) {
+	conn, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer conn.Close()
+	breaker := NewCircuitBreaker("remote", 1, 20*time.Millisecond)
+	c, err := NewHubClient("127.0.0.1", 1, nil,
+		WithConnection(&Connection{address: "127.0.0.1:1", conn: conn}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithCircuitBreaker(breaker))
+	assert.Nil(t, err)
+	breaker.Failure()
+	time.Sleep(30 * time.Millisecond)
+
+	// 半开状态下的探测调用被取消后, 后续调用仍可探测
+	ctx, cancel := context.WithCancel(context.Background())
+	err = c.call(ctx, "Test", func(conn *grpc.ClientConn) error {
+		cancel()
+		return status.Error(codes.Canceled, "canceled")
+	})
+	assert.NotNil(t, err)
+	state, _ := breaker.State()
+	assert.Equal(t, BreakerHalfOpen, state)
+	err = c.call(context.Background(), "Test", func(conn *grpc.ClientConn) error {
+		return nil
+	})
+	assert.Nil(t, err)
+	state, _ = breaker.State()
+	assert.Equal(t, BreakerClosed, state)
+
+	// 远端未在截止时间前响应时计入失败
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = c.call(ctx, "Test", func(conn *grpc.ClientConn) error {
+		<-ctx.Done()
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	})
+	assert.NotNil(t, err)
+	state, failures := breaker.State()
+	assert.Equal(t, BreakerOpen, state)
+	assert.Equal(t, 1, failures)
+}
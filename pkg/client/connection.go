@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/fabric-creed/grpc"
+	"github.com/fabric-creed/grpc/backoff"
+	"github.com/fabric-creed/grpc/connectivity"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Connection 远端网关的长连接, 同一 namespace 下的所有通道共享.
+// 已建立的连接断开后由 grpc 按退避策略自动重连, 首次建连失败时按退避时间拒绝新的建连
+type Connection struct {
+	address string
+	client  *cgrpc.GRPCClient
+	backoff backoff.Config
+	// 建连时的 TLS 选项, 如按节点指定服务端名称
+	tlsOptions []cgrpc.TLSOption
+
+	lock sync.Mutex
+	conn *grpc.ClientConn
+	// 连续建连失败次数与下次允许建连的时间
+	failures int
+	nextDial time.Time
+	closed   bool
+}
+
+func NewConnection(address string, client *cgrpc.GRPCClient, tlsOptions ...cgrpc.TLSOption) *Connection {
+	return &Connection{
+		address:    address,
+		client:     client,
+		backoff:    client.Backoff(),
+		tlsOptions: tlsOptions,
+	}
+}
+
+// Get 返回共享的连接, 连接不存在或已关闭时重新建连
+func (c *Connection) Get() (*grpc.ClientConn, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil, errors.Errorf("connection to %s is closed", c.address)
+	}
+	if c.conn != nil && c.conn.GetState() != connectivity.Shutdown {
+		return c.conn, nil
+	}
+	if now := time.Now(); now.Before(c.nextDial) {
+		return nil, errors.Errorf("connection to %s is backing off, retry after %s",
+			c.address, c.nextDial.Sub(now).Round(time.Millisecond))
+	}
+
+	conn, err := c.client.NewConnection(c.address, c.tlsOptions...)
+	if err != nil {
+		c.nextDial = time.Now().Add(c.delay(c.failures))
+		c.failures++
+		return nil, errors.Wrapf(err, "failed to connect to %s", c.address)
+	}
+	c.failures = 0
+	c.nextDial = time.Time{}
+	c.conn = conn
+	go c.monitor(conn)
+	return conn, nil
+}
+
+// State 返回连接状态, 尚未建连时为 Idle
+func (c *Connection) State() connectivity.State {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.conn == nil {
+		return connectivity.Idle
+	}
+	return c.conn.GetState()
+}
+
+func (c *Connection) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// monitor 记录连接状态的变化, 连接关闭后退出
+func (c *Connection) monitor(conn *grpc.ClientConn) {
+	state := conn.GetState()
+	for conn.WaitForStateChange(context.Background(), state) {
+		state = conn.GetState()
+		switch state {
+		case connectivity.TransientFailure:
+			logrus.Warnf("connection to %s is %s, reconnecting", c.address, state)
+		case connectivity.Shutdown:
+			logrus.Infof("connection to %s is %s", c.address, state)
+			return
+		default:
+			logrus.Debugf("connection to %s is %s", c.address, state)
+		}
+	}
+}
+
+// delay 第 retries 次建连失败后的退避时间, 与 grpc 的指数退避一致
+func (c *Connection) delay(retries int) time.Duration {
+	if retries == 0 {
+		return c.backoff.BaseDelay
+	}
+	d, max := float64(c.backoff.BaseDelay), float64(c.backoff.MaxDelay)
+	for d < max && retries > 0 {
+		d *= c.backoff.Multiplier
+		retries--
+	}
+	if d > max {
+		d = max
+	}
+	d *= 1 + c.backoff.Jitter*(rand.Float64()*2-1)
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/fabric-creed/grpc/backoff"
+	"github.com/fabric-creed/grpc/connectivity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnection(t *testing.T) {
+	grpcClient, err := cgrpc.NewGRPCClient(cgrpc.ClientConfig{
+		KaOpts:  cgrpc.DefaultKeepaliveOptions,
+		Timeout: 200 * time.Millisecond,
+		BackoffOpts: backoff.Config{
+			BaseDelay:  100 * time.Millisecond,
+			Multiplier: 1.6,
+			MaxDelay:   time.Second,
+		},
+	})
+	assert.Nil(t, err)
+
+	// 预留端口, 服务未启动前建连失败
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	address := lis.Addr().String()
+	assert.Nil(t, lis.Close())
+
+	conn := NewConnection(address, grpcClient)
+	assert.Equal(t, connectivity.Idle, conn.State())
+	_, err = conn.Get()
+	assert.NotNil(t, err)
+	// 退避期间不再建连
+	_, err = conn.Get()
+	assert.Contains(t, err.Error(), "backing off")
+
+	server, err := cgrpc.NewGRPCServer(address, cgrpc.ServerConfig{})
+	assert.Nil(t, err)
+	go server.Start()
+	defer server.Stop()
+
+	time.Sleep(150 * time.Millisecond)
+	first, err := conn.Get()
+	assert.Nil(t, err)
+	second, err := conn.Get()
+	assert.Nil(t, err)
+	assert.True(t, first == second)
+	assert.Equal(t, connectivity.Ready, conn.State())
+
+	assert.Nil(t, conn.Close())
+	_, err = conn.Get()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,333 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+
+	"github.com/fabric-creed/cryptogm/x509"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/msp"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// MSP 远端通道中一个组织的 MSP, 用于核实背书节点证书
+type MSP struct {
+	ID            string
+	Roots         *x509.CertPool
+	Intermediates *x509.CertPool
+}
+
+// NewMSP 由 PEM 编码的根证书与中间证书创建 MSP
+func NewMSP(id string, rootCerts, intermediateCerts [][]byte) (*MSP, error) {
+	if id == "" {
+		return nil, errors.New("msp id is empty")
+	}
+	if len(rootCerts) == 0 {
+		return nil, errors.Errorf("root certs of msp %s are empty", id)
+	}
+	m := &MSP{
+		ID:            id,
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range rootCerts {
+		if !m.Roots.AppendCertsFromPEM(cert) {
+			return nil, errors.Errorf("failed to append root cert of msp %s", id)
+		}
+	}
+	for _, cert := range intermediateCerts {
+		if !m.Intermediates.AppendCertsFromPEM(cert) {
+			return nil, errors.Errorf("failed to append intermediate cert of msp %s", id)
+		}
+	}
+	return m, nil
+}
+
+// FncNoTransactionCall 代理合约执行跨链调用的方法名
+const FncNoTransactionCall = "NoTransactionCall"
+
+// ProxyArgs 返回代理合约执行跨链调用的参数, 依次为目标合约名称、方法名与 JSON 编码的参数列表
+func ProxyArgs(payload *pb.FabricPayloadRequest) ([][]byte, error) {
+	args := payload.Args
+	if args == nil {
+		args = []string{}
+	}
+	ccArgs, err := json.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal args:[%v]", args)
+	}
+	return [][]byte{[]byte(payload.ChainCodeName), []byte(payload.FncName), ccArgs}, nil
+}
+
+// EndorsementVerifier 按远端通道的 MSP 与背书策略核实背书证明
+type EndorsementVerifier struct {
+	MSPs map[string]*MSP
+	// 背书策略, 至少需要 Threshold 个不同组织的有效背书
+	Threshold int
+	IsGM      bool
+	// 远端通道的代理合约名称, 为空时不核对背书提案调用的合约
+	ProxyChainCodeName string
+}
+
+func NewEndorsementVerifier(msps []*MSP, threshold int, isGM bool) (*EndorsementVerifier, error) {
+	m := make(map[string]*MSP, len(msps))
+	for _, item := range msps {
+		if _, ok := m[item.ID]; ok {
+			return nil, errors.Errorf("msp %s is duplicated", item.ID)
+		}
+		m[item.ID] = item
+	}
+	if threshold <= 0 || threshold > len(m) {
+		return nil, errors.Errorf("threshold %d is out of range [1, %d]", threshold, len(m))
+	}
+	return &EndorsementVerifier{
+		MSPs:      m,
+		Threshold: threshold,
+		IsGM:      isGM,
+	}, nil
+}
+
+// executeResult 为 channel.Response 的 JSON 编码中需要与背书证明核对的字段
+type executeResult struct {
+	TransactionID    string
+	TxValidationCode int32
+	Payload          []byte
+}
+
+// VerifyResponse 核实响应携带的背书证明, 并确认证明与响应中的执行结果一致, 且背书的提案即 req 请求的调用.
+// 交易验证码与区块号由网关提供, 未经提交证据核实
+func (v *EndorsementVerifier) VerifyResponse(req *pb.NoTransactionCallRequest, resp *pb.CommonResponseMessage) error {
+	var result executeResult
+	if err := json.Unmarshal(resp.Payload, &result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal execute result")
+	}
+	proof := resp.Proof
+	if proof == nil {
+		return errors.New("endorsement proof is missing")
+	}
+	if proof.TxID != result.TransactionID {
+		return errors.Errorf("tx id %s of proof does not match %s", proof.TxID, result.TransactionID)
+	}
+	if proof.ValidationCode != result.TxValidationCode {
+		return errors.Errorf("validation code %d of proof does not match %d", proof.ValidationCode, result.TxValidationCode)
+	}
+	if err := v.verifyInvocation(req, proof); err != nil {
+		return err
+	}
+	return v.Verify(proof, result.Payload)
+}
+
+// verifyInvocation 核实背书的提案在请求的通道中以请求的合约、方法与参数调用代理合约,
+// 防止网关以其他调用的有效背书冒充请求的执行结果
+func (v *EndorsementVerifier) verifyInvocation(req *pb.NoTransactionCallRequest, proof *pb.EndorsementProof) error {
+	fabricPayload := &pb.FabricPayloadRequest{}
+	if err := json.Unmarshal(req.Payload, fabricPayload); err != nil {
+		return errors.Wrap(err, "failed to unmarshal request payload")
+	}
+	expected, err := ProxyArgs(fabricPayload)
+	if err != nil {
+		return err
+	}
+	expected = append([][]byte{[]byte(FncNoTransactionCall)}, expected...)
+
+	header := &common.Header{}
+	if err = proto.Unmarshal(proof.Header, header); err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal header")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err = proto.Unmarshal(header.ChannelHeader, channelHeader); err != nil {
+		return errors.Wrap(err, "failed to unmarshal channel header")
+	}
+	if channelHeader.ChannelId != fabricPayload.ChannelName {
+		return errors.Errorf("channel %s of proposal does not match %s", channelHeader.ChannelId, fabricPayload.ChannelName)
+	}
+	proposalPayload := &peer.ChaincodeProposalPayload{}
+	if err = proto.Unmarshal(proof.ProposalPayload, proposalPayload); err != nil {
+		return errors.Wrap(err, "failed to unmarshal chaincode proposal payload")
+	}
+	spec := &peer.ChaincodeInvocationSpec{}
+	if err = proto.Unmarshal(proposalPayload.Input, spec); err != nil {
+		return errors.Wrap(err, "failed to unmarshal chaincode invocation spec")
+	}
+	if spec.ChaincodeSpec == nil || spec.ChaincodeSpec.ChaincodeId == nil || spec.ChaincodeSpec.Input == nil {
+		return errors.Errorf("chaincode invocation of transaction %s is missing", proof.TxID)
+	}
+	if v.ProxyChainCodeName != "" && spec.ChaincodeSpec.ChaincodeId.Name != v.ProxyChainCodeName {
+		return errors.Errorf("chaincode %s of proposal does not match %s", spec.ChaincodeSpec.ChaincodeId.Name, v.ProxyChainCodeName)
+	}
+	args := spec.ChaincodeSpec.Input.Args
+	if len(args) != len(expected) {
+		return errors.Errorf("args of transaction %s do not match the request", proof.TxID)
+	}
+	for i := range args {
+		if !bytes.Equal(args[i], expected[i]) {
+			return errors.Errorf("args of transaction %s do not match the request", proof.TxID)
+		}
+	}
+	return nil
+}
+
+// Verify 核实满足门限的组织对 txID 的提案与同一结果进行了背书, 且证明中的交易验证码为 VALID,
+// 验证码本身由证明的提供方给出. result 为合约返回值, 为 nil 时不核对
+func (v *EndorsementVerifier) Verify(proof *pb.EndorsementProof, result []byte) error {
+	if proof == nil {
+		return errors.New("endorsement proof is missing")
+	}
+	if proof.ValidationCode != int32(peer.TxValidationCode_VALID) {
+		return errors.Errorf("transaction %s is invalid, validation code: %s",
+			proof.TxID, peer.TxValidationCode(proof.ValidationCode))
+	}
+	if len(proof.Endorsements) == 0 {
+		return errors.Errorf("transaction %s has no endorsement", proof.TxID)
+	}
+
+	// 各背书节点的模拟结果必须一致
+	payload := proof.Endorsements[0].Payload
+	for _, endorsement := range proof.Endorsements[1:] {
+		if !bytes.Equal(endorsement.Payload, payload) {
+			return errors.Errorf("proposal response payloads of transaction %s do not match", proof.TxID)
+		}
+	}
+	if err := verifyProposalHash(proof, payload); err != nil {
+		return err
+	}
+	if result != nil {
+		ccPayload, err := chaincodeResponsePayload(payload)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(ccPayload, result) {
+			return errors.Errorf("endorsed result of transaction %s does not match the response", proof.TxID)
+		}
+	}
+
+	endorsed := make(map[string]struct{}, len(proof.Endorsements))
+	var lastErr error
+	for _, endorsement := range proof.Endorsements {
+		mspID, err := v.verifyEndorsement(endorsement)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		endorsed[mspID] = struct{}{}
+		if len(endorsed) >= v.Threshold {
+			return nil
+		}
+	}
+	err := errors.Errorf("only %d of the required %d organizations endorsed transaction %s",
+		len(endorsed), v.Threshold, proof.TxID)
+	if lastErr != nil {
+		err = errors.Wrap(lastErr, err.Error())
+	}
+	return err
+}
+
+// verifyEndorsement 核实背书签名, 背书签名覆盖 ProposalResponsePayload 与背书节点身份的拼接
+func (v *EndorsementVerifier) verifyEndorsement(endorsement *pb.Endorsement) (string, error) {
+	msg := append(append([]byte{}, endorsement.Payload...), endorsement.Endorser...)
+	return v.VerifySignature(endorsement.Endorser, msg, endorsement.Signature)
+}
+
+// VerifySignature 核实签名者证书由已知 MSP 签发且签名有效, 返回签名者的 MSP ID,
+// identity 为序列化的 msp.SerializedIdentity
+func (v *EndorsementVerifier) VerifySignature(identity, msg, signature []byte) (string, error) {
+	sid := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sid); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal identity")
+	}
+	m, ok := v.MSPs[sid.Mspid]
+	if !ok {
+		return "", errors.Errorf("msp %s is unknown", sid.Mspid)
+	}
+	block, _ := pem.Decode(sid.IdBytes)
+	if block == nil {
+		return "", errors.Errorf("failed to decode cert of msp %s", sid.Mspid)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse cert of msp %s", sid.Mspid)
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         m.Roots,
+		Intermediates: m.Intermediates,
+		CurrentTime:   time.Now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "cert is not issued by msp %s", sid.Mspid)
+	}
+
+	key, err := sw.ParsePublicByCertificate(sid.IdBytes)
+	if err != nil {
+		return "", err
+	}
+	valid, err := (&sw.CSP{}).Verify(key, signature, util.Hash(msg, v.IsGM), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to verify signature of msp %s", sid.Mspid)
+	}
+	if !valid {
+		return "", errors.Errorf("signature of msp %s is invalid", sid.Mspid)
+	}
+	return sid.Mspid, nil
+}
+
+// verifyProposalHash 核实背书结果中的提案哈希由证明携带的提案头与提案内容计算得到,
+// 且提案头中的交易 ID 与证明一致, 防止将其他交易的背书冒充为 txID 的背书
+func verifyProposalHash(proof *pb.EndorsementProof, payload []byte) error {
+	if len(proof.Header) == 0 || len(proof.ProposalPayload) == 0 {
+		return errors.Errorf("proposal of transaction %s is missing", proof.TxID)
+	}
+	header := &common.Header{}
+	if err := proto.Unmarshal(proof.Header, header); err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal header")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(header.ChannelHeader, channelHeader); err != nil {
+		return errors.Wrap(err, "failed to unmarshal channel header")
+	}
+	if channelHeader.TxId != proof.TxID {
+		return errors.Errorf("tx id %s of proposal does not match %s", channelHeader.TxId, proof.TxID)
+	}
+	prp := &peer.ProposalResponsePayload{}
+	if err := proto.Unmarshal(payload, prp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response payload")
+	}
+	if !bytes.Equal(prp.ProposalHash, ProposalHash(header, proof.ProposalPayload)) {
+		return errors.Errorf("proposal hash of transaction %s does not match", proof.TxID)
+	}
+	return nil
+}
+
+// ProposalHash 按 Fabric protoutil.GetProposalHash2 计算提案哈希,
+// proposalPayload 为去除 TransientMap 后序列化的 ChaincodeProposalPayload
+func ProposalHash(header *common.Header, proposalPayload []byte) []byte {
+	hash := sha256.New()
+	hash.Write(header.ChannelHeader)
+	hash.Write(header.SignatureHeader)
+	hash.Write(proposalPayload)
+	return hash.Sum(nil)
+}
+
+// chaincodeResponsePayload 从 ProposalResponsePayload 中取出合约返回值
+func chaincodeResponsePayload(raw []byte) ([]byte, error) {
+	prp := &peer.ProposalResponsePayload{}
+	if err := proto.Unmarshal(raw, prp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposal response payload")
+	}
+	action := &peer.ChaincodeAction{}
+	if err := proto.Unmarshal(prp.Extension, action); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode action")
+	}
+	if action.Response == nil {
+		return nil, errors.New("chaincode response is missing")
+	}
+	return action.Response.Payload, nil
+}
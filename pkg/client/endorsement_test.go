@@ -0,0 +1,206 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/internal/testutil"
+	"github.com/fabric-creed/fabric-hub/pkg/common/crypto/tlsgen"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/msp"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOrg struct {
+	msp  *MSP
+	peer *tlsgen.CertKeyPair
+}
+
+func newTestOrg(t *testing.T, id string, isGM bool) *testOrg {
+	ca, err := tlsgen.NewCA(isGM)
+	assert.Nil(t, err)
+	pair, err := ca.NewClientCertKeyPair(isGM)
+	assert.Nil(t, err)
+	m, err := NewMSP(id, [][]byte{ca.CertBytes()}, nil)
+	assert.Nil(t, err)
+	return &testOrg{msp: m, peer: pair}
+}
+
+func (o *testOrg) endorse(t *testing.T, payload []byte, isGM bool) *pb.Endorsement {
+	endorser, err := proto.Marshal(&msp.SerializedIdentity{Mspid: o.msp.ID, IdBytes: o.peer.Cert})
+	assert.Nil(t, err)
+	key, err := sw.ParsePrivateKey(o.peer.Key)
+	assert.Nil(t, err)
+	msg := append(append([]byte{}, payload...), endorser...)
+	sig, err := (&sw.CSP{}).Sign(key, util.Hash(msg, isGM), nil)
+	assert.Nil(t, err)
+	return &pb.Endorsement{Endorser: endorser, Signature: sig, Payload: payload}
+}
+
+// testFabricPayload 测试请求在目的链调用的合约
+var testFabricPayload = &pb.FabricPayloadRequest{ChannelName: "mychannel", ChainCodeName: "cc", FncName: "invoke", Args: []string{"a"}}
+
+// newTestProposal 返回经代理合约调用 fabricPayload 的序列化提案头与提案内容
+func newTestProposal(t *testing.T, txID string, fabricPayload *pb.FabricPayloadRequest) ([]byte, []byte) {
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: fabricPayload.ChannelName,
+		TxId:      txID,
+	})
+	assert.Nil(t, err)
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: []byte("creator"), Nonce: []byte(txID)})
+	assert.Nil(t, err)
+	header, err := proto.Marshal(&common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader})
+	assert.Nil(t, err)
+	args, err := ProxyArgs(fabricPayload)
+	assert.Nil(t, err)
+	input, err := proto.Marshal(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+		ChaincodeId: &peer.ChaincodeID{Name: "proxy"},
+		Input:       &peer.ChaincodeInput{Args: append([][]byte{[]byte(FncNoTransactionCall)}, args...)},
+	}})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&peer.ChaincodeProposalPayload{Input: input})
+	assert.Nil(t, err)
+	return header, payload
+}
+
+func newTestProposalResponsePayload(t *testing.T, header, proposalPayload, result []byte) []byte {
+	h := &common.Header{}
+	assert.Nil(t, proto.Unmarshal(header, h))
+	action, err := proto.Marshal(&peer.ChaincodeAction{Response: &peer.Response{Status: 200, Payload: result}})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&peer.ProposalResponsePayload{
+		ProposalHash: ProposalHash(h, proposalPayload),
+		Extension:    action,
+	})
+	assert.Nil(t, err)
+	return payload
+}
+
+func testEndorsementVerifier(t *testing.T, isGM bool) {
+	org1 := newTestOrg(t, "Org1MSP", isGM)
+	org2 := newTestOrg(t, "Org2MSP", isGM)
+	org3 := newTestOrg(t, "Org3MSP", isGM)
+	verifier, err := NewEndorsementVerifier([]*MSP{org1.msp, org2.msp, org3.msp}, 2, isGM)
+	assert.Nil(t, err)
+
+	result := []byte("ok")
+	header, proposalPayload := newTestProposal(t, "tx1", testFabricPayload)
+	payload := newTestProposalResponsePayload(t, header, proposalPayload, result)
+	proof := &pb.EndorsementProof{
+		TxID:            "tx1",
+		BlockNumber:     10,
+		Endorsements:    []*pb.Endorsement{org1.endorse(t, payload, isGM), org2.endorse(t, payload, isGM)},
+		Header:          header,
+		ProposalPayload: proposalPayload,
+	}
+	assert.Nil(t, verifier.Verify(proof, result))
+	// 合约返回值被篡改
+	assert.NotNil(t, verifier.Verify(proof, []byte("tampered")))
+
+	// 背书需绑定到所声明交易的提案
+	otherHeader, otherPayload := newTestProposal(t, "tx2", testFabricPayload)
+	other := *proof
+	other.TxID = "tx2"
+	assert.NotNil(t, verifier.Verify(&other, result))
+	other.Header = otherHeader
+	assert.NotNil(t, verifier.Verify(&other, result))
+	other.ProposalPayload = otherPayload
+	assert.NotNil(t, verifier.Verify(&other, result))
+	missing := *proof
+	missing.Header = nil
+	assert.NotNil(t, verifier.Verify(&missing, result))
+
+	// 同一组织重复背书只计一次
+	one := &pb.EndorsementProof{TxID: "tx1", Header: header, ProposalPayload: proposalPayload, Endorsements: []*pb.Endorsement{proof.Endorsements[0], proof.Endorsements[0]}}
+	assert.NotNil(t, verifier.Verify(one, result))
+
+	// 未配置的组织不计数
+	outsider := newTestOrg(t, "Org4MSP", isGM)
+	unknown := &pb.EndorsementProof{TxID: "tx1", Header: header, ProposalPayload: proposalPayload, Endorsements: []*pb.Endorsement{
+		proof.Endorsements[0], outsider.endorse(t, payload, isGM)}}
+	assert.NotNil(t, verifier.Verify(unknown, result))
+
+	// 冒用组织 MSP ID 的证书不是该组织 CA 签发
+	outsider.msp.ID = "Org2MSP"
+	forged := &pb.EndorsementProof{TxID: "tx1", Header: header, ProposalPayload: proposalPayload, Endorsements: []*pb.Endorsement{
+		proof.Endorsements[0], outsider.endorse(t, payload, isGM)}}
+	assert.NotNil(t, verifier.Verify(forged, result))
+
+	// 背书签名无效
+	broken := *proof.Endorsements[1]
+	broken.Signature = proof.Endorsements[0].Signature
+	invalid := &pb.EndorsementProof{TxID: "tx1", Header: header, ProposalPayload: proposalPayload, Endorsements: []*pb.Endorsement{proof.Endorsements[0], &broken}}
+	assert.NotNil(t, verifier.Verify(invalid, result))
+
+	// 交易未通过验证
+	rejected := *proof
+	rejected.ValidationCode = int32(peer.TxValidationCode_MVCC_READ_CONFLICT)
+	assert.NotNil(t, verifier.Verify(&rejected, result))
+
+	// 响应中的执行结果需与证明一致
+	requestPayload, err := json.Marshal(testFabricPayload)
+	assert.Nil(t, err)
+	req := &pb.NoTransactionCallRequest{Payload: requestPayload}
+	raw, err := json.Marshal(executeResult{TransactionID: "tx1", Payload: result})
+	assert.Nil(t, err)
+	assert.Nil(t, verifier.VerifyResponse(req, &pb.CommonResponseMessage{Payload: raw, Proof: proof}))
+	raw, err = json.Marshal(executeResult{TransactionID: "tx2", Payload: result})
+	assert.Nil(t, err)
+	assert.NotNil(t, verifier.VerifyResponse(req, &pb.CommonResponseMessage{Payload: raw, Proof: proof}))
+	assert.NotNil(t, verifier.VerifyResponse(req, &pb.CommonResponseMessage{Payload: raw}))
+
+	// 背书的提案需为请求的调用
+	raw, err = json.Marshal(executeResult{TransactionID: "tx1", Payload: result})
+	assert.Nil(t, err)
+	for _, fabricPayload := range []*pb.FabricPayloadRequest{
+		{ChannelName: "other", ChainCodeName: "cc", FncName: "invoke", Args: []string{"a"}},
+		{ChannelName: "mychannel", ChainCodeName: "other", FncName: "invoke", Args: []string{"a"}},
+		{ChannelName: "mychannel", ChainCodeName: "cc", FncName: "other", Args: []string{"a"}},
+		{ChannelName: "mychannel", ChainCodeName: "cc", FncName: "invoke", Args: []string{"b"}},
+	} {
+		otherPayload, err := json.Marshal(fabricPayload)
+		assert.Nil(t, err)
+		err = verifier.VerifyResponse(&pb.NoTransactionCallRequest{Payload: otherPayload}, &pb.CommonResponseMessage{Payload: raw, Proof: proof})
+		assert.NotNil(t, err)
+	}
+	verifier.ProxyChainCodeName = "proxy"
+	assert.Nil(t, verifier.VerifyResponse(req, &pb.CommonResponseMessage{Payload: raw, Proof: proof}))
+	verifier.ProxyChainCodeName = "other"
+	assert.NotNil(t, verifier.VerifyResponse(req, &pb.CommonResponseMessage{Payload: raw, Proof: proof}))
+	verifier.ProxyChainCodeName = ""
+
+	_, err = NewEndorsementVerifier([]*MSP{org1.msp, org2.msp}, 3, isGM)
+	assert.NotNil(t, err)
+}
+
+func TestEndorsementVerifier(t *testing.T) {
+	t.Run("ECDSA", func(t *testing.T) { testEndorsementVerifier(t, false) })
+	t.Run("SM2", func(t *testing.T) { testEndorsementVerifier(t, true) })
+}
+
+func TestHubClientVerifyResponse(t *testing.T) {
+	csp := testutil.NewTestCSP(t)
+	c := &HubClient{}
+	c.SetCSP(map[string]*sw.SimpleCSP{"to": csp})
+	req := &pb.NoTransactionCallRequest{From: "from", To: "to", TransactionID: "t1", StepID: "1"}
+	payload := []byte("result")
+	sig, err := csp.Sign(payload)
+	assert.Nil(t, err)
+	resp := &pb.CommonResponseMessage{From: "from", To: "to", TransactionID: "t1", StepID: "1", Payload: payload,
+		Signer: sig, KeyID: csp.KeyID(), Algorithm: csp.Algorithm()}
+	assert.Nil(t, c.VerifyResponse(req, resp))
+
+	// 其他请求的响应不被接受
+	other := *resp
+	other.TransactionID = "t2"
+	assert.NotNil(t, c.VerifyResponse(req, &other))
+	other = *resp
+	other.StepID = "2"
+	assert.NotNil(t, c.VerifyResponse(req, &other))
+}
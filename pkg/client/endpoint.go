@@ -0,0 +1,206 @@
+package client
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc/connectivity"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultEjectTimeout 节点调用失败后被摘除的时间, 到期后重新参与选择
+const DefaultEjectTimeout = 10 * time.Second
+
+// Endpoint 远端网关的一个节点
+type Endpoint struct {
+	Address string
+	Port    uint32
+	// 优先级, 数值越小越优先, 同一优先级的节点全部不可用时才选择下一优先级
+	Priority int
+	// 同一优先级内按权重随机选择, 不大于 0 时视为 1
+	Weight int
+	// TLS 校验证书时使用的服务端名称, 为空时使用地址
+	ServerNameOverride string
+}
+
+func (e Endpoint) String() string {
+	return fmt.Sprintf("%s:%d", e.Address, e.Port)
+}
+
+type endpointState struct {
+	Endpoint
+	address string
+	conn    *Connection
+	// 连续调用失败次数与摘除到期时间
+	failures     int
+	ejectedUntil time.Time
+}
+
+// healthy 节点未被摘除且连接不处于建连失败状态
+func (e *endpointState) healthy(now time.Time) bool {
+	return !now.Before(e.ejectedUntil) && e.conn.State() != connectivity.TransientFailure
+}
+
+// EndpointPool 远端网关的多个节点, 同一 namespace 下的通道共享.
+// 按优先级与权重选择健康节点, 节点失败后摘除一段时间, 其余节点继续承接流量.
+// 远端网关只在单个节点内对跨链请求去重, 发件箱重发时切换到其他节点仍可能重复执行
+type EndpointPool struct {
+	name         string
+	ejectTimeout time.Duration
+
+	lock      sync.Mutex
+	endpoints []*endpointState
+	// 最近一次选择的节点
+	current *endpointState
+}
+
+// NewEndpointPool 为每个节点建立独立的长连接, 并按节点设置 TLS 服务端名称
+func NewEndpointPool(name string, client *cgrpc.GRPCClient, endpoints []Endpoint, ejectTimeout time.Duration) (*EndpointPool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.Errorf("the endpoints of remote hub %s are empty", name)
+	}
+	if ejectTimeout <= 0 {
+		ejectTimeout = DefaultEjectTimeout
+	}
+	p := &EndpointPool{name: name, ejectTimeout: ejectTimeout}
+	for _, endpoint := range endpoints {
+		var tlsOptions []cgrpc.TLSOption
+		if endpoint.ServerNameOverride != "" {
+			tlsOptions = append(tlsOptions, cgrpc.ServerNameOverride(endpoint.ServerNameOverride))
+		}
+		p.endpoints = append(p.endpoints, &endpointState{
+			Endpoint: endpoint,
+			address:  endpoint.String(),
+			conn:     NewConnection(endpoint.String(), client, tlsOptions...),
+		})
+	}
+	return p, nil
+}
+
+// newSingleEndpointPool 使用已有连接构造只有一个节点的节点池
+func newSingleEndpointPool(conn *Connection) *EndpointPool {
+	state := &endpointState{address: conn.address, conn: conn}
+	return &EndpointPool{
+		name:         conn.address,
+		ejectTimeout: DefaultEjectTimeout,
+		endpoints:    []*endpointState{state},
+	}
+}
+
+// pick 选择优先级最高的健康节点, 同一优先级内按权重随机.
+// 全部节点不可用时选择最早恢复的节点, 由调用结果决定是否继续摘除
+func (p *EndpointPool) pick() *endpointState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	var candidates []*endpointState
+	for _, e := range p.endpoints {
+		if !e.healthy(now) {
+			continue
+		}
+		if len(candidates) > 0 && e.Priority > candidates[0].Priority {
+			continue
+		}
+		if len(candidates) > 0 && e.Priority < candidates[0].Priority {
+			candidates = candidates[:0]
+		}
+		candidates = append(candidates, e)
+	}
+
+	var selected *endpointState
+	if len(candidates) == 0 {
+		for _, e := range p.endpoints {
+			if selected == nil || e.ejectedUntil.Before(selected.ejectedUntil) {
+				selected = e
+			}
+		}
+	} else {
+		selected = weightedRandom(candidates)
+	}
+	if p.current != nil && p.current != selected && !p.current.healthy(now) {
+		logrus.Warnf("remote hub %s fails over from %s to %s", p.name, p.current.address, selected.address)
+	}
+	p.current = selected
+	return selected
+}
+
+func weightedRandom(endpoints []*endpointState) *endpointState {
+	total := 0
+	for _, e := range endpoints {
+		total += weight(e)
+	}
+	n := rand.Intn(total)
+	for _, e := range endpoints {
+		if n -= weight(e); n < 0 {
+			return e
+		}
+	}
+	return endpoints[len(endpoints)-1]
+}
+
+func weight(e *endpointState) int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+func (p *EndpointPool) success(e *endpointState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	e.failures = 0
+	e.ejectedUntil = time.Time{}
+}
+
+// failure 摘除调用失败的节点, 到期前不再选择
+func (p *EndpointPool) failure(e *endpointState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	e.failures++
+	e.ejectedUntil = time.Now().Add(p.ejectTimeout)
+	logrus.Warnf("endpoint %s of remote hub %s is ejected for %s after %d failures", e.address, p.name, p.ejectTimeout, e.failures)
+}
+
+// selected 返回最近一次选择的节点, 尚未调用时为第一个节点
+func (p *EndpointPool) selected() *endpointState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.current == nil {
+		return p.endpoints[0]
+	}
+	return p.current
+}
+
+// Status 返回各节点的连接与健康状态
+func (p *EndpointPool) Status() []*pb.RemoteEndpointStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	var status []*pb.RemoteEndpointStatus
+	for _, e := range p.endpoints {
+		status = append(status, &pb.RemoteEndpointStatus{
+			Address:      e.address,
+			Connectivity: e.conn.State().String(),
+			Healthy:      e.healthy(now),
+			Priority:     int32(e.Priority),
+			Weight:       int32(weight(e)),
+			Failures:     uint32(e.failures),
+		})
+	}
+	return status
+}
+
+func (p *EndpointPool) Close() error {
+	var err error
+	for _, e := range p.endpoints {
+		if cerr := e.conn.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
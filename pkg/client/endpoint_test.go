@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	cgrpc "github.com/fabric-creed/fabric-hub/pkg/common/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointPool(t *testing.T) {
+	grpcClient, err := cgrpc.NewGRPCClient(cgrpc.ClientConfig{KaOpts: cgrpc.DefaultKeepaliveOptions})
+	assert.Nil(t, err)
+	_, err = NewEndpointPool("remote", grpcClient, nil, 0)
+	assert.NotNil(t, err)
+
+	pool, err := NewEndpointPool("remote", grpcClient, []Endpoint{
+		{Address: "10.0.0.1", Port: 2000, Priority: 0, ServerNameOverride: "hub1.example.com"},
+		{Address: "10.0.0.2", Port: 2000, Priority: 1, Weight: 3},
+		{Address: "10.0.0.3", Port: 2000, Priority: 1, Weight: 1},
+	}, 50*time.Millisecond)
+	assert.Nil(t, err)
+	defer pool.Close()
+	assert.Len(t, pool.endpoints[0].conn.tlsOptions, 1)
+	assert.Len(t, pool.endpoints[1].conn.tlsOptions, 0)
+
+	// 优先选择高优先级节点
+	primary := pool.pick()
+	assert.Equal(t, "10.0.0.1:2000", primary.address)
+	assert.Equal(t, "10.0.0.1:2000", pool.pick().address)
+
+	// 主节点失败后切换到下一优先级的节点
+	pool.failure(primary)
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		counts[pool.pick().address]++
+	}
+	assert.Equal(t, 0, counts["10.0.0.1:2000"])
+	assert.True(t, counts["10.0.0.2:2000"] > counts["10.0.0.3:2000"])
+	assert.False(t, pool.Status()[0].Healthy)
+	assert.Equal(t, uint32(1), pool.Status()[0].Failures)
+
+	// 全部节点被摘除时选择最早恢复的节点
+	for _, e := range pool.endpoints[1:] {
+		pool.failure(e)
+	}
+	assert.Equal(t, "10.0.0.1:2000", pool.pick().address)
+
+	// 摘除到期后恢复
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "10.0.0.1:2000", pool.pick().address)
+	pool.success(primary)
+	assert.True(t, pool.Status()[0].Healthy)
+	assert.Equal(t, uint32(0), pool.Status()[0].Failures)
+}
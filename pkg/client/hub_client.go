@@ -1,23 +1,85 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"github.com/fabric-creed/fabric-hub/pkg/common/grpc"
 	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	ggrpc "github.com/fabric-creed/grpc"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"time"
 )
 
 type HubClient struct {
 	address string
 	port    uint32
 	client  *grpc.GRPCClient
+	// 远端网关各节点的长连接, 可由同一 namespace 下的通道共享
+	endpoints *EndpointPool
+	// 重试策略与熔断器, 熔断器可由同一 namespace 下的通道共享
+	retryPolicy RetryPolicy
+	breaker     *CircuitBreaker
+	// 调用方未设置截止时间时的默认超时, 包含重试
+	timeout time.Duration
 	csp     map[string]*sw.SimpleCSP
+	// 远端通道的多签门限策略
+	attestationPolicy map[string]*AttestationPolicy
+	// 远端通道的背书证明校验器
+	endorsementVerifier map[string]*EndorsementVerifier
 }
 
-func NewHubClient(address string, port uint32, client *grpc.GRPCClient) (*HubClient, error) {
-	return &HubClient{
+type Option func(c *HubClient)
+
+// WithConnection 使用共享的连接, 未指定时单独建立连接
+func WithConnection(conn *Connection) Option {
+	return func(c *HubClient) {
+		c.endpoints = newSingleEndpointPool(conn)
+	}
+}
+
+// WithEndpoints 使用共享的多节点连接池, 节点故障时切换到其余节点
+func WithEndpoints(endpoints *EndpointPool) Option {
+	return func(c *HubClient) {
+		c.endpoints = endpoints
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *HubClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithCircuitBreaker 设置熔断器, 未指定时不熔断
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *HubClient) {
+		c.breaker = breaker
+	}
+}
+
+// WithTimeout 设置调用的默认超时, 为 0 时不限制
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *HubClient) {
+		c.timeout = timeout
+	}
+}
+
+func NewHubClient(address string, port uint32, client *grpc.GRPCClient, opts ...Option) (*HubClient, error) {
+	c := &HubClient{
 		address: address,
 		port:    port,
 		client:  client,
-	}, nil
+		// 未指定时使用默认重试策略
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.endpoints == nil {
+		c.endpoints = newSingleEndpointPool(NewConnection(fmt.Sprintf("%s:%d", address, port), client))
+	}
+	return c, nil
 }
 
 func NewGRPCClient(certPath, keyPath, caCertPath, serverCACertPath string, isGm bool) (*grpc.GRPCClient, error) {
@@ -32,13 +94,107 @@ func NewGRPCClient(certPath, keyPath, caCertPath, serverCACertPath string, isGm
 		return nil, err
 	}
 	cc := grpc.ClientConfig{
-		SecOpts: so,
-		KaOpts:  grpc.DefaultKeepaliveOptions,
-		Timeout: grpc.DefaultConnectionTimeout,
+		SecOpts:     so,
+		KaOpts:      grpc.DefaultKeepaliveOptions,
+		Timeout:     grpc.DefaultConnectionTimeout,
+		BackoffOpts: grpc.DefaultBackoffOptions,
 	}
 	return grpc.NewGRPCClient(cc)
 }
 
+// withTimeout 调用方未设置截止时间时使用默认超时
+func (c *HubClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// call 按重试策略调用远端网关, 熔断期间直接失败. 每次尝试选择一个健康节点,
+// 调用失败或超时的节点被摘除, 重试时切换到其余节点. 远端已处理请求(返回不可重试的错误)或
+// 调用方主动取消时不计入熔断器的失败次数
+func (c *HubClient) call(ctx context.Context, method string, fn func(conn *ggrpc.ClientConn) error) error {
+	return c.invoke(ctx, method, true, fn)
+}
+
+// callNonIdempotent 调用非幂等的方法, 只在请求未发出(取连接失败)时重试. 请求可能已送达远端时
+// 不再重发, 由调用方决定是否重发: 跨链任务的发件箱在确认远端按 From、TransactionID 与 StepID
+// 去重后重发, 而去重只在远端单个节点内生效, 在此重试可能由其他节点重复执行
+func (c *HubClient) callNonIdempotent(ctx context.Context, method string, fn func(conn *ggrpc.ClientConn) error) error {
+	return c.invoke(ctx, method, false, fn)
+}
+
+// invoke idempotent 为 false 时, 请求发出后即使失败也不再重试
+func (c *HubClient) invoke(ctx context.Context, method string, idempotent bool, fn func(conn *ggrpc.ClientConn) error) error {
+	var (
+		err      error
+		endpoint *endpointState
+	)
+	for attempt := 1; attempt <= c.retryPolicy.Attempts(); attempt++ {
+		if attempt > 1 {
+			backoff := c.retryPolicy.Backoff(attempt - 1)
+			logrus.Warnf("failed to call %s of %s, retry %d after %s, err:%s", method, endpoint.address, attempt-1, backoff, err)
+			// 调用方取消或超时后不再重试
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Wrapf(ctx.Err(), "failed to call %s of %s, last err:%s", method, endpoint.address, err)
+			case <-timer.C:
+			}
+		}
+		if c.breaker != nil {
+			if err = c.breaker.Allow(); err != nil {
+				return err
+			}
+		}
+		endpoint = c.endpoints.pick()
+		var conn *ggrpc.ClientConn
+		sent := false
+		if conn, err = endpoint.conn.Get(); err == nil {
+			sent = true
+			err = fn(conn)
+		}
+		// 调用方主动取消不代表远端不可用, 不计入熔断器. 超时说明远端未及时响应, 计入失败
+		switch ctx.Err() {
+		case context.Canceled:
+			if c.breaker != nil {
+				c.breaker.Release()
+			}
+			return err
+		case context.DeadlineExceeded:
+			c.endpoints.failure(endpoint)
+			if c.breaker != nil {
+				c.breaker.Failure()
+			}
+			return err
+		}
+		if !c.retryPolicy.Retryable(err) {
+			c.endpoints.success(endpoint)
+			if c.breaker != nil {
+				c.breaker.Success()
+			}
+			return err
+		}
+		c.endpoints.failure(endpoint)
+		if c.breaker != nil {
+			c.breaker.Failure()
+		}
+		if sent && !idempotent {
+			return err
+		}
+	}
+	return err
+}
+
 func (c *HubClient) SetCSP(csp map[string]*sw.SimpleCSP) {
 	c.csp = csp
 }
+
+func (c *HubClient) SetAttestationPolicy(policy map[string]*AttestationPolicy) {
+	c.attestationPolicy = policy
+}
+
+func (c *HubClient) SetEndorsementVerifier(verifier map[string]*EndorsementVerifier) {
+	c.endorsementVerifier = verifier
+}
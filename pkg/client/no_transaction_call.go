@@ -2,16 +2,16 @@ package client
 
 import (
 	"context"
-	"fmt"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
-	"github.com/fabric-creed/grpc/codes"
-	"github.com/fabric-creed/grpc/status"
+	"github.com/fabric-creed/grpc"
 	"github.com/pkg/errors"
 	"time"
 )
 
-func (c *HubClient) NoTransactionCall(request *pb.NoTransactionCallRequest) (*pb.CommonResponseMessage, error) {
-	if request.Signer == nil {
+func (c *HubClient) NoTransactionCall(ctx context.Context, request *pb.NoTransactionCallRequest) (*pb.CommonResponseMessage, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if len(request.Signer) == 0 {
 		fromCSP, ok := c.csp[request.From]
 		if !ok {
 			return nil, errors.New("the from id is invalid")
@@ -21,47 +21,81 @@ func (c *HubClient) NoTransactionCall(request *pb.NoTransactionCallRequest) (*pb
 			return nil, errors.Wrapf(err, "failed to sign payload by %s", request.From)
 		}
 		request.Signer = sign
+		request.KeyID = fromCSP.KeyID()
+		request.Algorithm = fromCSP.Algorithm()
 	}
 	if request.Timestamp == 0 {
 		request.Timestamp = time.Now().Unix()
 	}
+	// 未指定超时时间时, 每次尝试前按调用方的剩余时间设置, 远端网关据此设置 Fabric SDK 的请求超时
+	deadline, withDeadline := ctx.Deadline()
+	if withDeadline = withDeadline && request.Timeout == 0; withDeadline {
+		// 请求可能由调用方重发, 不保留本次调用的超时时间
+		defer func() { request.Timeout = 0 }()
+	}
 
-	retryTime := 5
-retry:
-	conn, err := c.client.NewConnection(fmt.Sprintf("%s:%d", c.address, c.port))
+	var resp *pb.CommonResponseMessage
+	err := c.callNonIdempotent(ctx, "NoTransactionCall", func(conn *grpc.ClientConn) (err error) {
+		if withDeadline {
+			timeout := time.Until(deadline).Milliseconds()
+			if timeout <= 0 {
+				return context.DeadlineExceeded
+			}
+			request.Timeout = timeout
+		}
+		resp, err = pb.NewHubClient(conn).NoTransactionCall(ctx, request)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
-	resp, err := pb.NewHubClient(conn).NoTransactionCall(context.Background(), request)
-	if err != nil {
-		statu, ok := status.FromError(err)
-		if ok {
-			// 判断是否为调用超时
-			if statu.Code() == codes.DeadlineExceeded {
-				if retryTime > 0 {
-					retryTime--
-					conn.Close()
-					goto retry
-				}
-			}
-		}
+	if err = c.VerifyResponse(request, resp); err != nil {
 		return nil, err
 	}
 
+	return resp, nil
+}
+
+// VerifyResponse 确认响应属于 req, 使用目的链的公钥核实响应签名, 并按配置核实多签门限与背书证明
+func (c *HubClient) VerifyResponse(req *pb.NoTransactionCallRequest, resp *pb.CommonResponseMessage) error {
+	if resp.From != req.From || resp.To != req.To || resp.TransactionID != req.TransactionID || resp.StepID != req.StepID {
+		return errors.Errorf("the response of %s/%s from %s to %s does not match the request of %s/%s from %s to %s",
+			resp.TransactionID, resp.StepID, resp.From, resp.To, req.TransactionID, req.StepID, req.From, req.To)
+	}
+	if err := c.verifySigner(resp); err != nil {
+		return err
+	}
+	// 配置了远端通道的 MSP 时, 需核实远端节点的背书而非仅信任网关
+	if verifier, ok := c.endorsementVerifier[resp.To]; ok {
+		if err := verifier.VerifyResponse(req, resp); err != nil {
+			return errors.Wrap(err, "the message from server does not carry a valid endorsement proof")
+		}
+	}
+
+	return nil
+}
+
+// verifySigner 使用目的链的公钥核实响应签名, 并按配置核实多签门限
+func (c *HubClient) verifySigner(resp *pb.CommonResponseMessage) error {
 	toCSP, ok := c.csp[resp.To]
 	if !ok {
-		return nil, errors.New("the to channel id is invalid")
+		return errors.New("the to channel id is invalid")
 	}
-	// 使用目的链的公钥核实消息签名
-	valid, err := toCSP.Verify(resp.Signer, resp.Payload)
+	// 使用目的链的公钥核实消息签名, 按 keyID 选择轮换期间的有效公钥
+	valid, err := toCSP.VerifyWithKeyID(resp.KeyID, resp.Algorithm, resp.Signer, resp.Payload)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to verify signer")
+		return errors.Wrapf(err, "failed to verify signer")
 	}
 	if !valid {
-		return nil, errors.New("the message from sever is in invalid")
+		return errors.New("the message from sever is in invalid")
+	}
+	// 配置了多签策略时, 需满足门限才接受响应
+	if policy, ok := c.attestationPolicy[resp.To]; ok {
+		if err = policy.Evaluate(resp); err != nil {
+			return errors.Wrap(err, "the message from server does not satisfy the attestation policy")
+		}
 	}
 
-	return resp, nil
+	return nil
 }
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+)
+
+// RetryPolicy 调用远端网关的重试策略, 重试间隔按指数增长并带随机抖动
+type RetryPolicy struct {
+	// 最大尝试次数, 包含首次调用
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	// 可重试的 grpc 状态码, 非 grpc 状态的错误(如建连失败)总是可重试
+	RetryableCodes []codes.Code
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted},
+}
+
+// Retryable 判断错误是否可重试
+func (p RetryPolicy) Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	for _, code := range p.RetryableCodes {
+		if s.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff 第 attempt 次重试前的等待时间, attempt 从 1 开始
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	d, max := float64(p.InitialBackoff), float64(p.MaxBackoff)
+	for i := 1; i < attempt && d < max; i++ {
+		d *= p.Multiplier
+	}
+	if max > 0 && d > max {
+		d = max
+	}
+	d *= 1 + p.Jitter*(rand.Float64()*2-1)
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
+
+// Attempts 返回最大尝试次数, 未配置时只调用一次
+func (p RetryPolicy) Attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc"
+)
+
+// Status 返回到远端网关各节点的连接与熔断状态
+func (c *HubClient) Status() *pb.RemoteHubStatus {
+	endpoint := c.endpoints.selected()
+	status := &pb.RemoteHubStatus{
+		Address:      endpoint.address,
+		Connectivity: endpoint.conn.State().String(),
+		Endpoints:    c.endpoints.Status(),
+	}
+	if c.breaker != nil {
+		state, failures := c.breaker.State()
+		status.Breaker = state.String()
+		status.Failures = uint32(failures)
+	}
+	return status
+}
+
+// QueryStatus 查询远端网关到其远端网关的状态
+func (c *HubClient) QueryStatus(ctx context.Context, request *pb.StatusRequest) (*pb.StatusResponse, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *pb.StatusResponse
+	err := c.call(ctx, "Status", func(conn *grpc.ClientConn) (err error) {
+		resp, err = pb.NewHubClient(conn).Status(ctx, request)
+		return err
+	})
+	return resp, err
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc"
+)
+
+func (c *HubClient) SyncBlockHeaders(ctx context.Context, request *pb.SyncBlockHeadersRequest) (*pb.SyncBlockHeadersResponse, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *pb.SyncBlockHeadersResponse
+	err := c.call(ctx, "SyncBlockHeaders", func(conn *grpc.ClientConn) (err error) {
+		resp, err = pb.NewHubClient(conn).SyncBlockHeaders(ctx, request)
+		return err
+	})
+	return resp, err
+}
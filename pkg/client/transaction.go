@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc"
+)
+
+// StartTransaction 事务接口的响应不携带背书证明, 只核实网关签名与多签门限
+func (c *HubClient) StartTransaction(ctx context.Context, request *pb.StartTransactionRequest) (*pb.CommonResponseMessage, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *pb.CommonResponseMessage
+	err := c.callNonIdempotent(ctx, "StartTransaction", func(conn *grpc.ClientConn) (err error) {
+		resp, err = pb.NewHubClient(conn).StartTransaction(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = c.verifySigner(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *HubClient) SendTransaction(ctx context.Context, request *pb.SendTransactionRequest) (*pb.CommonResponseMessage, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *pb.CommonResponseMessage
+	err := c.callNonIdempotent(ctx, "SendTransaction", func(conn *grpc.ClientConn) (err error) {
+		resp, err = pb.NewHubClient(conn).SendTransaction(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = c.verifySigner(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *HubClient) CommitTransaction(ctx context.Context, request *pb.CommitTransactionRequest) (*pb.CommonResponseMessage, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	var resp *pb.CommonResponseMessage
+	err := c.callNonIdempotent(ctx, "CommitTransaction", func(conn *grpc.ClientConn) (err error) {
+		resp, err = pb.NewHubClient(conn).CommitTransaction(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = c.verifySigner(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
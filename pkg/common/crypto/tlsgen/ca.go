@@ -3,6 +3,7 @@ package tlsgen
 import (
 	"crypto"
 	"github.com/fabric-creed/cryptogm/x509"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
 )
 
 // CertKeyPair denotes a TLS certificate and corresponding key,
@@ -23,6 +24,9 @@ type CA interface {
 	// CertBytes returns the certificate of the CA in PEM encoding
 	CertBytes() []byte
 
+	// KeyBytes returns the private key of the CA in PEM encoding
+	KeyBytes() []byte
+
 	NewIntermediateCA(isGM bool) (CA, error)
 
 	// newCertKeyPair returns a certificate and private key pair and nil,
@@ -66,6 +70,11 @@ func (c *ca) CertBytes() []byte {
 	return c.caCert.Cert
 }
 
+// KeyBytes returns the private key of the CA in PEM encoding
+func (c *ca) KeyBytes() []byte {
+	return c.caCert.Key
+}
+
 // newClientCertKeyPair returns a certificate and private key pair and nil,
 // or nil, error in case of failure
 // The certificate is signed by the CA and is used as a client TLS certificate
@@ -83,3 +92,9 @@ func (c *ca) NewServerCertKeyPair(host string, isGM bool) (*CertKeyPair, error)
 	}
 	return keypair, nil
 }
+
+// EncryptedKey returns the private key as a PKCS#8 PEM block encrypted
+// with password, suitable for the csp section of the hub config
+func (p *CertKeyPair) EncryptedKey(password []byte) ([]byte, error) {
+	return sw.PrivateKeyToPEM(p.Signer, password)
+}
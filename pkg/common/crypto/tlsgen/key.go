@@ -68,7 +68,7 @@ func newCertKeyPair(isCA bool, isServer bool, host string, certSigner crypto.Sig
 	switch publicKey.(type) {
 	case *sm2.PublicKey:
 		pub = publicKey.(*sm2.PublicKey)
-	case *ecdsa.PrivateKey:
+	case *ecdsa.PublicKey:
 		pub = publicKey.(*ecdsa.PublicKey)
 	}
 
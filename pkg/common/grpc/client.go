@@ -7,6 +7,7 @@ import (
 	"github.com/fabric-creed/cryptogm/tls"
 	"github.com/fabric-creed/cryptogm/x509"
 	"github.com/fabric-creed/grpc"
+	"github.com/fabric-creed/grpc/backoff"
 	"github.com/fabric-creed/grpc/keepalive"
 	"github.com/pkg/errors"
 	"time"
@@ -23,6 +24,8 @@ type GRPCClient struct {
 	maxRecvMsgSize int
 	// Maximum message size the client can send
 	maxSendMsgSize int
+	// Backoff between reconnection attempts
+	backoff backoff.Config
 }
 
 // NewGRPCClient creates a new implementation of GRPCClient given an address
@@ -51,6 +54,15 @@ func NewGRPCClient(config ClientConfig) (*GRPCClient, error) {
 		client.dialOpts = append(client.dialOpts, grpc.FailOnNonTempDialError(true))
 	}
 	client.timeout = config.Timeout
+	// reconnection backoff of established connections
+	client.backoff = backoff.DefaultConfig
+	if config.BackoffOpts.BaseDelay > 0 {
+		client.backoff = config.BackoffOpts
+		client.dialOpts = append(client.dialOpts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           config.BackoffOpts,
+			MinConnectTimeout: config.Timeout,
+		}))
+	}
 	// set send/recv message size to package defaults
 	client.maxRecvMsgSize = MaxRecvMsgSize
 	client.maxSendMsgSize = MaxSendMsgSize
@@ -126,6 +138,11 @@ func (client *GRPCClient) Certificate() tls.Certificate {
 	return cert
 }
 
+// Backoff returns the backoff configuration between reconnection attempts
+func (client *GRPCClient) Backoff() backoff.Config {
+	return client.backoff
+}
+
 // TLSEnabled is a flag indicating whether to use TLS for client
 // connections
 func (client *GRPCClient) TLSEnabled() bool {
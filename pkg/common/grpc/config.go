@@ -5,6 +5,7 @@ import (
 	"github.com/fabric-creed/cryptogm/x509"
 	"github.com/fabric-creed/fabric-hub/pkg/common/crypto/tlsgen"
 	"github.com/fabric-creed/grpc"
+	"github.com/fabric-creed/grpc/backoff"
 	"github.com/fabric-creed/grpc/keepalive"
 	"github.com/pkg/errors"
 	"io/ioutil"
@@ -40,6 +41,13 @@ var (
 	}
 	// default connection timeout
 	DefaultConnectionTimeout = 10 * time.Second
+	// Default reconnection backoff for long-lived client connections
+	DefaultBackoffOptions = backoff.Config{
+		BaseDelay:  time.Second,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+		MaxDelay:   30 * time.Second,
+	}
 )
 
 // ServerConfig defines the parameters for configuring a GRPCServer instance
@@ -72,6 +80,9 @@ type ClientConfig struct {
 	Timeout time.Duration
 	// AsyncConnect makes connection creation non blocking
 	AsyncConnect bool
+	// BackoffOpts defines the backoff between reconnection attempts,
+	// the gRPC defaults are used when BaseDelay is zero
+	BackoffOpts backoff.Config
 }
 
 // Clone clones this ClientConfig
@@ -1,10 +1,51 @@
 package sw
 
 import (
+	"bytes"
+	"encoding/hex"
 	"github.com/pkg/errors"
 	"io/ioutil"
+	"sync"
+	"time"
 )
 
+const (
+	// FileKeyStore reads the private key from a PEM file
+	FileKeyStore = "file"
+	// DirKeyStore reads the private key from a directory keyed by SKI
+	DirKeyStore = "dir"
+	// ExternalKeyStore delegates signing to a signer process
+	ExternalKeyStore = "external"
+)
+
+const (
+	AlgorithmSM2   = "SM2"
+	AlgorithmECDSA = "ECDSA"
+)
+
+// KeyAlgorithm returns the signature algorithm identifier of key k.
+func KeyAlgorithm(k Key) string {
+	switch key := k.(type) {
+	case *sm2PrivateKey, *sm2PublicKey:
+		return AlgorithmSM2
+	case *ecdsaPrivateKey, *ecdsaPublicKey:
+		return AlgorithmECDSA
+	case *externalKey:
+		return KeyAlgorithm(key.pub)
+	default:
+		return ""
+	}
+}
+
+// KeyID returns the identifier carried in messages signed by key k,
+// the hex encoded SKI.
+func KeyID(k Key) string {
+	if k == nil {
+		return ""
+	}
+	return hex.EncodeToString(k.SKI())
+}
+
 type CSP struct{}
 
 // Sign signs digest using key k.
@@ -29,6 +70,10 @@ func (csp *CSP) Sign(k Key, digest []byte, opts SignerOpts) (signature []byte, e
 	case *ecdsaPrivateKey, *ecdsaPublicKey:
 		signer := ecdsaSigner{}
 		signature, err = signer.Sign(k, digest, opts)
+	case *externalKey:
+		signature, err = k.(*externalKey).sign(digest)
+	default:
+		return nil, errors.Errorf("Unsupported key type [%T]", k)
 	}
 
 	if err != nil {
@@ -64,6 +109,10 @@ func (csp *CSP) Verify(k Key, signature, digest []byte, opts SignerOpts) (valid
 	case *ecdsaPrivateKey:
 		verifier := ecdsaPrivateKeyVerifier{}
 		valid, err = verifier.Verify(k, signature, digest, opts)
+	case *externalKey:
+		return csp.Verify(k.(*externalKey).pub, signature, digest, opts)
+	default:
+		return false, errors.Errorf("Unsupported key type [%T]", k)
 	}
 	if err != nil {
 		return false, errors.Wrapf(err, "Failed verifing with opts [%v]", opts)
@@ -74,51 +123,142 @@ func (csp *CSP) Verify(k Key, signature, digest []byte, opts SignerOpts) (valid
 
 type SimpleCSP struct {
 	*CSP
-	*KeyStore
+	// KeyStore the signing key was resolved from, nil for verify only CSPs
+	KeyStore   KeyStore
+	PrivateKey Key
+	PublicKey  Key
+
+	// verifyKeys are the additional keys accepted during key rollover
+	verifyKeys []*VerifyKey
+	lock       sync.RWMutex
 }
 
-func NewSimpleCSP(keyPath, certPath string) (*SimpleCSP, error) {
-	ks, err := newKeyStore(keyPath, certPath)
-	if err != nil {
-		return nil, err
+// VerifyKey is a verification key with an optional validity period,
+// zero times leave the period open.
+type VerifyKey struct {
+	Key       Key
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// ValidAt reports whether the key may be used at t.
+func (k *VerifyKey) ValidAt(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+	if !k.NotAfter.IsZero() && t.After(k.NotAfter) {
+		return false
 	}
-	return &SimpleCSP{
-		CSP:      &CSP{},
-		KeyStore: ks,
-	}, nil
+	return true
 }
 
-type KeyStore struct {
-	PrivateKey Key
-	PublicKey  Key
+// KeyStoreOpts describes where a SimpleCSP loads its keys from.
+type KeyStoreOpts struct {
+	// Type of the KeyStore, one of file (default), dir and external
+	Type string
+	// KeyPath is the PEM private key read by the file KeyStore
+	KeyPath string
+	// CertPath is the certificate holding the verification key
+	CertPath string
+	// Dir is the directory of the dir KeyStore
+	Dir string
+	// SKI selects the signing key, it defaults to the SKI of the certificate
+	SKI []byte
+	// SignerAddress is the socket of the external signer process
+	SignerAddress string
+	// Password decrypts encrypted PKCS#8 private keys
+	Password []byte
 }
 
-func newKeyStore(keyPath, certPath string) (*KeyStore, error) {
-	keyStore := &KeyStore{}
-	if keyPath != "" {
-		key, err := ioutil.ReadFile(keyPath)
+func NewSimpleCSP(keyPath, certPath string) (*SimpleCSP, error) {
+	return NewSimpleCSPWithOpts(KeyStoreOpts{KeyPath: keyPath, CertPath: certPath})
+}
+
+// NewSimpleCSPWithOpts creates a SimpleCSP whose signing key comes from
+// the KeyStore described by opts.
+func NewSimpleCSPWithOpts(opts KeyStoreOpts) (*SimpleCSP, error) {
+	s := &SimpleCSP{CSP: &CSP{}}
+	if opts.CertPath != "" {
+		cert, err := ioutil.ReadFile(opts.CertPath)
 		if err != nil {
 			return nil, err
 		}
-		priKey, err := ParsePrivateKey(key)
+		pubKey, err := ParsePublicByCertificate(cert)
 		if err != nil {
 			return nil, err
 		}
-		keyStore.PrivateKey = priKey
+		s.PublicKey = pubKey
 	}
-	if certPath != "" {
-		cert, err := ioutil.ReadFile(certPath)
+
+	ski := opts.SKI
+	switch opts.Type {
+	case "", FileKeyStore:
+		if opts.KeyPath == "" {
+			return s, nil
+		}
+		ks, err := NewFileKeyStore(opts.KeyPath, opts.Password)
 		if err != nil {
 			return nil, err
 		}
-		pubKey, err := ParsePublicByCertificate(cert)
+		s.KeyStore = ks
+	case DirKeyStore:
+		ks, err := NewDirKeyStore(opts.Dir, opts.Password, true)
 		if err != nil {
 			return nil, err
 		}
-		keyStore.PublicKey = pubKey
+		if len(ski) == 0 && s.PublicKey != nil {
+			ski = s.PublicKey.SKI()
+		}
+		s.KeyStore = ks
+	case ExternalKeyStore:
+		ks, err := NewExternalKeyStore(opts.SignerAddress)
+		if err != nil {
+			return nil, err
+		}
+		if len(ski) == 0 && s.PublicKey != nil {
+			ski = s.PublicKey.SKI()
+		}
+		s.KeyStore = ks
+	default:
+		return nil, errors.Errorf("unknown key store type %s", opts.Type)
 	}
 
-	return keyStore, nil
+	key, err := s.KeyStore.GetKey(ski)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signing key")
+	}
+	pubKey, err := key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	// signatures are verified with the certificate, a signing key that
+	// does not belong to it would only fail on the remote side
+	if s.PublicKey != nil {
+		if err = matchPublicKey(pubKey, s.PublicKey); err != nil {
+			return nil, errors.WithMessagef(err, "the signing key does not match the certificate %s", opts.CertPath)
+		}
+	} else {
+		s.PublicKey = pubKey
+	}
+	s.PrivateKey = key
+
+	return s, nil
+}
+
+// matchPublicKey returns an error unless both keys are the same public key.
+func matchPublicKey(a, b Key) error {
+	rawA, err := a.Bytes()
+	if err != nil {
+		return err
+	}
+	rawB, err := b.Bytes()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rawA, rawB) {
+		return errors.Errorf("public key %x differs from %x", a.SKI(), b.SKI())
+	}
+	return nil
 }
 
 func (s *SimpleCSP) Sign(digest []byte) (signature []byte, err error) {
@@ -128,3 +268,79 @@ func (s *SimpleCSP) Sign(digest []byte) (signature []byte, err error) {
 func (s *SimpleCSP) Verify(signature, digest []byte) (bool, error) {
 	return s.CSP.Verify(s.PublicKey, signature, digest, nil)
 }
+
+// KeyID returns the identifier of the signing key.
+func (s *SimpleCSP) KeyID() string {
+	if s.PrivateKey != nil {
+		return KeyID(s.PrivateKey)
+	}
+	return KeyID(s.PublicKey)
+}
+
+// Algorithm returns the algorithm identifier of the signing key.
+func (s *SimpleCSP) Algorithm() string {
+	if s.PrivateKey != nil {
+		return KeyAlgorithm(s.PrivateKey)
+	}
+	return KeyAlgorithm(s.PublicKey)
+}
+
+// AddVerifyKey registers an extra verification key, so that a partner can
+// roll its signing key over while both keys are accepted.
+func (s *SimpleCSP) AddVerifyKey(k *VerifyKey) error {
+	if k == nil || k.Key == nil {
+		return errors.New("verify key must not be nil")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.verifyKeys = append(s.verifyKeys, k)
+	return nil
+}
+
+// VerifyKeys returns the verification keys valid at t, the key of the
+// configured certificate comes first.
+func (s *SimpleCSP) VerifyKeys(t time.Time) []Key {
+	var keys []Key
+	if s.PublicKey != nil {
+		keys = append(keys, s.PublicKey)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, k := range s.verifyKeys {
+		if k.ValidAt(t) {
+			keys = append(keys, k.Key)
+		}
+	}
+	return keys
+}
+
+// VerifyWithKeyID verifies signature with the key named by keyID. Messages
+// without a key id are checked against every key valid now.
+func (s *SimpleCSP) VerifyWithKeyID(keyID, algorithm string, signature, digest []byte) (bool, error) {
+	keys := s.VerifyKeys(time.Now())
+	if keyID == "" {
+		var lastErr error
+		for _, k := range keys {
+			valid, err := s.CSP.Verify(k, signature, digest, nil)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if valid {
+				return true, nil
+			}
+		}
+		return false, lastErr
+	}
+
+	for _, k := range keys {
+		if KeyID(k) != keyID {
+			continue
+		}
+		if algorithm != "" && algorithm != KeyAlgorithm(k) {
+			return false, errors.Errorf("algorithm %s does not match key %s", algorithm, keyID)
+		}
+		return s.CSP.Verify(k, signature, digest, nil)
+	}
+	return false, errors.Errorf("key %s is unknown or not valid now", keyID)
+}
@@ -1,9 +1,13 @@
 package sw
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestPrivateSignPublicVerify(t *testing.T) {
@@ -18,3 +22,41 @@ func TestPrivateSignPublicVerify(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, valid)
 }
+
+func TestVerifyWithKeyID(t *testing.T) {
+	msg := []byte("hello world")
+	oldCSP, err := NewSimpleCSP("./test/server.key", "./test/server.crt")
+	assert.Nil(t, err)
+
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	newKey := NewEcdsaPrivateKey(raw)
+	newPub, err := newKey.PublicKey()
+	assert.Nil(t, err)
+	newCSP := &SimpleCSP{CSP: &CSP{}, PrivateKey: newKey, PublicKey: newPub}
+
+	verifier, err := NewSimpleCSP("", "./test/server.crt")
+	assert.Nil(t, err)
+	assert.Nil(t, verifier.AddVerifyKey(&VerifyKey{Key: newPub, NotBefore: time.Now().Add(-time.Hour)}))
+
+	for _, signer := range []*SimpleCSP{oldCSP, newCSP} {
+		signature, err := signer.Sign(msg)
+		assert.Nil(t, err)
+		valid, err := verifier.VerifyWithKeyID(signer.KeyID(), signer.Algorithm(), signature, msg)
+		assert.Nil(t, err)
+		assert.True(t, valid)
+		valid, err = verifier.VerifyWithKeyID("", "", signature, msg)
+		assert.Nil(t, err)
+		assert.True(t, valid)
+	}
+
+	expired, err := NewSimpleCSP("", "./test/server.crt")
+	assert.Nil(t, err)
+	assert.Nil(t, expired.AddVerifyKey(&VerifyKey{Key: newPub, NotAfter: time.Now().Add(-time.Hour)}))
+	signature, err := newCSP.Sign(msg)
+	assert.Nil(t, err)
+	_, err = expired.VerifyWithKeyID(newCSP.KeyID(), newCSP.Algorithm(), signature, msg)
+	assert.NotNil(t, err)
+	_, err = verifier.VerifyWithKeyID(newCSP.KeyID(), AlgorithmSM2, signature, msg)
+	assert.NotNil(t, err)
+}
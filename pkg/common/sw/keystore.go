@@ -0,0 +1,189 @@
+package sw
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// KeyStore represents a storage system for the keys used by SimpleCSP.
+type KeyStore interface {
+	// ReadOnly returns true if this KeyStore is read only, false otherwise.
+	// If ReadOnly is true then StoreKey will fail.
+	ReadOnly() bool
+
+	// GetKey returns a key object whose SKI is the one passed.
+	// A KeyStore holding a single key returns it when ski is empty.
+	GetKey(ski []byte) (Key, error)
+
+	// StoreKey stores the key k in this KeyStore.
+	// If this KeyStore is read only then the method will fail.
+	StoreKey(k Key) error
+}
+
+// ReadPassword returns the passphrase of an encrypted private key. The
+// environment variable takes precedence over the secret file, nil is
+// returned when neither of them is set.
+func ReadPassword(env, file string) ([]byte, error) {
+	if env != "" {
+		if pwd, ok := os.LookupEnv(env); ok && pwd != "" {
+			return []byte(pwd), nil
+		}
+	}
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read password file %s", file)
+		}
+		return bytes.TrimRight(data, "\r\n"), nil
+	}
+	return nil, nil
+}
+
+// fileKeyStore holds the single private key read from a PEM file.
+type fileKeyStore struct {
+	key Key
+}
+
+// NewFileKeyStore loads the PEM encoded private key at keyPath, the
+// password is only needed for encrypted PKCS#8 keys.
+func NewFileKeyStore(keyPath string, password []byte) (KeyStore, error) {
+	raw, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParsePrivateKeyWithPassword(raw, password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse private key %s", keyPath)
+	}
+	return &fileKeyStore{key: key}, nil
+}
+
+func (ks *fileKeyStore) ReadOnly() bool {
+	return true
+}
+
+func (ks *fileKeyStore) GetKey(ski []byte) (Key, error) {
+	if len(ski) != 0 && !bytes.Equal(ski, ks.key.SKI()) {
+		return nil, errors.Errorf("key with SKI %x not found", ski)
+	}
+	return ks.key, nil
+}
+
+func (ks *fileKeyStore) StoreKey(k Key) error {
+	return errors.New("read only KeyStore")
+}
+
+// dirKeyStore keeps private keys in a directory, one PEM file per key
+// named after the hex encoded SKI, the same layout as the Fabric keystore.
+type dirKeyStore struct {
+	path     string
+	password []byte
+	readOnly bool
+
+	m sync.Mutex
+}
+
+// NewDirKeyStore opens the keystore at path, creating the directory when
+// the keystore is writable.
+func NewDirKeyStore(path string, password []byte, readOnly bool) (KeyStore, error) {
+	if path == "" {
+		return nil, errors.New("keystore path is empty")
+	}
+	if !readOnly {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create keystore directory %s", path)
+		}
+	} else if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrapf(err, "failed to open keystore directory %s", path)
+	}
+	return &dirKeyStore{path: path, password: password, readOnly: readOnly}, nil
+}
+
+func (ks *dirKeyStore) ReadOnly() bool {
+	return ks.readOnly
+}
+
+func (ks *dirKeyStore) GetKey(ski []byte) (Key, error) {
+	if len(ski) == 0 {
+		return nil, errors.New("invalid SKI. Cannot be of zero length")
+	}
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	raw, err := ioutil.ReadFile(ks.keyPath(hex.EncodeToString(ski)))
+	if err != nil {
+		// fall back to files that are not named after the SKI,
+		// e.g. keys copied from a CA enrollment
+		return ks.searchKey(ski, err)
+	}
+	key, err := ParsePrivateKeyWithPassword(raw, ks.password)
+	if err != nil {
+		return nil, err
+	}
+	// the file name alone does not prove which key the file holds
+	if !bytes.Equal(key.SKI(), ski) {
+		return nil, errors.Errorf("key file %s does not hold the key with SKI %x", ks.keyPath(hex.EncodeToString(ski)), ski)
+	}
+	return key, nil
+}
+
+func (ks *dirKeyStore) StoreKey(k Key) error {
+	if ks.readOnly {
+		return errors.New("read only KeyStore")
+	}
+	if k == nil || !k.Private() {
+		return errors.New("only private keys can be stored")
+	}
+	var raw interface{}
+	switch key := k.(type) {
+	case *ecdsaPrivateKey:
+		raw = key.privKey
+	case *sm2PrivateKey:
+		raw = key.privKey
+	default:
+		return errors.Errorf("key type %T not supported", k)
+	}
+	data, err := PrivateKeyToPEM(raw, ks.password)
+	if err != nil {
+		return err
+	}
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+	return ioutil.WriteFile(ks.keyPath(hex.EncodeToString(k.SKI())), data, 0600)
+}
+
+func (ks *dirKeyStore) keyPath(alias string) string {
+	return filepath.Join(ks.path, alias+"_sk")
+}
+
+func (ks *dirKeyStore) searchKey(ski []byte, cause error) (Key, error) {
+	files, err := ioutil.ReadDir(ks.path)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), "_sk") {
+			continue
+		}
+		raw, err := ioutil.ReadFile(filepath.Join(ks.path, f.Name()))
+		if err != nil {
+			continue
+		}
+		key, err := ParsePrivateKeyWithPassword(raw, ks.password)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(key.SKI(), ski) {
+			return key, nil
+		}
+	}
+	return nil, errors.Wrapf(cause, "key with SKI %x not found in %s", ski, ks.path)
+}
@@ -0,0 +1,217 @@
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fabric-creed/cryptogm/sm2"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateKeys(t *testing.T) map[string]interface{} {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	sm2Key, err := sm2.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	return map[string]interface{}{"ecdsa": ecdsaKey, "sm2": sm2Key}
+}
+
+func TestEncryptedPrivateKey(t *testing.T) {
+	msg := []byte("hello world")
+	for name, key := range generateKeys(t) {
+		t.Run(name, func(t *testing.T) {
+			data, err := PrivateKeyToPEM(key, []byte("passw0rd"))
+			assert.Nil(t, err)
+
+			_, err = ParsePrivateKey(data)
+			assert.NotNil(t, err)
+			_, err = ParsePrivateKeyWithPassword(data, []byte("wrong"))
+			assert.NotNil(t, err)
+
+			k, err := ParsePrivateKeyWithPassword(data, []byte("passw0rd"))
+			assert.Nil(t, err)
+			csp := &CSP{}
+			signature, err := csp.Sign(k, msg, nil)
+			assert.Nil(t, err)
+			valid, err := csp.Verify(k, signature, msg, nil)
+			assert.Nil(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
+
+func TestReadPassword(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "password")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("from-file\n"), 0600))
+
+	pwd, err := ReadPassword("", file)
+	assert.Nil(t, err)
+	assert.Equal(t, "from-file", string(pwd))
+
+	os.Setenv("HUB_TEST_KEY_PASSWORD", "from-env")
+	defer os.Unsetenv("HUB_TEST_KEY_PASSWORD")
+	pwd, err = ReadPassword("HUB_TEST_KEY_PASSWORD", file)
+	assert.Nil(t, err)
+	assert.Equal(t, "from-env", string(pwd))
+}
+
+func TestDirKeyStore(t *testing.T) {
+	dir := t.TempDir()
+	ks, err := NewDirKeyStore(dir, []byte("passw0rd"), false)
+	assert.Nil(t, err)
+
+	fks, err := NewFileKeyStore("./test/server.key", nil)
+	assert.Nil(t, err)
+	key, err := fks.GetKey(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, ks.StoreKey(key))
+
+	s, err := NewSimpleCSPWithOpts(KeyStoreOpts{
+		Type:     DirKeyStore,
+		Dir:      dir,
+		CertPath: "./test/server.crt",
+		Password: []byte("passw0rd"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, key.SKI(), s.PrivateKey.SKI())
+
+	signature, err := s.Sign([]byte("hello world"))
+	assert.Nil(t, err)
+	valid, err := s.Verify(signature, []byte("hello world"))
+	assert.Nil(t, err)
+	assert.True(t, valid)
+}
+
+func TestExternalKeyStore(t *testing.T) {
+	fks, err := NewFileKeyStore("./test/server.key", nil)
+	assert.Nil(t, err)
+	server, err := NewSignerServer(fks, nil)
+	assert.Nil(t, err)
+
+	address := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", address)
+	assert.Nil(t, err)
+	defer l.Close()
+	go server.Serve(l)
+
+	s, err := NewSimpleCSPWithOpts(KeyStoreOpts{
+		Type:          ExternalKeyStore,
+		SignerAddress: address,
+		CertPath:      "./test/server.crt",
+	})
+	assert.Nil(t, err)
+
+	signature, err := s.Sign([]byte("hello world"))
+	assert.Nil(t, err)
+	local, err := NewSimpleCSP("", "./test/server.crt")
+	assert.Nil(t, err)
+	valid, err := local.Verify(signature, []byte("hello world"))
+	assert.Nil(t, err)
+	assert.True(t, valid)
+}
+
+// fixedKeyStore returns its key whatever SKI is requested, like a
+// misconfigured signer process
+type fixedKeyStore struct {
+	key Key
+}
+
+func (ks *fixedKeyStore) ReadOnly() bool {
+	return true
+}
+
+func (ks *fixedKeyStore) GetKey(ski []byte) (Key, error) {
+	return ks.key, nil
+}
+
+func (ks *fixedKeyStore) StoreKey(k Key) error {
+	return nil
+}
+
+func TestKeyDoesNotMatchCertificate(t *testing.T) {
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	other := NewEcdsaPrivateKey(raw)
+	data, err := PrivateKeyToPEM(raw, nil)
+	assert.Nil(t, err)
+	keyPath := filepath.Join(t.TempDir(), "other.key")
+	assert.Nil(t, ioutil.WriteFile(keyPath, data, 0600))
+
+	_, err = NewSimpleCSPWithOpts(KeyStoreOpts{KeyPath: keyPath, CertPath: "./test/server.crt"})
+	assert.NotNil(t, err)
+	_, err = NewSimpleCSPWithOpts(KeyStoreOpts{KeyPath: keyPath})
+	assert.Nil(t, err)
+
+	// the dir keystore checks the key held by a file named after the SKI
+	cert, err := NewSimpleCSP("", "./test/server.crt")
+	assert.Nil(t, err)
+	dir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, hex.EncodeToString(cert.PublicKey.SKI())+"_sk"), data, 0600))
+	_, err = NewSimpleCSPWithOpts(KeyStoreOpts{Type: DirKeyStore, Dir: dir, CertPath: "./test/server.crt"})
+	assert.NotNil(t, err)
+
+	// the external keystore checks the key returned for the requested SKI
+	server, err := NewSignerServer(&fixedKeyStore{key: other}, nil)
+	assert.Nil(t, err)
+	address := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", address)
+	assert.Nil(t, err)
+	defer l.Close()
+	go server.Serve(l)
+	_, err = NewSimpleCSPWithOpts(KeyStoreOpts{Type: ExternalKeyStore, SignerAddress: address, CertPath: "./test/server.crt"})
+	assert.NotNil(t, err)
+	ks, err := NewExternalKeyStore(address)
+	assert.Nil(t, err)
+	_, err = ks.GetKey(cert.PublicKey.SKI())
+	assert.NotNil(t, err)
+	key, err := ks.GetKey(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, other.SKI(), key.SKI())
+}
+
+func TestPBKDF2IterationLimit(t *testing.T) {
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	der, err := MarshalPKCS8PrivateKey(raw)
+	assert.Nil(t, err)
+	encrypted, err := EncryptPKCS8PrivateKey(der, []byte("passw0rd"), false)
+	assert.Nil(t, err)
+
+	// rewrite the iteration count and key length read from the file
+	rewrite := func(iterations, keyLength int) []byte {
+		var info encryptedPrivateKeyInfo
+		_, err := asn1.Unmarshal(encrypted, &info)
+		assert.Nil(t, err)
+		var params pbes2Params
+		_, err = asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params)
+		assert.Nil(t, err)
+		var kdf pbkdf2Params
+		_, err = asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf)
+		assert.Nil(t, err)
+		kdf.IterationCount, kdf.KeyLength = iterations, keyLength
+		params.KeyDerivationFunc.Parameters.FullBytes, err = asn1.Marshal(kdf)
+		assert.Nil(t, err)
+		info.Algo.Parameters.FullBytes, err = asn1.Marshal(params)
+		assert.Nil(t, err)
+		data, err := asn1.Marshal(info)
+		assert.Nil(t, err)
+		return data
+	}
+
+	_, err = DecryptPKCS8PrivateKey(encrypted, []byte("passw0rd"))
+	assert.Nil(t, err)
+	_, err = DecryptPKCS8PrivateKey(rewrite(maxPBKDF2Iterations+1, 32), []byte("passw0rd"))
+	assert.NotNil(t, err)
+	_, err = DecryptPKCS8PrivateKey(rewrite(0, 32), []byte("passw0rd"))
+	assert.NotNil(t, err)
+	_, err = DecryptPKCS8PrivateKey(rewrite(pbkdf2Iterations, 1<<30), []byte("passw0rd"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,299 @@
+package sw
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"hash"
+
+	"github.com/fabric-creed/cryptogm/sm2"
+	"github.com/fabric-creed/cryptogm/sm3"
+	"github.com/fabric-creed/cryptogm/sm4"
+	"github.com/fabric-creed/cryptogm/x509"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	pemTypePrivateKey          = "PRIVATE KEY"
+	pemTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+
+	pbkdf2Iterations = 10000
+	pbkdf2SaltSize   = 16
+	// maxPBKDF2Iterations bounds the work spent on a key file, the count
+	// is read from the file before the password is checked
+	maxPBKDF2Iterations = 1000000
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSM3    = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 401, 2}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidSM4CBC    = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 104, 2}
+
+	oidPublicKeyEC       = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveP256SM2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+// encryptedPrivateKeyInfo reflects an ASN.1 EncryptedPrivateKeyInfo, see RFC 5208.
+type encryptedPrivateKeyInfo struct {
+	Algo          pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params reflects the PBES2-params structure, see RFC 8018.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params reflects the PBKDF2-params structure, see RFC 8018.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// pkcs8 reflects an ASN.1, PKCS#8 PrivateKey, see RFC 5208.
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type pbeCipher struct {
+	oid     asn1.ObjectIdentifier
+	keySize int
+	block   func(key []byte) (cipher.Block, error)
+}
+
+var pbeCiphers = []pbeCipher{
+	{oid: oidAES128CBC, keySize: 16, block: aes.NewCipher},
+	{oid: oidAES256CBC, keySize: 32, block: aes.NewCipher},
+	{oid: oidSM4CBC, keySize: 16, block: sm4.NewCipher},
+}
+
+func pbeCipherByOID(oid asn1.ObjectIdentifier) (*pbeCipher, error) {
+	for i := range pbeCiphers {
+		if pbeCiphers[i].oid.Equal(oid) {
+			return &pbeCiphers[i], nil
+		}
+	}
+	return nil, errors.Errorf("unsupported encryption scheme %s", oid)
+}
+
+func prfByOID(oid asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(oid) == 0, oid.Equal(oidHMACWithSHA1):
+		return sha1.New, nil
+	case oid.Equal(oidHMACWithSHA256):
+		return sha256.New, nil
+	case oid.Equal(oidHMACWithSM3):
+		return sm3.New, nil
+	default:
+		return nil, errors.Errorf("unsupported pseudo random function %s", oid)
+	}
+}
+
+// IsEncryptedPrivateKey returns true if the PEM block holds a
+// PKCS#8 EncryptedPrivateKeyInfo.
+func IsEncryptedPrivateKey(block *pem.Block) bool {
+	return block != nil && block.Type == pemTypeEncryptedPrivateKey
+}
+
+// DecryptPKCS8PrivateKey decrypts a PBES2 encrypted PKCS#8 structure and
+// returns the plain PKCS#8 DER bytes.
+func DecryptPKCS8PrivateKey(der, password []byte) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, errors.New("password is required to decrypt the private key")
+	}
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal encrypted private key info")
+	}
+	if !info.Algo.Algorithm.Equal(oidPBES2) {
+		return nil, errors.Errorf("unsupported encryption algorithm %s, only PBES2 is supported", info.Algo.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal PBES2 parameters")
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, errors.Errorf("unsupported key derivation function %s", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal PBKDF2 parameters")
+	}
+	if kdf.IterationCount <= 0 || kdf.IterationCount > maxPBKDF2Iterations {
+		return nil, errors.Errorf("PBKDF2 iteration count %d is out of range [1, %d]", kdf.IterationCount, maxPBKDF2Iterations)
+	}
+	prf, err := prfByOID(kdf.PRF.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	c, err := pbeCipherByOID(params.EncryptionScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal cipher iv")
+	}
+	if kdf.KeyLength != 0 && kdf.KeyLength != c.keySize {
+		return nil, errors.Errorf("PBKDF2 key length %d does not match the cipher key size %d", kdf.KeyLength, c.keySize)
+	}
+	block, err := c.block(pbkdf2.Key(password, kdf.Salt, kdf.IterationCount, c.keySize, prf))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData)%block.BlockSize() != 0 || len(info.EncryptedData) == 0 {
+		return nil, errors.New("invalid encrypted private key")
+	}
+	plain := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, info.EncryptedData)
+
+	// remove PKCS#7 padding, a wrong password almost always ends up here
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > block.BlockSize() || !bytes.Equal(plain[len(plain)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, errors.New("failed to decrypt private key, the password may be incorrect")
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// EncryptPKCS8PrivateKey encrypts the plain PKCS#8 DER bytes with PBES2.
+// SM2 keys use PBKDF2-HMAC-SM3 with SM4-CBC, other keys use
+// PBKDF2-HMAC-SHA256 with AES-256-CBC.
+func EncryptPKCS8PrivateKey(der, password []byte, isGM bool) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, errors.New("password is required to encrypt the private key")
+	}
+	prfOID, cipherOID, prf := oidHMACWithSHA256, oidAES256CBC, sha256.New
+	if isGM {
+		prfOID, cipherOID, prf = oidHMACWithSM3, oidSM4CBC, sm3.New
+	}
+	c, err := pbeCipherByOID(cipherOID)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, pbkdf2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	block, err := c.block(pbkdf2.Key(password, salt, pbkdf2Iterations, c.keySize, prf))
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, block.BlockSize())
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	pad := block.BlockSize() - len(der)%block.BlockSize()
+	plain := append(append([]byte{}, der...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	encrypted := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, plain)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		KeyLength:      c.keySize,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: prfOID, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: cipherOID, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algo:          pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: encrypted,
+	})
+}
+
+// MarshalPKCS8PrivateKey converts an ECDSA or SM2 private key to PKCS#8 form.
+func MarshalPKCS8PrivateKey(key interface{}) ([]byte, error) {
+	var curveOID asn1.ObjectIdentifier
+	switch k := key.(type) {
+	case *sm2.PrivateKey:
+		curveOID = oidNamedCurveP256SM2
+	case *ecdsa.PrivateKey:
+		oid, ok := namedCurveOID(k)
+		if !ok {
+			return nil, errors.New("unknown elliptic curve")
+		}
+		curveOID = oid
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", key)
+	}
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal EC private key")
+	}
+	params, err := asn1.Marshal(curveOID)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyEC,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PrivateKey: sec1,
+	})
+}
+
+// PrivateKeyToPEM encodes the private key as PKCS#8 PEM, encrypted when a
+// password is given.
+func PrivateKeyToPEM(key interface{}, password []byte) ([]byte, error) {
+	der, err := MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+	}
+	_, isGM := key.(*sm2.PrivateKey)
+	encrypted, err := EncryptPKCS8PrivateKey(der, password, isGM)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPrivateKey, Bytes: encrypted}), nil
+}
+
+func namedCurveOID(k *ecdsa.PrivateKey) (asn1.ObjectIdentifier, bool) {
+	switch k.Curve.Params().Name {
+	case "P-224":
+		return asn1.ObjectIdentifier{1, 3, 132, 0, 33}, true
+	case "P-256":
+		return asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}, true
+	case "P-384":
+		return asn1.ObjectIdentifier{1, 3, 132, 0, 34}, true
+	case "P-521":
+		return asn1.ObjectIdentifier{1, 3, 132, 0, 35}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,220 @@
+package sw
+
+import (
+	"bytes"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	signerServiceName = "Signer"
+
+	defaultSignerTimeout = 10 * time.Second
+)
+
+// SignerRequest is sent to an external signer process.
+type SignerRequest struct {
+	// SKI of the key to use, empty selects the signer's default key
+	SKI []byte `json:"ski"`
+	// Digest to sign, empty for public key requests
+	Digest []byte `json:"digest,omitempty"`
+}
+
+// SignerResponse is returned by an external signer process.
+type SignerResponse struct {
+	SKI []byte `json:"ski"`
+	// PublicKey is the DER encoded PKIX public key
+	PublicKey []byte `json:"publicKey,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// externalKeyStore resolves keys held by a signer process listening on a
+// local socket. The process speaks JSON-RPC 1.0, exposing the methods
+// Signer.PublicKey and Signer.Sign, so it can be written in any language.
+type externalKeyStore struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewExternalKeyStore returns a KeyStore backed by the signer process at
+// address, either a unix socket path or network://address.
+func NewExternalKeyStore(address string) (KeyStore, error) {
+	if address == "" {
+		return nil, errors.New("signer address is empty")
+	}
+	network := "unix"
+	if i := strings.Index(address, "://"); i > 0 {
+		network, address = address[:i], address[i+3:]
+	}
+	return &externalKeyStore{
+		network: network,
+		address: address,
+		timeout: defaultSignerTimeout,
+	}, nil
+}
+
+func (ks *externalKeyStore) ReadOnly() bool {
+	return true
+}
+
+func (ks *externalKeyStore) GetKey(ski []byte) (Key, error) {
+	var resp SignerResponse
+	if err := ks.call("PublicKey", &SignerRequest{SKI: ski}, &resp); err != nil {
+		return nil, err
+	}
+	pub, err := ParsePublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key returned by signer")
+	}
+	if len(ski) != 0 && !bytes.Equal(pub.SKI(), ski) {
+		return nil, errors.Errorf("signer returned the key with SKI %x instead of %x", pub.SKI(), ski)
+	}
+	return &externalKey{ski: pub.SKI(), pub: pub, ks: ks}, nil
+}
+
+func (ks *externalKeyStore) StoreKey(k Key) error {
+	return errors.New("read only KeyStore")
+}
+
+func (ks *externalKeyStore) call(method string, req *SignerRequest, resp *SignerResponse) error {
+	conn, err := net.DialTimeout(ks.network, ks.address, ks.timeout)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to signer %s", ks.address)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(ks.timeout)); err != nil {
+		return err
+	}
+	client := jsonrpc.NewClient(conn)
+	defer client.Close()
+	if err := client.Call(signerServiceName+"."+method, req, resp); err != nil {
+		return errors.Wrapf(err, "signer call %s failed", method)
+	}
+	return nil
+}
+
+// externalKey is a private key that never leaves the signer process.
+type externalKey struct {
+	ski []byte
+	pub Key
+	ks  *externalKeyStore
+}
+
+func (k *externalKey) sign(digest []byte) ([]byte, error) {
+	var resp SignerResponse
+	if err := k.ks.call("Sign", &SignerRequest{SKI: k.ski, Digest: digest}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *externalKey) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *externalKey) SKI() []byte {
+	return k.ski
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *externalKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *externalKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+func (k *externalKey) PublicKey() (Key, error) {
+	return k.pub, nil
+}
+
+// SignerServer exposes the keys of a KeyStore with the external signer
+// protocol. It is a reference signer and a stand-in for tests.
+type SignerServer struct {
+	server *rpc.Server
+}
+
+// NewSignerServer returns a SignerServer signing with ks, defaultSKI is
+// used for requests that do not name a key.
+func NewSignerServer(ks KeyStore, defaultSKI []byte) (*SignerServer, error) {
+	server := rpc.NewServer()
+	err := server.RegisterName(signerServiceName, &signerService{
+		csp:        &CSP{},
+		ks:         ks,
+		defaultSKI: defaultSKI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SignerServer{server: server}, nil
+}
+
+// Serve accepts connections on l until it is closed.
+func (s *SignerServer) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+type signerService struct {
+	csp        *CSP
+	ks         KeyStore
+	defaultSKI []byte
+}
+
+func (s *signerService) key(ski []byte) (Key, error) {
+	if len(ski) == 0 {
+		ski = s.defaultSKI
+	}
+	return s.ks.GetKey(ski)
+}
+
+func (s *signerService) PublicKey(req *SignerRequest, resp *SignerResponse) error {
+	k, err := s.key(req.SKI)
+	if err != nil {
+		return err
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		return err
+	}
+	raw, err := pub.Bytes()
+	if err != nil {
+		return err
+	}
+	resp.SKI = k.SKI()
+	resp.PublicKey = raw
+	return nil
+}
+
+func (s *signerService) Sign(req *SignerRequest, resp *SignerResponse) error {
+	k, err := s.key(req.SKI)
+	if err != nil {
+		return err
+	}
+	signature, err := s.csp.Sign(k, req.Digest, nil)
+	if err != nil {
+		return err
+	}
+	resp.SKI = k.SKI()
+	resp.Signature = signature
+	return nil
+}
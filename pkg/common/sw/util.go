@@ -9,13 +9,28 @@ import (
 )
 
 func ParsePrivateKey(keyPEMBlock []byte) (Key, error) {
+	return ParsePrivateKeyWithPassword(keyPEMBlock, nil)
+}
+
+// ParsePrivateKeyWithPassword parses a PEM encoded private key, the password
+// is used when the key is a PKCS#8 EncryptedPrivateKeyInfo.
+func ParsePrivateKeyWithPassword(keyPEMBlock, password []byte) (Key, error) {
 	var keyDERBlock *pem.Block
 	keyDERBlock, keyPEMBlock = pem.Decode(keyPEMBlock)
 	if keyDERBlock == nil {
 		return nil, errors.New(" failed to find any PEM data in key input")
 	}
 
-	if key, err := x509.ParsePKCS8PrivateKey(keyDERBlock.Bytes); err == nil {
+	der := keyDERBlock.Bytes
+	if IsEncryptedPrivateKey(keyDERBlock) {
+		plain, err := DecryptPKCS8PrivateKey(der, password)
+		if err != nil {
+			return nil, err
+		}
+		der = plain
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key := key.(type) {
 		case *ecdsa.PrivateKey:
 			return NewEcdsaPrivateKey(key), nil
@@ -26,7 +41,7 @@ func ParsePrivateKey(keyPEMBlock []byte) (Key, error) {
 		}
 	}
 
-	if key, err := x509.ParseECPrivateKey(keyDERBlock.Bytes); err == nil {
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
 		switch key := key.(type) {
 		case *ecdsa.PrivateKey:
 			return NewEcdsaPrivateKey(key), nil
@@ -42,11 +57,27 @@ func ParsePrivateKey(keyPEMBlock []byte) (Key, error) {
 
 func ParsePublicByCertificate(data []byte) (Key, error) {
 	pemBlock, _ := pem.Decode(data)
+	if pemBlock == nil {
+		return nil, errors.New("failed to find any PEM data in certificate input")
+	}
 	cert, err := x509.ParseCertificate(pemBlock.Bytes)
 	if err != nil {
 		return nil, err
 	}
-	switch key := cert.PublicKey.(type) {
+	return publicKeyToKey(cert.PublicKey)
+}
+
+// ParsePublicKey parses a DER encoded PKIX public key.
+func ParsePublicKey(der []byte) (Key, error) {
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return publicKeyToKey(pub)
+}
+
+func publicKeyToKey(pub interface{}) (Key, error) {
+	switch key := pub.(type) {
 	case *ecdsa.PublicKey:
 		return NewEcdsaPublicKey(key), nil
 	case *sm2.PublicKey:
@@ -0,0 +1,16 @@
+package database
+
+import "time"
+
+// Cursor 本地通道的区块处理进度
+type Cursor struct {
+	// 本地通道ID
+	ChannelID string `storm:"id" json:"channelID"`
+	// 下一个处理的区块
+	NextBlock uint64 `json:"nextBlock"`
+	// 最近处理的区块的检查点, 通过管理接口设置下一个处理的区块后为空
+	BlockNumber  uint64    `json:"blockNumber"`
+	BlockHash    string    `json:"blockHash"`
+	PreviousHash string    `json:"previousHash"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,36 @@
+package database
+
+import "time"
+
+// DeadLetter 重试次数用尽或不可重试的跨链请求
+type DeadLetter struct {
+	PrimaryID int64 `storm:"id,increment" json:"primaryID"`
+	// 本地通道ID
+	ChannelID string `storm:"index" json:"channelID"`
+	// 来源链通道ID
+	From string `json:"from"`
+	// 目的链通道ID
+	To            string `json:"to"`
+	TransactionID string `storm:"index" json:"transactionID"`
+	StepID        string `json:"stepID"`
+	// 发起跨链请求的交易hash
+	TransactionHash string `storm:"index" json:"transactionHash"`
+	// 区块编号
+	BlockNumber uint64 `json:"blockNumber"`
+	// 区块hash
+	BlockHash string `json:"blockHash"`
+	// 源信息
+	OriginInfo []byte `json:"originInfo"`
+	// 序列化的跨链请求
+	Request []byte `json:"request"`
+	// 序列化的响应, 请求成功但回调失败时不为空
+	Response []byte `json:"response"`
+	// 累计尝试次数
+	Attempts int `json:"attempts"`
+	// 每次尝试的错误信息
+	Errors []string `json:"errors"`
+	// 是否为不可重试的错误
+	Permanent bool      `json:"permanent"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,18 @@
+package database
+
+// BlockHeader 远端通道经核实的区块头
+type BlockHeader struct {
+	PrimaryID int64 `storm:"id,increment" json:"primaryID"`
+	// 远端通道ID
+	ChannelID string `storm:"index" json:"channelID"`
+	// 区块编号
+	BlockNumber uint64 `storm:"index" json:"blockNumber"`
+	// 前驱哈希
+	PreviousHash string `json:"previousHash"`
+	// 数据哈希
+	DataHash string `json:"dataHash"`
+	// 区块哈希
+	BlockHash string `storm:"index" json:"blockHash"`
+	// 区块元数据 TRANSACTIONS_FILTER, 不受区块哈希与排序节点签名保护, 其可信度等同于区块头的同步来源
+	TransactionsFilter []byte `json:"transactionsFilter"`
+}
@@ -0,0 +1,45 @@
+package database
+
+import "time"
+
+// InboundState 远端网关跨链请求在本链的执行状态
+type InboundState string
+
+const (
+	// 正在执行, 执行结果未知时保持该状态
+	InboundExecuting InboundState = "executing"
+	// 合约已执行并保存执行结果, 签名响应尚未保存
+	InboundCommitted InboundState = "committed"
+	// 已执行并保存签名响应
+	InboundExecuted InboundState = "executed"
+	// 经管理接口核实交易未生效, 重复请求重新执行
+	InboundAborted InboundState = "aborted"
+)
+
+// InboundExecution 远端网关跨链请求的执行记录, 用于重复请求的幂等处理.
+// 记录保存在各网关节点本地, 不在同一链的多个网关节点间共享
+type InboundExecution struct {
+	PrimaryID int64 `storm:"id,increment" json:"primaryID"`
+	// 来源链通道ID、交易ID与步骤ID组成的唯一键
+	Key string `storm:"unique" json:"key"`
+	// 来源链通道ID
+	From string `json:"from"`
+	// 目的链通道ID
+	To            string `json:"to"`
+	TransactionID string `json:"transactionID"`
+	StepID        string `json:"stepID"`
+	// 请求 payload 的 sha256 哈希
+	PayloadHash string `json:"payloadHash"`
+	// 请求 payload, 执行结果未知时用于核对链上交易的调用参数
+	Payload []byte `json:"payload"`
+	// 当前状态
+	State InboundState `json:"state"`
+	// 合约执行结果, 签名响应保存前用于重新构造响应
+	Result []byte `json:"result"`
+	// 序列化的背书证明
+	Proof []byte `json:"proof"`
+	// 序列化的签名响应
+	Response  []byte    `json:"response"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
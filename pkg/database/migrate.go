@@ -0,0 +1,74 @@
+package database
+
+import (
+	"reflect"
+
+	"github.com/asdine/storm/v3"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// legacyChannelIndex storm 按重命名前的字段名建立的索引
+const legacyChannelIndex = "__storm_index_ChannelName"
+
+// legacyChannelRecord Cursor、Outbox 与 DeadLetter 中的本地通道ID字段重命名为 ChannelID 前后的字段,
+// gob 按字段名解码, 旧记录的 ChannelName 解码到新结构时被忽略. 没有任何字段匹配时 gob 解码失败,
+// 因此同时包含新字段
+type legacyChannelRecord struct {
+	ChannelName string
+	ChannelID   string
+}
+
+// MigrateChannelID 将重命名前保存的记录的 ChannelName 写入 ChannelID 并重建索引, 返回迁移的记录数.
+// model 为 Cursor、Outbox 或 DeadLetter 的指针, 已迁移的记录不做修改
+func MigrateChannelID(db *storm.DB, model interface{}) (int, error) {
+	typ := reflect.TypeOf(model)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return 0, storm.ErrStructPtrNeeded
+	}
+	typ = typ.Elem()
+	if _, ok := typ.FieldByName("ChannelID"); !ok {
+		return 0, errors.Errorf("%s has no channel id", typ.Name())
+	}
+
+	// 读事务中不能写入, 先收集需要迁移的记录
+	var records []reflect.Value
+	err := db.Select().Bucket(typ.Name()).RawEach(func(k, v []byte) error {
+		var legacy legacyChannelRecord
+		if err := db.Codec().Unmarshal(v, &legacy); err != nil {
+			return errors.Wrapf(err, "failed to decode %s %x", typ.Name(), k)
+		}
+		if legacy.ChannelName == "" {
+			return nil
+		}
+		record := reflect.New(typ)
+		if err := db.Codec().Unmarshal(v, record.Interface()); err != nil {
+			return errors.Wrapf(err, "failed to decode %s %x", typ.Name(), k)
+		}
+		record.Elem().FieldByName("ChannelID").SetString(legacy.ChannelName)
+		records = append(records, record)
+		return nil
+	})
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+
+	for _, record := range records {
+		if err = db.Save(record.Interface()); err != nil {
+			return 0, errors.Wrapf(err, "failed to save %s", typ.Name())
+		}
+	}
+	// 保存时已按 ChannelID 建立索引, 删除按旧字段名建立的索引. storm 的 ReIndex 复用同一结构
+	// 解码各记录, gob 不写入零值字段, 会把前一条记录的字段带入后一条记录, 不能使用
+	err = db.Bolt.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(typ.Name()))
+		if bucket == nil || bucket.Bucket([]byte(legacyChannelIndex)) == nil {
+			return nil
+		}
+		return bucket.DeleteBucket([]byte(legacyChannelIndex))
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete the legacy index of %s", typ.Name())
+	}
+	return len(records), nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import "time"
+
+// OutboxState 跨链请求的处理状态
+type OutboxState string
+
+const (
+	// 已从区块中解析出请求
+	OutboxDetected OutboxState = "detected"
+	// 已向远端网关发送请求, 尚未收到响应
+	OutboxSent OutboxState = "sent"
+	// 已收到并保存远端网关的签名响应
+	OutboxResponded OutboxState = "responded"
+	// 已将响应写入路由合约
+	OutboxResultWritten OutboxState = "result-written"
+	// 业务回调已完成, 处理结束
+	OutboxCallbackDone OutboxState = "callback-done"
+	// 处理失败, 已进入死信队列或远端返回错误
+	OutboxFailed OutboxState = "failed"
+)
+
+// Outbox 跨链请求的持久化处理记录, 重启后从最后的状态继续处理
+type Outbox struct {
+	PrimaryID int64 `storm:"id,increment" json:"primaryID"`
+	// 本地通道ID、交易ID与步骤ID组成的唯一键
+	Key string `storm:"unique" json:"key"`
+	// 本地通道ID
+	ChannelID string `storm:"index" json:"channelID"`
+	// 来源链通道ID
+	From string `json:"from"`
+	// 目的链通道ID
+	To            string `json:"to"`
+	TransactionID string `storm:"index" json:"transactionID"`
+	StepID        string `json:"stepID"`
+	// 发起跨链请求的交易hash
+	TransactionHash string `json:"transactionHash"`
+	// 区块编号
+	BlockNumber uint64 `json:"blockNumber"`
+	// 当前状态
+	State OutboxState `storm:"index" json:"state"`
+	// 序列化的跨链请求
+	Request []byte `json:"request"`
+	// 序列化的远端网关签名响应
+	Response []byte `json:"response"`
+	// 失败时的错误信息
+	Error string `json:"error"`
+	// 各状态的进入时间, 未进入时为零值
+	DetectedAt      time.Time `json:"detectedAt"`
+	SentAt          time.Time `json:"sentAt"`
+	RespondedAt     time.Time `json:"respondedAt"`
+	ResultWrittenAt time.Time `json:"resultWrittenAt"`
+	CallbackDoneAt  time.Time `json:"callbackDoneAt"`
+	FailedAt        time.Time `json:"failedAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
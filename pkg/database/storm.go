@@ -0,0 +1,94 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/codec/gob"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize 压缩时每个事务写入的最大字节数
+const compactTxMaxSize = 64 << 20
+
+var (
+	lock sync.Mutex
+	// 已打开的数据库, 按文件路径索引. 同一文件在进程内只打开一次, 不同路径互不影响
+	instantDBs = make(map[string]*storm.DB)
+)
+
+// OpenDB 打开 dbPath 目录下的数据库文件并初始化 model 的索引, 打开失败时 panic
+func OpenDB(dbPath, name string, model interface{}) *storm.DB {
+	path, err := filepath.Abs(filepath.Join(dbPath, name))
+	if err != nil {
+		panic(err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if db, ok := instantDBs[path]; ok {
+		return db
+	}
+	db, err := storm.Open(path, storm.Codec(gob.Codec))
+	if err != nil {
+		panic(err)
+	}
+	if err = db.Init(model); err != nil {
+		panic(err)
+	}
+	instantDBs[path] = db
+	return db
+}
+
+// CompactDB 将 dbPath 目录下的数据库文件复制到新文件以回收已删除数据的空间, 需在进程打开该文件前调用
+func CompactDB(dbPath, name string) error {
+	path, err := filepath.Abs(filepath.Join(dbPath, name))
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := instantDBs[path]; ok {
+		return errors.Errorf("%s is in use", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	src, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer src.Close()
+	// 上次压缩中断时留下的临时文件可能不完整
+	tmpPath := path + ".compact"
+	if err = os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", tmpPath)
+	}
+	dst, err := bolt.Open(tmpPath, info.Mode(), &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", tmpPath)
+	}
+	if err = bolt.Compact(dst, src, compactTxMaxSize); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return errors.Wrapf(err, "failed to compact %s", path)
+	}
+	if err = dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err = src.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
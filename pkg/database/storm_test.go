@@ -0,0 +1,105 @@
+package database
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/codec/gob"
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestCompactDB(t *testing.T) {
+	dbPath := t.TempDir()
+	path := filepath.Join(dbPath, "block.db")
+	db, err := storm.Open(path, storm.Codec(gob.Codec))
+	assert.Nil(t, err)
+	assert.Nil(t, db.Init(new(Block)))
+	for i := 1; i <= 200; i++ {
+		assert.Nil(t, db.Save(&Block{BlockNumber: uint64(i), OriginInfo: make([]byte, 4096)}))
+	}
+	var blocks []Block
+	assert.Nil(t, db.All(&blocks))
+	for i := range blocks[:199] {
+		assert.Nil(t, db.DeleteStruct(&blocks[i]))
+	}
+	assert.Nil(t, db.Close())
+	before, err := os.Stat(path)
+	assert.Nil(t, err)
+
+	// 上次中断留下的临时文件不影响压缩
+	assert.Nil(t, ioutil.WriteFile(path+".compact", []byte("stale"), 0600))
+	assert.Nil(t, CompactDB(dbPath, "block.db"))
+	after, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Less(t, after.Size(), before.Size())
+	_, err = os.Stat(path + ".compact")
+	assert.True(t, os.IsNotExist(err))
+
+	// 压缩后数据不变, 已打开的文件不能压缩
+	db = OpenDB(dbPath, "block.db", new(Block))
+	var block Block
+	assert.Nil(t, db.One("BlockNumber", uint64(200), &block))
+	assert.NotNil(t, CompactDB(dbPath, "block.db"))
+
+	// 文件不存在时忽略
+	assert.Nil(t, CompactDB(dbPath, "missing.db"))
+}
+
+func TestMigrateChannelID(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "legacy.db"), storm.Codec(gob.Codec))
+	assert.Nil(t, err)
+	defer db.Close()
+	assert.Nil(t, db.Init(new(DeadLetter)))
+	assert.Nil(t, db.Init(new(Cursor)))
+
+	// 重命名前保存的记录
+	type legacyDeadLetter struct {
+		PrimaryID     int64
+		ChannelName   string
+		TransactionID string
+	}
+	type legacyCursor struct {
+		ChannelName string
+		NextBlock   uint64
+	}
+	assert.Nil(t, db.Set("DeadLetter", int64(1), &legacyDeadLetter{PrimaryID: 1, ChannelName: "local", TransactionID: "tx"}))
+	assert.Nil(t, db.Set("Cursor", "local", &legacyCursor{ChannelName: "local", NextBlock: 5}))
+	assert.Nil(t, db.Bolt.Update(func(tx *bolt.Tx) error {
+		_, err := tx.Bucket([]byte("DeadLetter")).CreateBucket([]byte(legacyChannelIndex))
+		return err
+	}))
+	assert.Nil(t, db.Save(&DeadLetter{PrimaryID: 2, ChannelID: "other", TransactionID: "tx2"}))
+
+	n, err := MigrateChannelID(db, new(DeadLetter))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	var letters []DeadLetter
+	assert.Nil(t, db.Find("ChannelID", "local", &letters))
+	assert.Len(t, letters, 1)
+	assert.Equal(t, "tx", letters[0].TransactionID)
+	assert.Nil(t, db.Find("ChannelID", "other", &letters))
+	assert.Len(t, letters, 1)
+	assert.Nil(t, db.Bolt.View(func(tx *bolt.Tx) error {
+		assert.Nil(t, tx.Bucket([]byte("DeadLetter")).Bucket([]byte(legacyChannelIndex)))
+		return nil
+	}))
+
+	n, err = MigrateChannelID(db, new(Cursor))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	var cursor Cursor
+	assert.Nil(t, db.One("ChannelID", "local", &cursor))
+	assert.Equal(t, "local", cursor.ChannelID)
+	assert.Equal(t, uint64(5), cursor.NextBlock)
+
+	// 已迁移的记录不做修改
+	n, err = MigrateChannelID(db, new(DeadLetter))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	_, err = MigrateChannelID(db, new(Block))
+	assert.NotNil(t, err)
+}
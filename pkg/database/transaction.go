@@ -8,7 +8,8 @@ type Transaction struct {
 	BlockHash string `json:"blockHash"`
 	// 交易hash
 	TransactionHash string `storm:"unique" json:"transactionHash"`
-	// 源信息
+	// 源信息, 跨链交易的原始 Envelope
 	OriginInfo []byte `json:"originInfo"`
-	//
+	// 交易验证结果, 非 VALID 的交易未被转发, 只记录以便排查
+	ValidationCode int32 `json:"validationCode"`
 }
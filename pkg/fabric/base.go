@@ -9,6 +9,7 @@ import (
 	"github.com/fabric-creed/fabric-sdk-go/pkg/fabsdk"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"sync"
 )
 
 type Client struct {
@@ -20,6 +21,8 @@ type Client struct {
 	fabricSDK      *fabsdk.FabricSDK
 	ledgerManager  map[string]*Ledger
 	channelManager map[string]*Channel
+	// 通道及账本客户端按需创建, 可被并发访问
+	lock sync.Mutex
 }
 
 func NewClient(opts ...Option) (*Client, error) {
@@ -87,6 +90,8 @@ func (c *Client) Close() {
 }
 
 func (c *Client) Channel(channelID string) (*Channel, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	if _, ok := c.channelManager[channelID]; !ok {
 		channelProvider := c.fabricSDK.ChannelContext(
 			channelID,
@@ -104,6 +109,8 @@ func (c *Client) Channel(channelID string) (*Channel, error) {
 }
 
 func (c *Client) Ledger(channelID string, isGM bool) (*Ledger, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	if _, ok := c.ledgerManager[channelID]; !ok {
 		channelProvider := c.fabricSDK.ChannelContext(
 			channelID,
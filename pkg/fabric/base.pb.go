@@ -161,6 +161,18 @@ func DecodeBlockMetadata(in *common.BlockMetadata) (*BlockMetadata, error) {
 	return out, nil
 }
 
+// TxValidationCode 返回 TRANSACTIONS_FILTER 中第 index 个交易的验证结果, 缺少验证结果时返回 NOT_VALIDATED
+func (m *BlockMetadata) TxValidationCode(index int) peer.TxValidationCode {
+	if m == nil || len(m.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return peer.TxValidationCode_NOT_VALIDATED
+	}
+	filter := m.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	if index < 0 || index >= len(filter) {
+		return peer.TxValidationCode_NOT_VALIDATED
+	}
+	return peer.TxValidationCode(filter[index])
+}
+
 type ProcessedTransaction struct {
 	TransactionEnvelope *Envelope `json:"transaction_envelope,omitempty"`
 	ValidationCode      int32     `json:"validation_code,omitempty"`
@@ -0,0 +1,21 @@
+package fabric
+
+import (
+	"testing"
+
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxValidationCode(t *testing.T) {
+	metadata := &BlockMetadata{Metadata: [][]byte{
+		{}, {}, {byte(peer.TxValidationCode_VALID), byte(peer.TxValidationCode_MVCC_READ_CONFLICT)},
+	}}
+	assert.Equal(t, peer.TxValidationCode_VALID, metadata.TxValidationCode(0))
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, metadata.TxValidationCode(1))
+	// 缺少验证结果时视为未验证
+	assert.Equal(t, peer.TxValidationCode_NOT_VALIDATED, metadata.TxValidationCode(2))
+	assert.Equal(t, peer.TxValidationCode_NOT_VALIDATED, (&BlockMetadata{}).TxValidationCode(0))
+	var missing *BlockMetadata
+	assert.Equal(t, peer.TxValidationCode_NOT_VALIDATED, missing.TxValidationCode(0))
+}
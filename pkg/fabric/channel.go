@@ -1,7 +1,11 @@
 package fabric
 
 import (
+	"context"
+	"time"
+
 	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/common/providers/fab"
 )
 
 type Channel struct {
@@ -9,15 +13,30 @@ type Channel struct {
 }
 
 func (c *Channel) ChannelExecute(
+	ctx context.Context,
 	request channel.Request,
 	options ...channel.RequestOption,
 ) (channel.Response, error) {
-	return c.client.Execute(request, options...)
+	return c.client.Execute(request, append(requestOptions(ctx, fab.Execute), options...)...)
 }
 
 func (c *Channel) ChannelQuery(
+	ctx context.Context,
 	request channel.Request,
 	options ...channel.RequestOption,
 ) (channel.Response, error) {
-	return c.client.Query(request, options...)
+	return c.client.Query(request, append(requestOptions(ctx, fab.Query), options...)...)
+}
+
+// requestOptions 将调用方的上下文作为 Fabric SDK 请求的父上下文,
+// 存在截止时间时映射为对应操作的超时, 调用方放弃请求后不再占用节点资源
+func requestOptions(ctx context.Context, timeoutType fab.TimeoutType) []channel.RequestOption {
+	if ctx == nil {
+		return nil
+	}
+	options := []channel.RequestOption{channel.WithParentContext(ctx)}
+	if deadline, ok := ctx.Deadline(); ok {
+		options = append(options, channel.WithTimeout(timeoutType, time.Until(deadline)))
+	}
+	return options
 }
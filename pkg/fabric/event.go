@@ -0,0 +1,286 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/event"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/ledger"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/fabsdk"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultMaxEventGap 落后的区块数超过该值时先通过 QueryBlock 追赶, 再订阅区块事件
+	DefaultMaxEventGap = 100
+	// DefaultReconnectInterval 订阅失败、断开或区块不连续后首次重连的间隔
+	DefaultReconnectInterval = 2 * time.Second
+	// DefaultMaxReconnectInterval 连续重连时的最大间隔
+	DefaultMaxReconnectInterval = time.Minute
+)
+
+// DefaultReconnectPolicy 重连间隔按指数增长, 获取到区块后重置
+var DefaultReconnectPolicy = client.RetryPolicy{
+	InitialBackoff: DefaultReconnectInterval,
+	MaxBackoff:     DefaultMaxReconnectInterval,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// BlockEventSource 区块事件源
+type BlockEventSource interface {
+	// Subscribe 从 fromBlock 开始按顺序推送区块, 断开时关闭返回的通道, 调用取消函数结束订阅
+	Subscribe(fromBlock uint64) (<-chan *common.Block, func(), error)
+}
+
+// BlockQuerier 按区块号查询区块及区块高度, 用于追赶落后的区块
+type BlockQuerier interface {
+	QueryBlock(blockNumber uint64, options ...ledger.RequestOption) (*Block, error)
+	QueryHeight(options ...ledger.RequestOption) (uint64, error)
+}
+
+// EventSource 返回通道的区块事件源, 需要具有接收完整区块事件的权限
+func (c *Client) EventSource(channelID string) BlockEventSource {
+	return &eventSource{client: c, channelID: channelID}
+}
+
+type eventSource struct {
+	client    *Client
+	channelID string
+}
+
+func (s *eventSource) Subscribe(fromBlock uint64) (<-chan *common.Block, func(), error) {
+	channelProvider := s.client.fabricSDK.ChannelContext(
+		s.channelID,
+		fabsdk.WithOrg(s.client.Organization),
+		fabsdk.WithUser(s.client.Username),
+	)
+	eventClient, err := event.New(channelProvider,
+		event.WithBlockEvents(),
+		event.WithSeekType(seek.FromBlock),
+		event.WithBlockNum(fromBlock),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to new event client")
+	}
+	registration, events, err := eventClient.RegisterBlockEvent()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to register block event")
+	}
+
+	blocks := make(chan *common.Block)
+	done := make(chan struct{})
+	go func() {
+		defer close(blocks)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case blocks <- e.Block:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			eventClient.Unregister(registration)
+		})
+	}
+	return blocks, cancel, nil
+}
+
+// BlockSubscription 从指定区块开始按顺序获取区块. 落后较多时通过 QueryBlock 追赶,
+// 追上后订阅区块事件, 订阅断开或区块不连续时按退避策略等待后从下一个区块重新订阅
+type BlockSubscription struct {
+	source  BlockEventSource
+	querier BlockQuerier
+	isGM    bool
+	// 下一个待返回的区块号
+	next uint64
+	// 最近一次查询的区块高度
+	height    uint64
+	maxGap    uint64
+	reconnect client.RetryPolicy
+	// 未获取到区块的连续重连次数
+	reconnects int
+
+	blocks <-chan *common.Block
+	cancel func()
+}
+
+type SubscriptionOption func(s *BlockSubscription)
+
+// WithMaxEventGap 设置追赶阈值, 为 0 时使用默认值
+func WithMaxEventGap(maxGap uint64) SubscriptionOption {
+	return func(s *BlockSubscription) {
+		if maxGap > 0 {
+			s.maxGap = maxGap
+		}
+	}
+}
+
+// WithReconnectInterval 设置首次重连的间隔
+func WithReconnectInterval(interval time.Duration) SubscriptionOption {
+	return func(s *BlockSubscription) {
+		if interval > 0 {
+			s.reconnect.InitialBackoff = interval
+		}
+	}
+}
+
+// WithReconnectPolicy 设置重连间隔的退避策略, 只使用其中的间隔设置
+func WithReconnectPolicy(policy client.RetryPolicy) SubscriptionOption {
+	return func(s *BlockSubscription) {
+		s.reconnect = policy
+	}
+}
+
+func NewBlockSubscription(source BlockEventSource, querier BlockQuerier, fromBlock uint64, isGM bool, opts ...SubscriptionOption) *BlockSubscription {
+	s := &BlockSubscription{
+		source:    source,
+		querier:   querier,
+		isGM:      isGM,
+		next:      fromBlock,
+		maxGap:    DefaultMaxEventGap,
+		reconnect: DefaultReconnectPolicy,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Next 返回下一个区块, ctx 取消时返回
+func (s *BlockSubscription) Next(ctx context.Context) (*Block, error) {
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if s.blocks == nil {
+			block, err := s.connect()
+			if err != nil {
+				logrus.Warnf("failed to subscribe block events from %d, err:%s", s.next, err.Error())
+				if err = s.wait(ctx); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if block != nil {
+				s.next++
+				s.reconnects = 0
+				return block, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case raw, ok := <-s.blocks:
+			if !ok {
+				logrus.Warnf("block events are disconnected, reconnect from %d", s.next)
+				s.disconnect()
+				if err := s.wait(ctx); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if raw == nil || raw.Header == nil {
+				continue
+			}
+			// 重连后可能重复推送已处理的区块
+			if raw.Header.Number < s.next {
+				continue
+			}
+			// 区块不连续时退避后重新订阅, 由事件服务重新推送缺失的区块
+			if raw.Header.Number > s.next {
+				logrus.Warnf("block %d is received while expecting %d, resubscribe", raw.Header.Number, s.next)
+				s.disconnect()
+				if err := s.wait(ctx); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			block, err := DecodeBlock(raw, s.isGM)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode block")
+			}
+			s.next++
+			s.reconnects = 0
+			return block, nil
+		}
+	}
+}
+
+// connect 落后超过阈值时查询并返回下一个区块, 否则订阅区块事件并返回 nil
+func (s *BlockSubscription) connect() (*Block, error) {
+	if s.next+s.maxGap < s.height {
+		return s.query()
+	}
+	height, err := s.querier.QueryHeight()
+	if err != nil {
+		return nil, err
+	}
+	s.height = height
+	if s.next+s.maxGap < s.height {
+		logrus.Infof("catch up from block %d to %d by query", s.next, s.height)
+		return s.query()
+	}
+
+	blocks, cancel, err := s.source.Subscribe(s.next)
+	if err != nil {
+		return nil, err
+	}
+	s.blocks = blocks
+	s.cancel = cancel
+	return nil, nil
+}
+
+func (s *BlockSubscription) query() (*Block, error) {
+	block, err := s.querier.QueryBlock(s.next)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.Header == nil {
+		return nil, fmt.Errorf("block header should not be nil")
+	}
+	return block, nil
+}
+
+// wait 按连续重连次数退避
+func (s *BlockSubscription) wait(ctx context.Context) error {
+	s.reconnects++
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(s.reconnect.Backoff(s.reconnects)):
+		return nil
+	}
+}
+
+func (s *BlockSubscription) disconnect() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.blocks = nil
+	s.cancel = nil
+}
+
+// Close 结束区块事件订阅
+func (s *BlockSubscription) Close() {
+	s.disconnect()
+}
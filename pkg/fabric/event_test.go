@@ -0,0 +1,130 @@
+package fabric
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEventSource struct {
+	subscribed chan uint64
+	blocks     chan *common.Block
+}
+
+func (s *testEventSource) Subscribe(fromBlock uint64) (<-chan *common.Block, func(), error) {
+	s.blocks = make(chan *common.Block, 10)
+	s.subscribed <- fromBlock
+	return s.blocks, func() {}, nil
+}
+
+type testQuerier struct {
+	height  uint64
+	queried []uint64
+}
+
+func (q *testQuerier) QueryBlock(blockNumber uint64, options ...ledger.RequestOption) (*Block, error) {
+	q.queried = append(q.queried, blockNumber)
+	return &Block{Header: &BlockHeader{Number: blockNumber}}, nil
+}
+
+func (q *testQuerier) QueryHeight(options ...ledger.RequestOption) (uint64, error) {
+	return q.height, nil
+}
+
+func rawBlock(number uint64) *common.Block {
+	return &common.Block{Header: &common.BlockHeader{Number: number}}
+}
+
+func TestBlockSubscription(t *testing.T) {
+	source := &testEventSource{subscribed: make(chan uint64, 10)}
+	querier := &testQuerier{height: 10}
+	s := NewBlockSubscription(source, querier, 0, false,
+		WithMaxEventGap(3), WithReconnectInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	next := func() uint64 {
+		block, err := s.Next(ctx)
+		assert.Nil(t, err)
+		return block.Header.Number
+	}
+
+	// 落后超过阈值时通过查询追赶
+	for i := uint64(0); i < 7; i++ {
+		assert.Equal(t, i, next())
+	}
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6}, querier.queried)
+
+	// 追上后从下一个区块订阅, 跳过重复推送的区块
+	go func() {
+		assert.Equal(t, uint64(7), <-source.subscribed)
+		source.blocks <- rawBlock(6)
+		source.blocks <- rawBlock(7)
+		source.blocks <- rawBlock(8)
+		// 区块不连续时重新订阅
+		source.blocks <- rawBlock(10)
+	}()
+	assert.Equal(t, uint64(7), next())
+	assert.Equal(t, uint64(8), next())
+
+	go func() {
+		assert.Equal(t, uint64(9), <-source.subscribed)
+		source.blocks <- rawBlock(9)
+		// 断开后自动重连
+		close(source.blocks)
+		assert.Equal(t, uint64(10), <-source.subscribed)
+		source.blocks <- rawBlock(10)
+	}()
+	assert.Equal(t, uint64(9), next())
+	// 获取到区块后重置重连间隔
+	assert.Equal(t, 0, s.reconnects)
+	assert.Equal(t, uint64(10), next())
+	assert.Len(t, querier.queried, 7)
+
+	cancel()
+	_, err := s.Next(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+// testGapSource 每次订阅都推送不连续的区块
+type testGapSource struct {
+	subscribed chan time.Time
+}
+
+func (s *testGapSource) Subscribe(fromBlock uint64) (<-chan *common.Block, func(), error) {
+	blocks := make(chan *common.Block, 1)
+	blocks <- rawBlock(fromBlock + 1)
+	s.subscribed <- time.Now()
+	return blocks, func() {}, nil
+}
+
+func TestBlockSubscriptionBackoff(t *testing.T) {
+	source := &testGapSource{subscribed: make(chan time.Time, 10)}
+	s := NewBlockSubscription(source, &testQuerier{}, 0, false, WithReconnectPolicy(client.RetryPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     40 * time.Millisecond,
+		Multiplier:     2,
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := s.Next(ctx)
+		done <- err
+	}()
+
+	// 区块持续不连续时重连间隔按指数增长, 不超过最大间隔
+	var subscribed []time.Time
+	for i := 0; i < 5; i++ {
+		subscribed = append(subscribed, <-source.subscribed)
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	for i, min := range []time.Duration{10, 20, 40, 40} {
+		assert.GreaterOrEqual(t, int64(subscribed[i+1].Sub(subscribed[i])), int64(min*time.Millisecond))
+	}
+}
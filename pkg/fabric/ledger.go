@@ -3,6 +3,8 @@ package fabric
 import (
 	"encoding/hex"
 	"fmt"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
 	"github.com/fabric-creed/fabric-sdk-go/pkg/client/ledger"
 	"github.com/fabric-creed/fabric-sdk-go/pkg/common/providers/fab"
 )
@@ -63,3 +65,33 @@ func (c *Ledger) QueryTransaction(txID string, options ...ledger.RequestOption)
 	}
 	return respTo, nil
 }
+
+// QueryRawBlock 返回未经解码的区块, 用于需要原始字节的哈希与签名校验
+func (c *Ledger) QueryRawBlock(blockNumber uint64, options ...ledger.RequestOption) (*common.Block, error) {
+	resp, err := c.client.QueryBlock(blockNumber, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ledger QueryBlock: %v", err)
+	}
+	return resp, nil
+}
+
+// QueryRawTransaction 返回未经解码的交易及其验证结果, 用于解析交易的调用参数与背书
+func (c *Ledger) QueryRawTransaction(txID string, options ...ledger.RequestOption) (*peer.ProcessedTransaction, error) {
+	resp, err := c.client.QueryTransaction(fab.TransactionID(txID), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ledger QueryTransaction: %v", err)
+	}
+	return resp, nil
+}
+
+// QueryHeight 返回通道当前的区块高度
+func (c *Ledger) QueryHeight(options ...ledger.RequestOption) (uint64, error) {
+	resp, err := c.client.QueryInfo(options...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call ledger QueryInfo: %v", err)
+	}
+	if resp.BCI == nil {
+		return 0, fmt.Errorf("blockchain info should not be nil")
+	}
+	return resp.BCI.Height, nil
+}
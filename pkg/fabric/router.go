@@ -0,0 +1,34 @@
+package fabric
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// CrossChainRequestEvent 路由合约发起跨链请求时发出的合约事件名称
+const CrossChainRequestEvent = "CrossChainRequest"
+
+// CrossChainRequest 路由合约跨链请求事件的内容
+type CrossChainRequest struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	TransactionID string `json:"transactionId"`
+	StepID        string `json:"stepID"`
+	Payload       string `json:"payload"`
+	Signer        string `json:"signer"`
+	// 发起交易的时间戳, 单位秒
+	Timestamp int64 `json:"timestamp"`
+}
+
+// UnmarshalCrossChainRequest 解析跨链请求事件的内容
+func UnmarshalCrossChainRequest(payload []byte) (*CrossChainRequest, error) {
+	request := &CrossChainRequest{}
+	if err := json.Unmarshal(payload, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal cross chain request event")
+	}
+	if request.From == "" || request.To == "" || request.TransactionID == "" {
+		return nil, errors.New("cross chain request event is incomplete")
+	}
+	return request, nil
+}
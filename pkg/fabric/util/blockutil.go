@@ -32,9 +32,14 @@ func BlockHeaderBytes(b *cb.BlockHeader) []byte {
 }
 
 func BlockHeaderHash(b *cb.BlockHeader, isGM bool) []byte {
+	return Hash(BlockHeaderBytes(b), isGM)
+}
+
+// Hash 国密环境使用 SM3, 否则使用 SHA256
+func Hash(data []byte, isGM bool) []byte {
 	if isGM {
-		return sm3.SumSM3(BlockHeaderBytes(b))
+		return sm3.SumSM3(data)
 	}
-	sum := sha256.Sum256(BlockHeaderBytes(b))
+	sum := sha256.Sum256(data)
 	return sum[:]
 }
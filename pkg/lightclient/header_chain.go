@@ -0,0 +1,179 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/header"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// TrustAnchor 可信的区块, 头链从该区块开始同步, 一般由带外方式获得,
+// 信任创世区块时 Number 为 0, Hash 为创世区块头的哈希
+type TrustAnchor struct {
+	Number uint64
+	Hash   []byte
+}
+
+// HeaderChain 远端通道经核实的区块头链. 信任锚点之后的每个区块头都需与前一个区块头的哈希相连,
+// 并满足门限的排序节点签名
+type HeaderChain struct {
+	ChannelID string
+	// 远端通道的路由合约名称, 跨链请求交易必须直接调用该合约
+	RouterChainCodeName string
+	dbPath              string
+	isGM                bool
+	// 头链第一个区块头必须与信任锚点一致, 为 nil 时拒绝追加区块头
+	anchor *TrustAnchor
+	// 排序节点组织, 为 nil 时拒绝追加区块头
+	orderer *client.EndorsementVerifier
+
+	lock sync.Mutex
+}
+
+func NewHeaderChain(dbPath, channelID string, isGM bool, anchor *TrustAnchor, orderer *client.EndorsementVerifier) *HeaderChain {
+	return &HeaderChain{
+		ChannelID: channelID,
+		dbPath:    dbPath,
+		isGM:      isGM,
+		anchor:    anchor,
+		orderer:   orderer,
+	}
+}
+
+// Next 返回下一个需要同步的区块号
+func (h *HeaderChain) Next() (uint64, error) {
+	latest, err := header.NewController(h.dbPath).FetchLatestHeader(h.ChannelID)
+	if err != nil {
+		return 0, err
+	}
+	if latest != nil {
+		return latest.BlockNumber + 1, nil
+	}
+	if h.anchor == nil {
+		return 0, errors.Errorf("trust anchor of channel %s is not configured", h.ChannelID)
+	}
+	return h.anchor.Number, nil
+}
+
+// Header 返回已核实的区块头
+func (h *HeaderChain) Header(blockNumber uint64) (*database.BlockHeader, error) {
+	hdr, err := header.NewController(h.dbPath).FetchHeaderByNumber(h.ChannelID, blockNumber)
+	if err != nil {
+		return nil, errors.Wrapf(err, "header %d of channel %s is not verified", blockNumber, h.ChannelID)
+	}
+	return hdr, nil
+}
+
+// Append 核实一批连续的区块头并追加到头链, 任一区块头核实失败则整批丢弃
+func (h *HeaderChain) Append(headers []*pb.BlockHeader) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.orderer == nil {
+		return errors.Errorf("orderer of channel %s is not configured", h.ChannelID)
+	}
+
+	ctl := header.NewController(h.dbPath)
+	latest, err := ctl.FetchLatestHeader(h.ChannelID)
+	if err != nil {
+		return err
+	}
+	var next uint64
+	var previousHash []byte
+	if latest != nil {
+		next = latest.BlockNumber + 1
+		if previousHash, err = hex.DecodeString(latest.BlockHash); err != nil {
+			return errors.Wrapf(err, "invalid hash of header %d", latest.BlockNumber)
+		}
+	} else if h.anchor != nil {
+		next = h.anchor.Number
+	} else {
+		return errors.Errorf("trust anchor of channel %s is not configured", h.ChannelID)
+	}
+
+	var records []*database.BlockHeader
+	for _, hdr := range headers {
+		if hdr.Number != next {
+			return errors.Errorf("expect header %d of channel %s, got %d", next, h.ChannelID, hdr.Number)
+		}
+		cbHeader := &common.BlockHeader{
+			Number:       hdr.Number,
+			PreviousHash: hdr.PreviousHash,
+			DataHash:     hdr.DataHash,
+		}
+		hash := util.BlockHeaderHash(cbHeader, h.isGM)
+		if previousHash == nil {
+			// 头链的第一个区块头, 由信任锚点保证
+			if !bytes.Equal(hash, h.anchor.Hash) {
+				return errors.Errorf("header %d of channel %s does not match the trust anchor", hdr.Number, h.ChannelID)
+			}
+		} else {
+			if !bytes.Equal(hdr.PreviousHash, previousHash) {
+				return errors.Errorf("header %d of channel %s is not linked to the previous header", hdr.Number, h.ChannelID)
+			}
+			if err = h.verifySignatures(cbHeader, hdr.Signatures); err != nil {
+				return errors.Wrapf(err, "failed to verify signatures of header %d", hdr.Number)
+			}
+		}
+
+		records = append(records, &database.BlockHeader{
+			ChannelID:    h.ChannelID,
+			BlockNumber:  hdr.Number,
+			PreviousHash: hex.EncodeToString(hdr.PreviousHash),
+			DataHash:     hex.EncodeToString(hdr.DataHash),
+			BlockHash:    hex.EncodeToString(hash),
+			// 交易验证码不在区块哈希与签名的保护范围内, 随区块头一同从同步来源获得
+			TransactionsFilter: hdr.TransactionsFilter,
+		})
+		previousHash = hash
+		next++
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return ctl.CreateHeaders(records)
+}
+
+// verifySignatures 核实排序节点对区块的签名, 签名覆盖元数据值、签名头与区块头的拼接
+func (h *HeaderChain) verifySignatures(cbHeader *common.BlockHeader, raw []byte) error {
+	metadata := &common.Metadata{}
+	if err := proto.Unmarshal(raw, metadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal signatures metadata")
+	}
+	headerBytes := util.BlockHeaderBytes(cbHeader)
+	signed := make(map[string]struct{}, len(metadata.Signatures))
+	var lastErr error
+	for _, signature := range metadata.Signatures {
+		signatureHeader := &common.SignatureHeader{}
+		if err := proto.Unmarshal(signature.SignatureHeader, signatureHeader); err != nil {
+			lastErr = errors.Wrap(err, "failed to unmarshal signature header")
+			continue
+		}
+		var msg []byte
+		msg = append(msg, metadata.Value...)
+		msg = append(msg, signature.SignatureHeader...)
+		msg = append(msg, headerBytes...)
+		mspID, err := h.orderer.VerifySignature(signatureHeader.Creator, msg, signature.Signature)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		signed[mspID] = struct{}{}
+		if len(signed) >= h.orderer.Threshold {
+			return nil
+		}
+	}
+	err := errors.Errorf("only %d of the required %d orderer organizations signed the block",
+		len(signed), h.orderer.Threshold)
+	if lastErr != nil {
+		err = errors.Wrap(lastErr, err.Error())
+	}
+	return err
+}
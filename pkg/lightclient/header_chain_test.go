@@ -0,0 +1,175 @@
+package lightclient
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/common/crypto/tlsgen"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/msp"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDBPath 区块头存储在进程内只打开一次, 各测试共用同一目录
+var testDBPath string
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "lightclient")
+	if err != nil {
+		panic(err)
+	}
+	testDBPath = dir
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+type testOrderer struct {
+	verifier *client.EndorsementVerifier
+	identity []byte
+	key      sw.Key
+}
+
+func newTestOrderer(t *testing.T) *testOrderer {
+	ca, err := tlsgen.NewCA(false)
+	assert.Nil(t, err)
+	pair, err := ca.NewClientCertKeyPair(false)
+	assert.Nil(t, err)
+	m, err := client.NewMSP("OrdererMSP", [][]byte{ca.CertBytes()}, nil)
+	assert.Nil(t, err)
+	verifier, err := client.NewEndorsementVerifier([]*client.MSP{m}, 1, false)
+	assert.Nil(t, err)
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: m.ID, IdBytes: pair.Cert})
+	assert.Nil(t, err)
+	key, err := sw.ParsePrivateKey(pair.Key)
+	assert.Nil(t, err)
+	return &testOrderer{verifier: verifier, identity: identity, key: key}
+}
+
+func (o *testOrderer) sign(t *testing.T, header *pb.BlockHeader) {
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: o.identity, Nonce: []byte("nonce")})
+	assert.Nil(t, err)
+	value := []byte("value")
+	var msg []byte
+	msg = append(msg, value...)
+	msg = append(msg, signatureHeader...)
+	msg = append(msg, util.BlockHeaderBytes(&common.BlockHeader{
+		Number:       header.Number,
+		PreviousHash: header.PreviousHash,
+		DataHash:     header.DataHash,
+	})...)
+	signature, err := (&sw.CSP{}).Sign(o.key, util.Hash(msg, false), nil)
+	assert.Nil(t, err)
+	header.Signatures, err = proto.Marshal(&common.Metadata{
+		Value:      value,
+		Signatures: []*common.MetadataSignature{{SignatureHeader: signatureHeader, Signature: signature}},
+	})
+	assert.Nil(t, err)
+}
+
+// newTestHeaders 构造从 0 开始的 n 个相连区块头
+func newTestHeaders(n int) []*pb.BlockHeader {
+	var headers []*pb.BlockHeader
+	var previousHash []byte
+	for i := 0; i < n; i++ {
+		header := &pb.BlockHeader{
+			Number:       uint64(i),
+			PreviousHash: previousHash,
+			DataHash:     util.Hash([]byte{byte(i)}, false),
+		}
+		previousHash = util.BlockHeaderHash(&common.BlockHeader{
+			Number:       header.Number,
+			PreviousHash: header.PreviousHash,
+			DataHash:     header.DataHash,
+		}, false)
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// genesisAnchor 以创世区块为信任锚点
+func genesisAnchor(headers []*pb.BlockHeader) *TrustAnchor {
+	return &TrustAnchor{Number: 0, Hash: headers[1].PreviousHash}
+}
+
+func TestHeaderChain(t *testing.T) {
+	t.Run("genesis", func(t *testing.T) {
+		headers := newTestHeaders(5)
+		orderer := newTestOrderer(t)
+		for _, header := range headers[1:] {
+			orderer.sign(t, header)
+		}
+		// 未配置信任锚点或排序节点组织时拒绝追加区块头
+		untrusted := NewHeaderChain(testDBPath, "untrusted", false, nil, orderer.verifier)
+		_, err := untrusted.Next()
+		assert.NotNil(t, err)
+		assert.NotNil(t, untrusted.Append(headers[:1]))
+		unsigned := NewHeaderChain(testDBPath, "unsigned", false, genesisAnchor(headers), nil)
+		assert.NotNil(t, unsigned.Append(headers[:1]))
+
+		// 创世区块与锚点不一致
+		forgedGenesis := newTestHeaders(1)[0]
+		forgedGenesis.DataHash = headers[1].DataHash
+		chain := NewHeaderChain(testDBPath, "genesis", false, genesisAnchor(headers), orderer.verifier)
+		assert.NotNil(t, chain.Append([]*pb.BlockHeader{forgedGenesis}))
+		assert.Nil(t, chain.Append(headers[:3]))
+		// 区块号不连续
+		assert.NotNil(t, chain.Append(headers[4:]))
+		// 前驱哈希不匹配
+		forged := *headers[3]
+		forged.PreviousHash = headers[2].DataHash
+		assert.NotNil(t, chain.Append([]*pb.BlockHeader{&forged}))
+		assert.Nil(t, chain.Append(headers[3:]))
+
+		next, err := chain.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(5), next)
+		header, err := chain.Header(2)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(2), header.BlockNumber)
+		_, err = chain.Header(5)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("anchor", func(t *testing.T) {
+		headers := newTestHeaders(5)
+		orderer := newTestOrderer(t)
+		for _, header := range headers[3:] {
+			orderer.sign(t, header)
+		}
+		anchor := &TrustAnchor{Number: 2, Hash: headers[3].PreviousHash}
+		chain := NewHeaderChain(testDBPath, "anchor", false, anchor, orderer.verifier)
+		next, err := chain.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(2), next)
+
+		forged := *headers[2]
+		forged.DataHash = headers[1].DataHash
+		assert.NotNil(t, chain.Append([]*pb.BlockHeader{&forged}))
+		assert.Nil(t, chain.Append(headers[2:]))
+	})
+
+	t.Run("orderer", func(t *testing.T) {
+		headers := newTestHeaders(3)
+		orderer := newTestOrderer(t)
+		chain := NewHeaderChain(testDBPath, "orderer", false, genesisAnchor(headers), orderer.verifier)
+		// 创世区块无需签名
+		assert.Nil(t, chain.Append(headers[:1]))
+		assert.NotNil(t, chain.Append(headers[1:]))
+
+		// 非排序节点组织的签名无效
+		outsider := newTestOrderer(t)
+		outsider.sign(t, headers[1])
+		assert.NotNil(t, chain.Append(headers[1:2]))
+
+		orderer.sign(t, headers[1])
+		orderer.sign(t, headers[2])
+		assert.Nil(t, chain.Append(headers[1:]))
+	})
+}
@@ -0,0 +1,202 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// NewInclusionProof 由原始区块构造第 index 个交易的包含证明
+func NewInclusionProof(block *common.Block, index int) (*pb.InclusionProof, error) {
+	if block == nil || block.Header == nil || block.Data == nil {
+		return nil, errors.New("block is incomplete")
+	}
+	if index < 0 || index >= len(block.Data.Data) {
+		return nil, errors.Errorf("tx index %d is out of range", index)
+	}
+	proof := &pb.InclusionProof{
+		Header: &pb.BlockHeader{
+			Number:       block.Header.Number,
+			PreviousHash: block.Header.PreviousHash,
+			DataHash:     block.Header.DataHash,
+		},
+		Data:    block.Data.Data,
+		TxIndex: uint32(index),
+		// 缺少验证结果时视为无效交易
+		ValidationCode: int32(peer.TxValidationCode_NOT_VALIDATED),
+	}
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		filter := block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+		if index < len(filter) {
+			proof.ValidationCode = int32(filter[index])
+		}
+	}
+	return proof, nil
+}
+
+// VerifyInclusion 核实交易信封包含在头链中已核实的区块内且为有效交易, 返回该交易信封.
+// 交易验证码不在区块头哈希的保护范围内, 不采用证明中的验证码, 而以头链随区块头同步的
+// TRANSACTIONS_FILTER 为准, 未同步验证码的区块无法确认交易有效, 拒绝其证明.
+// 调用方还需核实交易的签名与背书
+func (h *HeaderChain) VerifyInclusion(proof *pb.InclusionProof) (*common.Envelope, error) {
+	if proof == nil || proof.Header == nil {
+		return nil, errors.New("inclusion proof is missing")
+	}
+	if int(proof.TxIndex) >= len(proof.Data) {
+		return nil, errors.Errorf("tx index %d is out of range", proof.TxIndex)
+	}
+
+	verified, err := h.Header(proof.Header.Number)
+	if err != nil {
+		return nil, err
+	}
+	filter := verified.TransactionsFilter
+	if len(filter) != len(proof.Data) {
+		return nil, errors.Errorf("validation codes of block %d are not synced", proof.Header.Number)
+	}
+	if code := peer.TxValidationCode(filter[proof.TxIndex]); code != peer.TxValidationCode_VALID {
+		return nil, errors.Errorf("transaction is invalid, validation code: %s", code)
+	}
+	hash := util.BlockHeaderHash(&common.BlockHeader{
+		Number:       proof.Header.Number,
+		PreviousHash: proof.Header.PreviousHash,
+		DataHash:     proof.Header.DataHash,
+	}, h.isGM)
+	if hex.EncodeToString(hash) != verified.BlockHash {
+		return nil, errors.Errorf("header %d does not match the verified header", proof.Header.Number)
+	}
+	dataHash := util.Hash(bytes.Join(proof.Data, nil), h.isGM)
+	if !bytes.Equal(dataHash, proof.Header.DataHash) {
+		return nil, errors.Errorf("data of block %d does not match the data hash", proof.Header.Number)
+	}
+
+	envelope := &common.Envelope{}
+	if err = proto.Unmarshal(proof.Data[proof.TxIndex], envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal envelope")
+	}
+	return envelope, nil
+}
+
+// ChaincodeInvocation 交易信封中的合约调用
+type ChaincodeInvocation struct {
+	TxID      string
+	ChannelID string
+	// 交易直接调用的合约名称
+	ChaincodeName string
+	// 交易提交者身份, 即序列化的 msp.SerializedIdentity
+	Creator []byte
+	Args    [][]byte
+	// 合约在背书结果中发出的事件, 未发出时为 nil
+	Event *peer.ChaincodeEvent
+	// 交易携带的背书, 可交由 client.EndorsementVerifier 核实
+	Endorsement *pb.EndorsementProof
+}
+
+// ParseChaincodeInvocation 解析背书交易信封中的合约调用参数与背书
+func ParseChaincodeInvocation(envelope *common.Envelope) (*ChaincodeInvocation, error) {
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal payload")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is missing")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal channel header")
+	}
+	if channelHeader.Type != int32(common.HeaderType_ENDORSER_TRANSACTION) {
+		return nil, errors.Errorf("transaction %s is not an endorser transaction", channelHeader.TxId)
+	}
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(payload.Header.SignatureHeader, signatureHeader); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal signature header")
+	}
+
+	tx := &peer.Transaction{}
+	if err := proto.Unmarshal(payload.Data, tx); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction")
+	}
+	if len(tx.Actions) == 0 {
+		return nil, errors.Errorf("transaction %s has no action", channelHeader.TxId)
+	}
+	actionPayload := &peer.ChaincodeActionPayload{}
+	if err := proto.Unmarshal(tx.Actions[0].Payload, actionPayload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode action payload")
+	}
+	proposalPayload := &peer.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(actionPayload.ChaincodeProposalPayload, proposalPayload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode proposal payload")
+	}
+	spec := &peer.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(proposalPayload.Input, spec); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode invocation spec")
+	}
+	if spec.ChaincodeSpec == nil || spec.ChaincodeSpec.Input == nil || spec.ChaincodeSpec.ChaincodeId == nil {
+		return nil, errors.Errorf("chaincode input of transaction %s is missing", channelHeader.TxId)
+	}
+	if actionPayload.Action == nil {
+		return nil, errors.Errorf("endorsed action of transaction %s is missing", channelHeader.TxId)
+	}
+
+	event, err := parseChaincodeEvent(actionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to parse chaincode event of transaction %s", channelHeader.TxId)
+	}
+
+	header, err := proto.Marshal(&common.Header{
+		ChannelHeader:   payload.Header.ChannelHeader,
+		SignatureHeader: payload.Header.SignatureHeader,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal proposal header")
+	}
+	endorsement := &pb.EndorsementProof{
+		TxID:            channelHeader.TxId,
+		ValidationCode:  int32(peer.TxValidationCode_VALID),
+		Header:          header,
+		ProposalPayload: actionPayload.ChaincodeProposalPayload,
+	}
+	for _, e := range actionPayload.Action.Endorsements {
+		endorsement.Endorsements = append(endorsement.Endorsements, &pb.Endorsement{
+			Endorser:  e.Endorser,
+			Signature: e.Signature,
+			Payload:   actionPayload.Action.ProposalResponsePayload,
+		})
+	}
+	return &ChaincodeInvocation{
+		TxID:          channelHeader.TxId,
+		ChannelID:     channelHeader.ChannelId,
+		ChaincodeName: spec.ChaincodeSpec.ChaincodeId.Name,
+		Creator:       signatureHeader.Creator,
+		Args:          spec.ChaincodeSpec.Input.Args,
+		Event:         event,
+		Endorsement:   endorsement,
+	}, nil
+}
+
+// parseChaincodeEvent 解析背书结果中的合约事件
+func parseChaincodeEvent(raw []byte) (*peer.ChaincodeEvent, error) {
+	responsePayload := &peer.ProposalResponsePayload{}
+	if err := proto.Unmarshal(raw, responsePayload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposal response payload")
+	}
+	action := &peer.ChaincodeAction{}
+	if err := proto.Unmarshal(responsePayload.Extension, action); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode action")
+	}
+	if len(action.Events) == 0 {
+		return nil, nil
+	}
+	event := &peer.ChaincodeEvent{}
+	if err := proto.Unmarshal(action.Events, event); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode event")
+	}
+	return event, nil
+}
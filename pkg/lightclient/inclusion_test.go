@@ -0,0 +1,147 @@
+package lightclient
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/pkg/fabric/util"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEnvelope(t *testing.T, txID string, args ...string) []byte {
+	var input [][]byte
+	for _, arg := range args {
+		input = append(input, []byte(arg))
+	}
+	spec, err := proto.Marshal(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+		ChaincodeId: &peer.ChaincodeID{Name: "router"},
+		Input:       &peer.ChaincodeInput{Args: input},
+	}})
+	assert.Nil(t, err)
+	proposalPayload, err := proto.Marshal(&peer.ChaincodeProposalPayload{Input: spec})
+	assert.Nil(t, err)
+	// 跨链调用由路由合约发出事件
+	action := &peer.ChaincodeAction{}
+	if len(args) == 7 && args[0] == "ChainCodeInvoke" {
+		action.Events, err = proto.Marshal(&peer.ChaincodeEvent{
+			ChaincodeId: "router",
+			TxId:        txID,
+			EventName:   "CrossChainRequest",
+			Payload: []byte(fmt.Sprintf(`{"from":%q,"to":%q,"transactionId":%q,"stepID":%q,"payload":%q,"signer":%q}`,
+				args[1], args[2], args[3], args[4], args[5], args[6])),
+		})
+		assert.Nil(t, err)
+	}
+	extension, err := proto.Marshal(action)
+	assert.Nil(t, err)
+	responsePayload, err := proto.Marshal(&peer.ProposalResponsePayload{ProposalHash: []byte("hash"), Extension: extension})
+	assert.Nil(t, err)
+	actionPayload, err := proto.Marshal(&peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: proposalPayload,
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: responsePayload,
+			Endorsements:            []*peer.Endorsement{{Endorser: []byte("endorser"), Signature: []byte("sig")}},
+		},
+	})
+	assert.Nil(t, err)
+	tx, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: actionPayload}}})
+	assert.Nil(t, err)
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: "mychannel",
+		TxId:      txID,
+	})
+	assert.Nil(t, err)
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: []byte("creator")})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader},
+		Data:   tx,
+	})
+	assert.Nil(t, err)
+	envelope, err := proto.Marshal(&common.Envelope{Payload: payload, Signature: []byte("signature")})
+	assert.Nil(t, err)
+	return envelope
+}
+
+func TestInclusionProof(t *testing.T) {
+	data := [][]byte{
+		newTestEnvelope(t, "tx0", "Other"),
+		newTestEnvelope(t, "tx1", "ChainCodeInvoke", "from", "to", "t1", "s1", "{}", ""),
+	}
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 0, DataHash: util.Hash(bytes.Join(data, nil), false)},
+		Data:   &common.BlockData{Data: data},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{
+			{}, {}, {byte(peer.TxValidationCode_VALID), byte(peer.TxValidationCode_MVCC_READ_CONFLICT)},
+		}},
+	}
+	orderer := newTestOrderer(t)
+	chain := NewHeaderChain(testDBPath, "inclusion", false, &TrustAnchor{
+		Number: 0,
+		Hash:   util.BlockHeaderHash(&common.BlockHeader{Number: 0, DataHash: block.Header.DataHash}, false),
+	}, orderer.verifier)
+	assert.Nil(t, chain.Append([]*pb.BlockHeader{{
+		Number:             0,
+		DataHash:           block.Header.DataHash,
+		TransactionsFilter: block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER],
+	}}))
+
+	proof, err := NewInclusionProof(block, 0)
+	assert.Nil(t, err)
+	envelope, err := chain.VerifyInclusion(proof)
+	assert.Nil(t, err)
+	invocation, err := ParseChaincodeInvocation(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "tx0", invocation.TxID)
+	assert.Equal(t, "router", invocation.ChaincodeName)
+	assert.Equal(t, []byte("creator"), invocation.Creator)
+	assert.Len(t, invocation.Endorsement.Endorsements, 1)
+	assert.Nil(t, invocation.Event)
+
+	envelope = &common.Envelope{}
+	assert.Nil(t, proto.Unmarshal(data[1], envelope))
+	invocation, err = ParseChaincodeInvocation(envelope)
+	assert.Nil(t, err)
+	assert.Equal(t, "CrossChainRequest", invocation.Event.EventName)
+
+	// 无效交易, 证明中伪造的验证码不被采用
+	proof, err = NewInclusionProof(block, 1)
+	assert.Nil(t, err)
+	_, err = chain.VerifyInclusion(proof)
+	assert.NotNil(t, err)
+	proof.ValidationCode = int32(peer.TxValidationCode_VALID)
+	_, err = chain.VerifyInclusion(proof)
+	assert.NotNil(t, err)
+
+	// 篡改交易信封
+	proof, err = NewInclusionProof(block, 0)
+	assert.Nil(t, err)
+	proof.Data = [][]byte{newTestEnvelope(t, "tx0", "Other", "x"), data[1]}
+	_, err = chain.VerifyInclusion(proof)
+	assert.NotNil(t, err)
+
+	// 未同步验证码的区块无法确认交易有效
+	unsynced := NewHeaderChain(testDBPath, "unsynced", false, &TrustAnchor{
+		Number: 0,
+		Hash:   util.BlockHeaderHash(&common.BlockHeader{Number: 0, DataHash: block.Header.DataHash}, false),
+	}, orderer.verifier)
+	assert.Nil(t, unsynced.Append([]*pb.BlockHeader{{Number: 0, DataHash: block.Header.DataHash}}))
+	proof, err = NewInclusionProof(block, 0)
+	assert.Nil(t, err)
+	_, err = unsynced.VerifyInclusion(proof)
+	assert.NotNil(t, err)
+
+	// 未核实的区块
+	proof.Header.Number = 1
+	_, err = chain.VerifyInclusion(proof)
+	assert.NotNil(t, err)
+
+	_, err = NewInclusionProof(block, 2)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,69 @@
+package lightclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultSyncInterval = 5 * time.Second
+	defaultSyncLimit    = 100
+)
+
+// SyncTask 定期从远端网关拉取区块头并追加到头链
+type SyncTask struct {
+	chain     *HeaderChain
+	hubClient *client.HubClient
+	interval  time.Duration
+}
+
+func NewSyncTask(chain *HeaderChain, hubClient *client.HubClient, interval time.Duration) *SyncTask {
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	return &SyncTask{
+		chain:     chain,
+		hubClient: hubClient,
+		interval:  interval,
+	}
+}
+
+// Run 持续同步区块头, 直到 ctx 取消
+func (t *SyncTask) Run(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		next, err := t.chain.Next()
+		if err != nil {
+			logrus.Errorf("failed to fetch next header of channel %s, err:%s", t.chain.ChannelID, err.Error())
+			time.Sleep(t.interval)
+			continue
+		}
+		resp, err := t.hubClient.SyncBlockHeaders(ctx, &pb.SyncBlockHeadersRequest{
+			ChannelID:   t.chain.ChannelID,
+			StartNumber: next,
+			Limit:       defaultSyncLimit,
+		})
+		if err != nil {
+			logrus.Errorf("failed to sync headers of channel %s, err:%s", t.chain.ChannelID, err.Error())
+			time.Sleep(t.interval)
+			continue
+		}
+		if len(resp.Headers) == 0 {
+			time.Sleep(t.interval)
+			continue
+		}
+		// 核实失败说明远端网关返回了分叉或伪造的区块头, 需人工介入
+		if err = t.chain.Append(resp.Headers); err != nil {
+			logrus.Errorf("failed to append headers of channel %s, err:%s", t.chain.ChannelID, err.Error())
+			time.Sleep(t.interval)
+			continue
+		}
+		logrus.Infof("succeeded to sync headers of channel %s to %d", t.chain.ChannelID, next+uint64(len(resp.Headers))-1)
+	}
+}
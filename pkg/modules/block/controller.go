@@ -2,41 +2,41 @@ package block
 
 import (
 	"github.com/asdine/storm/v3"
-	"github.com/asdine/storm/v3/codec/gob"
 	"github.com/asdine/storm/v3/q"
 	"github.com/fabric-creed/fabric-hub/pkg/database"
-	"path/filepath"
-	"sync"
+	"time"
 )
 
 const DBName = "block.db"
 
-var (
-	MT        = database.Block{}
-	instantDB *storm.DB
-	once      sync.Once
-)
+var MT = database.Block{}
 
 type Controller struct {
-	db *storm.DB
+	node storm.Node
 }
 
-func NewController(dbPath string) *Controller {
-	once.Do(func() {
-		db, err := storm.Open(filepath.Join(dbPath, DBName), storm.Codec(gob.Codec))
-		if err != nil {
-			panic(err)
-		}
-		db.Init(new(database.Block))
-		instantDB = db
-	})
+// NewController 返回本地通道的区块存储, 各通道的区块保存在以通道ID命名的 bucket 中, 区块号及哈希在通道内唯一
+func NewController(dbPath string, channelID string) *Controller {
+	return &Controller{node: database.OpenDB(dbPath, DBName, new(database.Block)).From(channelID)}
+}
+
+// NewLegacyController 返回按通道划分前保存的区块存储, 旧数据不区分通道
+func NewLegacyController(dbPath string) *Controller {
+	return &Controller{node: database.OpenDB(dbPath, DBName, new(database.Block))}
+}
 
-	return &Controller{db: instantDB}
+// FetchLegacyLatestBlockNum 返回按通道划分前保存的最新区块号, 旧数据不区分通道
+func FetchLegacyLatestBlockNum(dbPath string) (uint64, error) {
+	return fetchLatestBlockNum(database.OpenDB(dbPath, DBName, new(database.Block)))
 }
 
 func (c *Controller) FetchLatestBlockNum() (uint64, error) {
+	return fetchLatestBlockNum(c.node)
+}
+
+func fetchLatestBlockNum(node storm.Node) (uint64, error) {
 	var block []database.Block
-	err := c.db.Select().Limit(1).Reverse().Find(&block)
+	err := node.Select().Limit(1).Reverse().Find(&block)
 	if err != nil {
 		if err == storm.ErrNotFound {
 			return 0, nil
@@ -49,19 +49,22 @@ func (c *Controller) FetchLatestBlockNum() (uint64, error) {
 
 func (c *Controller) CreateBlock(block *database.Block) error {
 	var preBlock []database.Block
-	err := c.db.Select(q.Eq("BlockHash", block.PreviousHash)).Find(&preBlock)
+	err := c.node.Select(q.Eq("BlockHash", block.PreviousHash)).Find(&preBlock)
 	if err != nil && err != storm.ErrNotFound {
 		return err
 	}
 
-	tx, err := c.db.Begin(true)
+	tx, err := c.node.Begin(true)
+	if err != nil {
+		return err
+	}
 	if err = tx.Save(block); err != nil {
 		tx.Rollback()
 		return err
 	}
 	if len(preBlock) > 0 && preBlock[0].BlockHash != "" {
 		preBlock[0].NextHash = block.BlockHash
-		if err = tx.Update(preBlock[0]); err != nil {
+		if err = tx.Update(&preBlock[0]); err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -73,3 +76,62 @@ func (c *Controller) CreateBlock(block *database.Block) error {
 
 	return nil
 }
+
+// PruneBatch 每次清理最多删除的区块数
+const PruneBatch = 1000
+
+// Prune 在一个事务中删除最多 limit 个区块号小于 beforeNumber 或出块时间早于 beforeTime 的区块,
+// 为零值时不按该条件删除, 返回删除的区块数. 删除后的空间由后续写入复用, 文件大小通过 database.CompactDB 收缩
+func (c *Controller) Prune(beforeNumber uint64, beforeTime time.Time, limit int) (int, error) {
+	var matchers []q.Matcher
+	if beforeNumber > 0 {
+		matchers = append(matchers, q.Lt("BlockNumber", beforeNumber))
+	}
+	if !beforeTime.IsZero() {
+		matchers = append(matchers, q.Lt("BlockTime", beforeTime))
+	}
+	if len(matchers) == 0 || limit <= 0 {
+		return 0, nil
+	}
+
+	var blocks []database.Block
+	err := c.node.Select(q.Or(matchers...)).Limit(limit).Find(&blocks)
+	if err == storm.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	tx, err := c.node.Begin(true)
+	if err != nil {
+		return 0, err
+	}
+	for i := range blocks {
+		if err = tx.DeleteStruct(&blocks[i]); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(blocks), nil
+}
+
+func (c *Controller) FetchBlockByNumber(blockNumber uint64) (*database.Block, error) {
+	var block database.Block
+	if err := c.node.One("BlockNumber", blockNumber, &block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (c *Controller) FetchBlockByHash(blockHash string) (*database.Block, error) {
+	var block database.Block
+	if err := c.node.One("BlockHash", blockHash, &block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
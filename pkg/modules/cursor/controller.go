@@ -0,0 +1,54 @@
+package cursor
+
+import (
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"time"
+)
+
+const DBName = "cursor.db"
+
+var MT = database.Cursor{}
+
+type Controller struct {
+	db *storm.DB
+}
+
+func NewController(dbPath string) *Controller {
+	return &Controller{db: database.OpenDB(dbPath, DBName, new(database.Cursor))}
+}
+
+// FetchCursor 查询本地通道的处理进度, 未处理过区块时返回 storm.ErrNotFound
+func (c *Controller) FetchCursor(channelID string) (*database.Cursor, error) {
+	var cursor database.Cursor
+	if err := c.db.One("ChannelID", channelID, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+func (c *Controller) Save(channelID string, nextBlock uint64) error {
+	return c.db.Save(&database.Cursor{
+		ChannelID: channelID,
+		NextBlock: nextBlock,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// SaveCheckpoint 保存已处理区块的检查点, 下一个处理的区块为其后一个区块
+func (c *Controller) SaveCheckpoint(channelID string, blockNumber uint64, blockHash, previousHash string) error {
+	return c.db.Save(&database.Cursor{
+		ChannelID:    channelID,
+		NextBlock:    blockNumber + 1,
+		BlockNumber:  blockNumber,
+		BlockHash:    blockHash,
+		PreviousHash: previousHash,
+		UpdatedAt:    time.Now(),
+	})
+}
+
+// MigrateChannelID 迁移本地通道ID字段重命名前保存的记录, 返回迁移的记录数
+func (c *Controller) MigrateChannelID() (int, error) {
+	return database.MigrateChannelID(c.db, new(database.Cursor))
+}
@@ -0,0 +1,93 @@
+package deadletter
+
+import (
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"time"
+)
+
+const DBName = "deadletter.db"
+
+var MT = database.DeadLetter{}
+
+type Controller struct {
+	db *storm.DB
+}
+
+func NewController(dbPath string) *Controller {
+	return &Controller{db: database.OpenDB(dbPath, DBName, new(database.DeadLetter))}
+}
+
+func (c *Controller) Create(letter *database.DeadLetter) error {
+	now := time.Now()
+	letter.CreatedAt = now
+	letter.UpdatedAt = now
+	return c.db.Save(letter)
+}
+
+// Update 保存死信的全部字段, storm 的 Update 会跳过零值字段
+func (c *Controller) Update(letter *database.DeadLetter) error {
+	letter.UpdatedAt = time.Now()
+	return c.db.Save(letter)
+}
+
+func (c *Controller) FetchDeadLetterByID(id int64) (*database.DeadLetter, error) {
+	var letter database.DeadLetter
+	if err := c.db.One("PrimaryID", id, &letter); err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
+
+// FetchByTransactionHash 查询通道中发起跨链请求的交易对应的死信
+func (c *Controller) FetchByTransactionHash(channelID, txHash string) (*database.DeadLetter, error) {
+	var letter database.DeadLetter
+	err := c.db.Select(q.Eq("ChannelID", channelID), q.Eq("TransactionHash", txHash)).First(&letter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
+
+// FetchByRequest 查询通道中跨链请求对应的死信, 每个请求只保留一条
+func (c *Controller) FetchByRequest(channelID, transactionID, stepID string) (*database.DeadLetter, error) {
+	var letter database.DeadLetter
+	err := c.db.Select(q.Eq("ChannelID", channelID), q.Eq("TransactionID", transactionID), q.Eq("StepID", stepID)).
+		First(&letter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
+
+// FetchDeadLetters 按ID倒序分页查询死信, channelID 为空时查询全部通道
+func (c *Controller) FetchDeadLetters(channelID string, offset, limit int) ([]database.DeadLetter, error) {
+	var matchers []q.Matcher
+	if channelID != "" {
+		matchers = append(matchers, q.Eq("ChannelID", channelID))
+	}
+	query := c.db.Select(matchers...).OrderBy("PrimaryID").Reverse().Skip(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var letters []database.DeadLetter
+	err := query.Find(&letters)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	return letters, nil
+}
+
+func (c *Controller) Delete(id int64) error {
+	return c.db.DeleteStruct(&database.DeadLetter{PrimaryID: id})
+}
+
+// MigrateChannelID 迁移本地通道ID字段重命名前保存的记录, 返回迁移的记录数
+func (c *Controller) MigrateChannelID() (int, error) {
+	return database.MigrateChannelID(c.db, new(database.DeadLetter))
+}
@@ -0,0 +1,59 @@
+package header
+
+import (
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+)
+
+const DBName = "header.db"
+
+var MT = database.BlockHeader{}
+
+type Controller struct {
+	db *storm.DB
+}
+
+func NewController(dbPath string) *Controller {
+	return &Controller{db: database.OpenDB(dbPath, DBName, new(database.BlockHeader))}
+}
+
+// FetchLatestHeader 返回通道最新的区块头, 没有区块头时返回 nil
+func (c *Controller) FetchLatestHeader(channelID string) (*database.BlockHeader, error) {
+	var headers []database.BlockHeader
+	err := c.db.Select(q.Eq("ChannelID", channelID)).OrderBy("BlockNumber").Reverse().Limit(1).Find(&headers)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &headers[0], nil
+}
+
+func (c *Controller) FetchHeaderByNumber(channelID string, blockNumber uint64) (*database.BlockHeader, error) {
+	var headers []database.BlockHeader
+	err := c.db.Select(q.Eq("ChannelID", channelID), q.Eq("BlockNumber", blockNumber)).Limit(1).Find(&headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headers[0], nil
+}
+
+// CreateHeaders 在一个事务中保存一批连续的区块头
+func (c *Controller) CreateHeaders(headers []*database.BlockHeader) error {
+	tx, err := c.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	for _, header := range headers {
+		if err = tx.Save(header); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,71 @@
+package inbound
+
+import (
+	"fmt"
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"time"
+)
+
+const DBName = "inbound.db"
+
+var MT = database.InboundExecution{}
+
+type Controller struct {
+	db *storm.DB
+}
+
+func NewController(dbPath string) *Controller {
+	return &Controller{db: database.OpenDB(dbPath, DBName, new(database.InboundExecution))}
+}
+
+// Key 返回远端网关跨链请求的唯一键
+func Key(from, transactionID, stepID string) string {
+	return fmt.Sprintf("%s/%s/%s", from, transactionID, stepID)
+}
+
+func (c *Controller) Create(execution *database.InboundExecution) error {
+	now := time.Now()
+	execution.CreatedAt = now
+	execution.UpdatedAt = now
+	return c.db.Save(execution)
+}
+
+// Update 保存执行记录的全部字段, storm 的 Update 会跳过零值字段
+func (c *Controller) Update(execution *database.InboundExecution) error {
+	execution.UpdatedAt = time.Now()
+	return c.db.Save(execution)
+}
+
+func (c *Controller) FetchByKey(key string) (*database.InboundExecution, error) {
+	var execution database.InboundExecution
+	if err := c.db.One("Key", key, &execution); err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+// FetchExecutions 按状态分页查询执行记录, 新记录在前, state 为空时查询全部状态
+func (c *Controller) FetchExecutions(state database.InboundState, offset, limit int) ([]database.InboundExecution, error) {
+	var matchers []q.Matcher
+	if state != "" {
+		matchers = append(matchers, q.Eq("State", state))
+	}
+	query := c.db.Select(matchers...).OrderBy("PrimaryID").Reverse().Skip(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var executions []database.InboundExecution
+	err := query.Find(&executions)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	return executions, nil
+}
+
+func (c *Controller) Delete(execution *database.InboundExecution) error {
+	return c.db.DeleteStruct(execution)
+}
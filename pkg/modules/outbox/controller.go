@@ -0,0 +1,137 @@
+package outbox
+
+import (
+	"fmt"
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"time"
+)
+
+const DBName = "outbox.db"
+
+var MT = database.Outbox{}
+
+type Controller struct {
+	db *storm.DB
+}
+
+func NewController(dbPath string) *Controller {
+	return &Controller{db: database.OpenDB(dbPath, DBName, new(database.Outbox))}
+}
+
+// Filter 查询跨链请求的条件, 为零值的条件不过滤
+type Filter struct {
+	ChannelID string
+	From      string
+	To        string
+	State     database.OutboxState
+	// 检测到请求的时间范围, 包含起始时间不包含结束时间
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (f *Filter) matchers() []q.Matcher {
+	var matchers []q.Matcher
+	if f.ChannelID != "" {
+		matchers = append(matchers, q.Eq("ChannelID", f.ChannelID))
+	}
+	if f.From != "" {
+		matchers = append(matchers, q.Eq("From", f.From))
+	}
+	if f.To != "" {
+		matchers = append(matchers, q.Eq("To", f.To))
+	}
+	if f.State != "" {
+		matchers = append(matchers, q.Eq("State", f.State))
+	}
+	if !f.StartTime.IsZero() {
+		matchers = append(matchers, q.Gte("DetectedAt", f.StartTime))
+	}
+	if !f.EndTime.IsZero() {
+		matchers = append(matchers, q.Lt("DetectedAt", f.EndTime))
+	}
+	return matchers
+}
+
+// Key 返回跨链请求在本地通道内的唯一键
+func Key(channelID, transactionID, stepID string) string {
+	return fmt.Sprintf("%s/%s/%s", channelID, transactionID, stepID)
+}
+
+func (c *Controller) Create(record *database.Outbox) error {
+	now := time.Now()
+	record.State = database.OutboxDetected
+	record.DetectedAt = now
+	record.UpdatedAt = now
+	return c.db.Save(record)
+}
+
+func (c *Controller) FetchByKey(key string) (*database.Outbox, error) {
+	var record database.Outbox
+	if err := c.db.One("Key", key, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Transit 将记录切换到指定状态并记录进入时间, 保存全部字段
+func (c *Controller) Transit(record *database.Outbox, state database.OutboxState) error {
+	now := time.Now()
+	switch state {
+	case database.OutboxSent:
+		record.SentAt = now
+	case database.OutboxResponded:
+		record.RespondedAt = now
+	case database.OutboxResultWritten:
+		record.ResultWrittenAt = now
+	case database.OutboxCallbackDone:
+		record.CallbackDoneAt = now
+	case database.OutboxFailed:
+		record.FailedAt = now
+	}
+	record.State = state
+	record.UpdatedAt = now
+	return c.db.Save(record)
+}
+
+// FetchByTransactionHash 查询交易发起的跨链请求, 通道为空时查询全部通道
+func (c *Controller) FetchByTransactionHash(channelID, transactionHash string) ([]database.Outbox, error) {
+	matchers := []q.Matcher{q.Eq("TransactionHash", transactionHash)}
+	if channelID != "" {
+		matchers = append(matchers, q.Eq("ChannelID", channelID))
+	}
+	var records []database.Outbox
+	err := c.db.Select(matchers...).OrderBy("PrimaryID").Find(&records)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// FetchOutboxes 按条件分页查询跨链请求, 最新的在前, 同时返回满足条件的总数
+func (c *Controller) FetchOutboxes(filter *Filter, offset, limit int) ([]database.Outbox, int, error) {
+	matchers := filter.matchers()
+	total, err := c.db.Select(matchers...).Count(new(database.Outbox))
+	if err != nil {
+		return nil, 0, err
+	}
+	query := c.db.Select(matchers...).OrderBy("PrimaryID").Reverse().Skip(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var records []database.Outbox
+	err = query.Find(&records)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// MigrateChannelID 迁移本地通道ID字段重命名前保存的记录, 返回迁移的记录数
+func (c *Controller) MigrateChannelID() (int, error) {
+	return database.MigrateChannelID(c.db, new(database.Outbox))
+}
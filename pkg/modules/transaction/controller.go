@@ -2,46 +2,34 @@ package transaction
 
 import (
 	"github.com/asdine/storm/v3"
-	"github.com/asdine/storm/v3/codec/gob"
-	"github.com/asdine/storm/v3/q"
 	"github.com/fabric-creed/fabric-hub/pkg/database"
-	"path/filepath"
-	"sync"
 )
 
 const DBName = "transaction.db"
 
-var (
-	MT        = database.Transaction{}
-	instantDB *storm.DB
-	once      sync.Once
-)
+var MT = database.Transaction{}
 
 type Controller struct {
-	db *storm.DB
+	node storm.Node
+}
+
+// NewController 返回本地通道的交易存储, 各通道的交易保存在以通道ID命名的 bucket 中
+func NewController(dbPath string, channelID string) *Controller {
+	return &Controller{node: database.OpenDB(dbPath, DBName, new(database.Transaction)).From(channelID)}
 }
 
-func NewController(dbPath string) *Controller {
-	once.Do(func() {
-		db, err := storm.Open(filepath.Join(dbPath, DBName), storm.Codec(gob.Codec))
-		if err != nil {
-			panic(err)
-		}
-		db.Init(new(database.Transaction))
-		instantDB = db
-	})
-
-	return &Controller{db: instantDB}
+// NewLegacyController 返回按通道划分前保存的交易存储, 旧数据不区分通道
+func NewLegacyController(dbPath string) *Controller {
+	return &Controller{node: database.OpenDB(dbPath, DBName, new(database.Transaction))}
 }
 
 func (c *Controller) FetchTransactionByTransactionHash(transactionHash string) (*database.Transaction, error) {
-	var transaction []database.Transaction
-	err := c.db.Select(q.Eq("Transaction", transactionHash)).Limit(1).Find(&transaction)
-	if err != nil {
+	var transaction database.Transaction
+	if err := c.node.One("TransactionHash", transactionHash, &transaction); err != nil {
 		return nil, err
 	}
 
-	return &transaction[0], nil
+	return &transaction, nil
 }
 
 func (c *Controller) Create(blockNumber uint64, blockHash string, txHash string, originInfo []byte) error {
@@ -51,5 +39,16 @@ func (c *Controller) Create(blockNumber uint64, blockHash string, txHash string,
 		TransactionHash: txHash,
 		OriginInfo:      originInfo,
 	}
-	return c.db.Save(transaction)
+	return c.node.Save(transaction)
+}
+
+// CreateInvalid 记录被跳过的无效交易及其验证结果
+func (c *Controller) CreateInvalid(blockNumber uint64, blockHash string, txHash string, validationCode int32) error {
+	transaction := &database.Transaction{
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+		TransactionHash: txHash,
+		ValidationCode:  validationCode,
+	}
+	return c.node.Save(transaction)
 }
@@ -0,0 +1,1765 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/protos/admin.proto
+
+package pb // import "pkg/protos/pb"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ListDeadLettersRequest struct {
+	// 本地通道ID, 为空时查询全部通道
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Offset    uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 为 0 时返回全部
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersRequest) Reset()         { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()    {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{0}
+}
+func (m *ListDeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersRequest.Unmarshal(m, b)
+}
+func (m *ListDeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListDeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersRequest.Merge(dst, src)
+}
+func (m *ListDeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersRequest.Size(m)
+}
+func (m *ListDeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersRequest proto.InternalMessageInfo
+
+func (m *ListDeadLettersRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ListDeadLettersRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListDeadLettersRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters          []*DeadLetter `protobuf:"bytes,1,rep,name=deadLetters,proto3" json:"deadLetters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListDeadLettersResponse) Reset()         { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()    {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{1}
+}
+func (m *ListDeadLettersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersResponse.Unmarshal(m, b)
+}
+func (m *ListDeadLettersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListDeadLettersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersResponse.Merge(dst, src)
+}
+func (m *ListDeadLettersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersResponse.Size(m)
+}
+func (m *ListDeadLettersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersResponse proto.InternalMessageInfo
+
+func (m *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if m != nil {
+		return m.DeadLetters
+	}
+	return nil
+}
+
+type DeadLetterRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetterRequest) Reset()         { *m = DeadLetterRequest{} }
+func (m *DeadLetterRequest) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterRequest) ProtoMessage()    {}
+func (*DeadLetterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{2}
+}
+func (m *DeadLetterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterRequest.Unmarshal(m, b)
+}
+func (m *DeadLetterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeadLetterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterRequest.Merge(dst, src)
+}
+func (m *DeadLetterRequest) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterRequest.Size(m)
+}
+func (m *DeadLetterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterRequest proto.InternalMessageInfo
+
+func (m *DeadLetterRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 本地通道ID
+	ChannelID     string `protobuf:"bytes,2,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	TransactionID string `protobuf:"bytes,5,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	StepID        string `protobuf:"bytes,6,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	// 发起跨链请求的交易hash
+	TransactionHash string `protobuf:"bytes,7,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	BlockNumber     uint64 `protobuf:"varint,8,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	// 累计尝试次数
+	Attempts uint32 `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// 每次尝试的错误信息
+	Errors []string `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
+	// 是否为不可重试的错误
+	Permanent bool `protobuf:"varint,11,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	// 是否已收到响应, 重新处理时只重试回调
+	HasResponse bool `protobuf:"varint,12,opt,name=hasResponse,proto3" json:"hasResponse,omitempty"`
+	// unix 时间戳, 秒
+	CreatedAt int64 `protobuf:"varint,13,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,14,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// 序列化的跨链请求, 只在查询单个死信时返回
+	Request              []byte   `protobuf:"bytes,15,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeadLetter) Reset()         { *m = DeadLetter{} }
+func (m *DeadLetter) String() string { return proto.CompactTextString(m) }
+func (*DeadLetter) ProtoMessage()    {}
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{3}
+}
+func (m *DeadLetter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetter.Unmarshal(m, b)
+}
+func (m *DeadLetter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetter.Marshal(b, m, deterministic)
+}
+func (dst *DeadLetter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetter.Merge(dst, src)
+}
+func (m *DeadLetter) XXX_Size() int {
+	return xxx_messageInfo_DeadLetter.Size(m)
+}
+func (m *DeadLetter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetter proto.InternalMessageInfo
+
+func (m *DeadLetter) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetTransactionID() string {
+	if m != nil {
+		return m.TransactionID
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetStepID() string {
+	if m != nil {
+		return m.StepID
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *DeadLetter) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *DeadLetter) GetPermanent() bool {
+	if m != nil {
+		return m.Permanent
+	}
+	return false
+}
+
+func (m *DeadLetter) GetHasResponse() bool {
+	if m != nil {
+		return m.HasResponse
+	}
+	return false
+}
+
+func (m *DeadLetter) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *DeadLetter) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type RetryDeadLetterResponse struct {
+	// 重新处理成功后死信被删除
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// 重新处理失败时为更新后的死信
+	DeadLetter           *DeadLetter `protobuf:"bytes,2,opt,name=deadLetter,proto3" json:"deadLetter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RetryDeadLetterResponse) Reset()         { *m = RetryDeadLetterResponse{} }
+func (m *RetryDeadLetterResponse) String() string { return proto.CompactTextString(m) }
+func (*RetryDeadLetterResponse) ProtoMessage()    {}
+func (*RetryDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{4}
+}
+func (m *RetryDeadLetterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryDeadLetterResponse.Unmarshal(m, b)
+}
+func (m *RetryDeadLetterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryDeadLetterResponse.Marshal(b, m, deterministic)
+}
+func (dst *RetryDeadLetterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryDeadLetterResponse.Merge(dst, src)
+}
+func (m *RetryDeadLetterResponse) XXX_Size() int {
+	return xxx_messageInfo_RetryDeadLetterResponse.Size(m)
+}
+func (m *RetryDeadLetterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryDeadLetterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryDeadLetterResponse proto.InternalMessageInfo
+
+func (m *RetryDeadLetterResponse) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *RetryDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if m != nil {
+		return m.DeadLetter
+	}
+	return nil
+}
+
+type DiscardDeadLetterResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscardDeadLetterResponse) Reset()         { *m = DiscardDeadLetterResponse{} }
+func (m *DiscardDeadLetterResponse) String() string { return proto.CompactTextString(m) }
+func (*DiscardDeadLetterResponse) ProtoMessage()    {}
+func (*DiscardDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{5}
+}
+func (m *DiscardDeadLetterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiscardDeadLetterResponse.Unmarshal(m, b)
+}
+func (m *DiscardDeadLetterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiscardDeadLetterResponse.Marshal(b, m, deterministic)
+}
+func (dst *DiscardDeadLetterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardDeadLetterResponse.Merge(dst, src)
+}
+func (m *DiscardDeadLetterResponse) XXX_Size() int {
+	return xxx_messageInfo_DiscardDeadLetterResponse.Size(m)
+}
+func (m *DiscardDeadLetterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardDeadLetterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardDeadLetterResponse proto.InternalMessageInfo
+
+type CursorRequest struct {
+	// 本地通道ID
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CursorRequest) Reset()         { *m = CursorRequest{} }
+func (m *CursorRequest) String() string { return proto.CompactTextString(m) }
+func (*CursorRequest) ProtoMessage()    {}
+func (*CursorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{6}
+}
+func (m *CursorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CursorRequest.Unmarshal(m, b)
+}
+func (m *CursorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CursorRequest.Marshal(b, m, deterministic)
+}
+func (dst *CursorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CursorRequest.Merge(dst, src)
+}
+func (m *CursorRequest) XXX_Size() int {
+	return xxx_messageInfo_CursorRequest.Size(m)
+}
+func (m *CursorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CursorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CursorRequest proto.InternalMessageInfo
+
+func (m *CursorRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+type Cursor struct {
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	// 下一个处理的区块
+	NextBlock            uint64   `protobuf:"varint,2,opt,name=nextBlock,proto3" json:"nextBlock,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cursor) Reset()         { *m = Cursor{} }
+func (m *Cursor) String() string { return proto.CompactTextString(m) }
+func (*Cursor) ProtoMessage()    {}
+func (*Cursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{7}
+}
+func (m *Cursor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cursor.Unmarshal(m, b)
+}
+func (m *Cursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cursor.Marshal(b, m, deterministic)
+}
+func (dst *Cursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cursor.Merge(dst, src)
+}
+func (m *Cursor) XXX_Size() int {
+	return xxx_messageInfo_Cursor.Size(m)
+}
+func (m *Cursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cursor proto.InternalMessageInfo
+
+func (m *Cursor) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *Cursor) GetNextBlock() uint64 {
+	if m != nil {
+		return m.NextBlock
+	}
+	return 0
+}
+
+type SetStartBlockRequest struct {
+	ChannelID   string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	// 为 true 时从账本当前高度开始, 忽略 blockNumber
+	Latest               bool     `protobuf:"varint,3,opt,name=latest,proto3" json:"latest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetStartBlockRequest) Reset()         { *m = SetStartBlockRequest{} }
+func (m *SetStartBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*SetStartBlockRequest) ProtoMessage()    {}
+func (*SetStartBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{8}
+}
+func (m *SetStartBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetStartBlockRequest.Unmarshal(m, b)
+}
+func (m *SetStartBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetStartBlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetStartBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetStartBlockRequest.Merge(dst, src)
+}
+func (m *SetStartBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_SetStartBlockRequest.Size(m)
+}
+func (m *SetStartBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetStartBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetStartBlockRequest proto.InternalMessageInfo
+
+func (m *SetStartBlockRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *SetStartBlockRequest) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *SetStartBlockRequest) GetLatest() bool {
+	if m != nil {
+		return m.Latest
+	}
+	return false
+}
+
+type ReplayBlocksRequest struct {
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	// 区块范围, 包含两端
+	FromBlock            uint64   `protobuf:"varint,2,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	ToBlock              uint64   `protobuf:"varint,3,opt,name=toBlock,proto3" json:"toBlock,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayBlocksRequest) Reset()         { *m = ReplayBlocksRequest{} }
+func (m *ReplayBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayBlocksRequest) ProtoMessage()    {}
+func (*ReplayBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{9}
+}
+func (m *ReplayBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayBlocksRequest.Unmarshal(m, b)
+}
+func (m *ReplayBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayBlocksRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReplayBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayBlocksRequest.Merge(dst, src)
+}
+func (m *ReplayBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayBlocksRequest.Size(m)
+}
+func (m *ReplayBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayBlocksRequest proto.InternalMessageInfo
+
+func (m *ReplayBlocksRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ReplayBlocksRequest) GetFromBlock() uint64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *ReplayBlocksRequest) GetToBlock() uint64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+type ReplayBlocksResponse struct {
+	// 处理的区块数
+	Blocks uint32 `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	// 处理的跨链请求数, 已处理过的请求由处理记录去重
+	Requests             uint32   `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayBlocksResponse) Reset()         { *m = ReplayBlocksResponse{} }
+func (m *ReplayBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayBlocksResponse) ProtoMessage()    {}
+func (*ReplayBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{10}
+}
+func (m *ReplayBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayBlocksResponse.Unmarshal(m, b)
+}
+func (m *ReplayBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayBlocksResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReplayBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayBlocksResponse.Merge(dst, src)
+}
+func (m *ReplayBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplayBlocksResponse.Size(m)
+}
+func (m *ReplayBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayBlocksResponse proto.InternalMessageInfo
+
+func (m *ReplayBlocksResponse) GetBlocks() uint32 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *ReplayBlocksResponse) GetRequests() uint32 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+type GetBlockRequest struct {
+	// 本地通道ID, 为空时查询按通道划分前保存的区块
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	// 区块哈希为空时按区块号查询
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BlockHash   string `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	// 是否返回解码后的区块数据
+	WithOriginInfo       bool     `protobuf:"varint,4,opt,name=withOriginInfo,proto3" json:"withOriginInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{11}
+}
+func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
+}
+func (m *GetBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockRequest.Merge(dst, src)
+}
+func (m *GetBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockRequest.Size(m)
+}
+func (m *GetBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockRequest proto.InternalMessageInfo
+
+func (m *GetBlockRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *GetBlockRequest) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *GetBlockRequest) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *GetBlockRequest) GetWithOriginInfo() bool {
+	if m != nil {
+		return m.WithOriginInfo
+	}
+	return false
+}
+
+type BlockRecord struct {
+	BlockNumber  uint64 `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BlockHash    string `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	PreviousHash string `protobuf:"bytes,3,opt,name=previousHash,proto3" json:"previousHash,omitempty"`
+	NextHash     string `protobuf:"bytes,4,opt,name=nextHash,proto3" json:"nextHash,omitempty"`
+	DataHash     string `protobuf:"bytes,5,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
+	// unix 时间戳, 秒
+	BlockTime int64  `protobuf:"varint,6,opt,name=blockTime,proto3" json:"blockTime,omitempty"`
+	TxNum     uint32 `protobuf:"varint,7,opt,name=txNum,proto3" json:"txNum,omitempty"`
+	// JSON 格式的解码后的区块数据
+	OriginInfo           string   `protobuf:"bytes,8,opt,name=originInfo,proto3" json:"originInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockRecord) Reset()         { *m = BlockRecord{} }
+func (m *BlockRecord) String() string { return proto.CompactTextString(m) }
+func (*BlockRecord) ProtoMessage()    {}
+func (*BlockRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{12}
+}
+func (m *BlockRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockRecord.Unmarshal(m, b)
+}
+func (m *BlockRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockRecord.Marshal(b, m, deterministic)
+}
+func (dst *BlockRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRecord.Merge(dst, src)
+}
+func (m *BlockRecord) XXX_Size() int {
+	return xxx_messageInfo_BlockRecord.Size(m)
+}
+func (m *BlockRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRecord proto.InternalMessageInfo
+
+func (m *BlockRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockRecord) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *BlockRecord) GetPreviousHash() string {
+	if m != nil {
+		return m.PreviousHash
+	}
+	return ""
+}
+
+func (m *BlockRecord) GetNextHash() string {
+	if m != nil {
+		return m.NextHash
+	}
+	return ""
+}
+
+func (m *BlockRecord) GetDataHash() string {
+	if m != nil {
+		return m.DataHash
+	}
+	return ""
+}
+
+func (m *BlockRecord) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
+
+func (m *BlockRecord) GetTxNum() uint32 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *BlockRecord) GetOriginInfo() string {
+	if m != nil {
+		return m.OriginInfo
+	}
+	return ""
+}
+
+type WalkBlocksRequest struct {
+	// 本地通道ID, 为空时查询按通道划分前保存的区块
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	// 起始区块, 区块哈希为空时按区块号查询, 结果包含起始区块
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BlockHash   string `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	// 为 true 时沿前驱哈希查询
+	Backward bool `protobuf:"varint,4,opt,name=backward,proto3" json:"backward,omitempty"`
+	// 为 0 时使用默认值
+	Limit                uint32   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	WithOriginInfo       bool     `protobuf:"varint,6,opt,name=withOriginInfo,proto3" json:"withOriginInfo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalkBlocksRequest) Reset()         { *m = WalkBlocksRequest{} }
+func (m *WalkBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*WalkBlocksRequest) ProtoMessage()    {}
+func (*WalkBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{13}
+}
+func (m *WalkBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalkBlocksRequest.Unmarshal(m, b)
+}
+func (m *WalkBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalkBlocksRequest.Marshal(b, m, deterministic)
+}
+func (dst *WalkBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalkBlocksRequest.Merge(dst, src)
+}
+func (m *WalkBlocksRequest) XXX_Size() int {
+	return xxx_messageInfo_WalkBlocksRequest.Size(m)
+}
+func (m *WalkBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalkBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WalkBlocksRequest proto.InternalMessageInfo
+
+func (m *WalkBlocksRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *WalkBlocksRequest) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *WalkBlocksRequest) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *WalkBlocksRequest) GetBackward() bool {
+	if m != nil {
+		return m.Backward
+	}
+	return false
+}
+
+func (m *WalkBlocksRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *WalkBlocksRequest) GetWithOriginInfo() bool {
+	if m != nil {
+		return m.WithOriginInfo
+	}
+	return false
+}
+
+type WalkBlocksResponse struct {
+	Blocks []*BlockRecord `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// 下一页的起始区块哈希, 为空时没有更多区块
+	NextPageHash         string   `protobuf:"bytes,2,opt,name=nextPageHash,proto3" json:"nextPageHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WalkBlocksResponse) Reset()         { *m = WalkBlocksResponse{} }
+func (m *WalkBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*WalkBlocksResponse) ProtoMessage()    {}
+func (*WalkBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{14}
+}
+func (m *WalkBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalkBlocksResponse.Unmarshal(m, b)
+}
+func (m *WalkBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalkBlocksResponse.Marshal(b, m, deterministic)
+}
+func (dst *WalkBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalkBlocksResponse.Merge(dst, src)
+}
+func (m *WalkBlocksResponse) XXX_Size() int {
+	return xxx_messageInfo_WalkBlocksResponse.Size(m)
+}
+func (m *WalkBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalkBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WalkBlocksResponse proto.InternalMessageInfo
+
+func (m *WalkBlocksResponse) GetBlocks() []*BlockRecord {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *WalkBlocksResponse) GetNextPageHash() string {
+	if m != nil {
+		return m.NextPageHash
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	// 本地通道ID, 为空时查询按通道划分前保存的交易
+	ChannelID            string   `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	TransactionHash      string   `protobuf:"bytes,2,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{15}
+}
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(dst, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *GetTransactionRequest) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+type TransactionRecord struct {
+	ChannelID       string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	BlockNumber     uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BlockHash       string `protobuf:"bytes,3,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	TransactionHash string `protobuf:"bytes,4,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	// 交易验证结果, 非 VALID 的交易未被转发
+	ValidationCode string `protobuf:"bytes,5,opt,name=validationCode,proto3" json:"validationCode,omitempty"`
+	// JSON 格式的解码后的交易 Envelope
+	OriginInfo string `protobuf:"bytes,6,opt,name=originInfo,proto3" json:"originInfo,omitempty"`
+	// 交易发起的跨链请求
+	Requests             []*CrossChainRequestRecord `protobuf:"bytes,7,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *TransactionRecord) Reset()         { *m = TransactionRecord{} }
+func (m *TransactionRecord) String() string { return proto.CompactTextString(m) }
+func (*TransactionRecord) ProtoMessage()    {}
+func (*TransactionRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{16}
+}
+func (m *TransactionRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionRecord.Unmarshal(m, b)
+}
+func (m *TransactionRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionRecord.Marshal(b, m, deterministic)
+}
+func (dst *TransactionRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionRecord.Merge(dst, src)
+}
+func (m *TransactionRecord) XXX_Size() int {
+	return xxx_messageInfo_TransactionRecord.Size(m)
+}
+func (m *TransactionRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionRecord proto.InternalMessageInfo
+
+func (m *TransactionRecord) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *TransactionRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TransactionRecord) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *TransactionRecord) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *TransactionRecord) GetValidationCode() string {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return ""
+}
+
+func (m *TransactionRecord) GetOriginInfo() string {
+	if m != nil {
+		return m.OriginInfo
+	}
+	return ""
+}
+
+func (m *TransactionRecord) GetRequests() []*CrossChainRequestRecord {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+type ListCrossChainRequestsRequest struct {
+	// 以下条件为空时不过滤
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	From      string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// detected, sent, responded, result-written, callback-done 或 failed
+	State string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	// 检测到请求的时间范围, unix 时间戳, 秒, 包含起始时间不包含结束时间
+	StartTime int64  `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime   int64  `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Offset    uint32 `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 为 0 时使用默认值
+	Limit                uint32   `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCrossChainRequestsRequest) Reset()         { *m = ListCrossChainRequestsRequest{} }
+func (m *ListCrossChainRequestsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCrossChainRequestsRequest) ProtoMessage()    {}
+func (*ListCrossChainRequestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{17}
+}
+func (m *ListCrossChainRequestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCrossChainRequestsRequest.Unmarshal(m, b)
+}
+func (m *ListCrossChainRequestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCrossChainRequestsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListCrossChainRequestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCrossChainRequestsRequest.Merge(dst, src)
+}
+func (m *ListCrossChainRequestsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCrossChainRequestsRequest.Size(m)
+}
+func (m *ListCrossChainRequestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCrossChainRequestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCrossChainRequestsRequest proto.InternalMessageInfo
+
+func (m *ListCrossChainRequestsRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *ListCrossChainRequestsRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ListCrossChainRequestsRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ListCrossChainRequestsRequest) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ListCrossChainRequestsRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ListCrossChainRequestsRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ListCrossChainRequestsRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListCrossChainRequestsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListCrossChainRequestsResponse struct {
+	Requests []*CrossChainRequestRecord `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// 满足条件的请求总数
+	Total                uint32   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCrossChainRequestsResponse) Reset()         { *m = ListCrossChainRequestsResponse{} }
+func (m *ListCrossChainRequestsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCrossChainRequestsResponse) ProtoMessage()    {}
+func (*ListCrossChainRequestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{18}
+}
+func (m *ListCrossChainRequestsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCrossChainRequestsResponse.Unmarshal(m, b)
+}
+func (m *ListCrossChainRequestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCrossChainRequestsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListCrossChainRequestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCrossChainRequestsResponse.Merge(dst, src)
+}
+func (m *ListCrossChainRequestsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCrossChainRequestsResponse.Size(m)
+}
+func (m *ListCrossChainRequestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCrossChainRequestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCrossChainRequestsResponse proto.InternalMessageInfo
+
+func (m *ListCrossChainRequestsResponse) GetRequests() []*CrossChainRequestRecord {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *ListCrossChainRequestsResponse) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type CrossChainRequestRecord struct {
+	// 本地通道ID
+	ChannelID     string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	TransactionID string `protobuf:"bytes,4,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	StepID        string `protobuf:"bytes,5,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	// 发起跨链请求的交易hash
+	TransactionHash string `protobuf:"bytes,6,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	BlockNumber     uint64 `protobuf:"varint,7,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	State           string `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	// 失败时的错误信息
+	Error string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	// 是否已收到远端网关的响应
+	HasResponse bool `protobuf:"varint,10,opt,name=hasResponse,proto3" json:"hasResponse,omitempty"`
+	// 各状态的进入时间, unix 时间戳, 秒, 未进入时为 0
+	DetectedAt      int64 `protobuf:"varint,11,opt,name=detectedAt,proto3" json:"detectedAt,omitempty"`
+	SentAt          int64 `protobuf:"varint,12,opt,name=sentAt,proto3" json:"sentAt,omitempty"`
+	RespondedAt     int64 `protobuf:"varint,13,opt,name=respondedAt,proto3" json:"respondedAt,omitempty"`
+	ResultWrittenAt int64 `protobuf:"varint,14,opt,name=resultWrittenAt,proto3" json:"resultWrittenAt,omitempty"`
+	CallbackDoneAt  int64 `protobuf:"varint,15,opt,name=callbackDoneAt,proto3" json:"callbackDoneAt,omitempty"`
+	FailedAt        int64 `protobuf:"varint,16,opt,name=failedAt,proto3" json:"failedAt,omitempty"`
+	UpdatedAt       int64 `protobuf:"varint,17,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// JSON 格式的解码后的跨链请求, 不包含包含证明
+	Request              string   `protobuf:"bytes,18,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossChainRequestRecord) Reset()         { *m = CrossChainRequestRecord{} }
+func (m *CrossChainRequestRecord) String() string { return proto.CompactTextString(m) }
+func (*CrossChainRequestRecord) ProtoMessage()    {}
+func (*CrossChainRequestRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{19}
+}
+func (m *CrossChainRequestRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossChainRequestRecord.Unmarshal(m, b)
+}
+func (m *CrossChainRequestRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossChainRequestRecord.Marshal(b, m, deterministic)
+}
+func (dst *CrossChainRequestRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossChainRequestRecord.Merge(dst, src)
+}
+func (m *CrossChainRequestRecord) XXX_Size() int {
+	return xxx_messageInfo_CrossChainRequestRecord.Size(m)
+}
+func (m *CrossChainRequestRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossChainRequestRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossChainRequestRecord proto.InternalMessageInfo
+
+func (m *CrossChainRequestRecord) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetTransactionID() string {
+	if m != nil {
+		return m.TransactionID
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetStepID() string {
+	if m != nil {
+		return m.StepID
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CrossChainRequestRecord) GetHasResponse() bool {
+	if m != nil {
+		return m.HasResponse
+	}
+	return false
+}
+
+func (m *CrossChainRequestRecord) GetDetectedAt() int64 {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetSentAt() int64 {
+	if m != nil {
+		return m.SentAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetRespondedAt() int64 {
+	if m != nil {
+		return m.RespondedAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetResultWrittenAt() int64 {
+	if m != nil {
+		return m.ResultWrittenAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetCallbackDoneAt() int64 {
+	if m != nil {
+		return m.CallbackDoneAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetFailedAt() int64 {
+	if m != nil {
+		return m.FailedAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *CrossChainRequestRecord) GetRequest() string {
+	if m != nil {
+		return m.Request
+	}
+	return ""
+}
+
+type ListInboundExecutionsRequest struct {
+	// executing, committed, executed 或 aborted, 为空时查询全部状态
+	State  string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 为 0 时返回全部
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListInboundExecutionsRequest) Reset()         { *m = ListInboundExecutionsRequest{} }
+func (m *ListInboundExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListInboundExecutionsRequest) ProtoMessage()    {}
+func (*ListInboundExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{20}
+}
+func (m *ListInboundExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInboundExecutionsRequest.Unmarshal(m, b)
+}
+func (m *ListInboundExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInboundExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListInboundExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInboundExecutionsRequest.Merge(dst, src)
+}
+func (m *ListInboundExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListInboundExecutionsRequest.Size(m)
+}
+func (m *ListInboundExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInboundExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInboundExecutionsRequest proto.InternalMessageInfo
+
+func (m *ListInboundExecutionsRequest) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ListInboundExecutionsRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListInboundExecutionsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListInboundExecutionsResponse struct {
+	Executions           []*InboundExecution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListInboundExecutionsResponse) Reset()         { *m = ListInboundExecutionsResponse{} }
+func (m *ListInboundExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListInboundExecutionsResponse) ProtoMessage()    {}
+func (*ListInboundExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{21}
+}
+func (m *ListInboundExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListInboundExecutionsResponse.Unmarshal(m, b)
+}
+func (m *ListInboundExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListInboundExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListInboundExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListInboundExecutionsResponse.Merge(dst, src)
+}
+func (m *ListInboundExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListInboundExecutionsResponse.Size(m)
+}
+func (m *ListInboundExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListInboundExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListInboundExecutionsResponse proto.InternalMessageInfo
+
+func (m *ListInboundExecutionsResponse) GetExecutions() []*InboundExecution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type ResolveInboundExecutionRequest struct {
+	From          string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	TransactionID string `protobuf:"bytes,2,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	StepID        string `protobuf:"bytes,3,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	// 请求在本链执行的 Fabric 交易ID, 为空表示已确认链上没有该请求的交易
+	TxID                 string   `protobuf:"bytes,4,opt,name=txID,proto3" json:"txID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveInboundExecutionRequest) Reset()         { *m = ResolveInboundExecutionRequest{} }
+func (m *ResolveInboundExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveInboundExecutionRequest) ProtoMessage()    {}
+func (*ResolveInboundExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{22}
+}
+func (m *ResolveInboundExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveInboundExecutionRequest.Unmarshal(m, b)
+}
+func (m *ResolveInboundExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveInboundExecutionRequest.Marshal(b, m, deterministic)
+}
+func (dst *ResolveInboundExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveInboundExecutionRequest.Merge(dst, src)
+}
+func (m *ResolveInboundExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveInboundExecutionRequest.Size(m)
+}
+func (m *ResolveInboundExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveInboundExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveInboundExecutionRequest proto.InternalMessageInfo
+
+func (m *ResolveInboundExecutionRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ResolveInboundExecutionRequest) GetTransactionID() string {
+	if m != nil {
+		return m.TransactionID
+	}
+	return ""
+}
+
+func (m *ResolveInboundExecutionRequest) GetStepID() string {
+	if m != nil {
+		return m.StepID
+	}
+	return ""
+}
+
+func (m *ResolveInboundExecutionRequest) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+type InboundExecution struct {
+	// 来源链通道ID、交易ID与步骤ID组成的唯一键
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	TransactionID string `protobuf:"bytes,4,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	StepID        string `protobuf:"bytes,5,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	State         string `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	// 请求在本链执行的 Fabric 交易ID, 合约执行前为空
+	TxID string `protobuf:"bytes,7,opt,name=txID,proto3" json:"txID,omitempty"`
+	// unix 时间戳, 秒
+	CreatedAt            int64    `protobuf:"varint,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt            int64    `protobuf:"varint,9,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InboundExecution) Reset()         { *m = InboundExecution{} }
+func (m *InboundExecution) String() string { return proto.CompactTextString(m) }
+func (*InboundExecution) ProtoMessage()    {}
+func (*InboundExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_7f63af2a7318c1c8, []int{23}
+}
+func (m *InboundExecution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InboundExecution.Unmarshal(m, b)
+}
+func (m *InboundExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InboundExecution.Marshal(b, m, deterministic)
+}
+func (dst *InboundExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InboundExecution.Merge(dst, src)
+}
+func (m *InboundExecution) XXX_Size() int {
+	return xxx_messageInfo_InboundExecution.Size(m)
+}
+func (m *InboundExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_InboundExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InboundExecution proto.InternalMessageInfo
+
+func (m *InboundExecution) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetTransactionID() string {
+	if m != nil {
+		return m.TransactionID
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetStepID() string {
+	if m != nil {
+		return m.StepID
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+func (m *InboundExecution) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *InboundExecution) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "ListDeadLettersResponse")
+	proto.RegisterType((*DeadLetterRequest)(nil), "DeadLetterRequest")
+	proto.RegisterType((*DeadLetter)(nil), "DeadLetter")
+	proto.RegisterType((*RetryDeadLetterResponse)(nil), "RetryDeadLetterResponse")
+	proto.RegisterType((*DiscardDeadLetterResponse)(nil), "DiscardDeadLetterResponse")
+	proto.RegisterType((*CursorRequest)(nil), "CursorRequest")
+	proto.RegisterType((*Cursor)(nil), "Cursor")
+	proto.RegisterType((*SetStartBlockRequest)(nil), "SetStartBlockRequest")
+	proto.RegisterType((*ReplayBlocksRequest)(nil), "ReplayBlocksRequest")
+	proto.RegisterType((*ReplayBlocksResponse)(nil), "ReplayBlocksResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "GetBlockRequest")
+	proto.RegisterType((*BlockRecord)(nil), "BlockRecord")
+	proto.RegisterType((*WalkBlocksRequest)(nil), "WalkBlocksRequest")
+	proto.RegisterType((*WalkBlocksResponse)(nil), "WalkBlocksResponse")
+	proto.RegisterType((*GetTransactionRequest)(nil), "GetTransactionRequest")
+	proto.RegisterType((*TransactionRecord)(nil), "TransactionRecord")
+	proto.RegisterType((*ListCrossChainRequestsRequest)(nil), "ListCrossChainRequestsRequest")
+	proto.RegisterType((*ListCrossChainRequestsResponse)(nil), "ListCrossChainRequestsResponse")
+	proto.RegisterType((*CrossChainRequestRecord)(nil), "CrossChainRequestRecord")
+	proto.RegisterType((*ListInboundExecutionsRequest)(nil), "ListInboundExecutionsRequest")
+	proto.RegisterType((*ListInboundExecutionsResponse)(nil), "ListInboundExecutionsResponse")
+	proto.RegisterType((*ResolveInboundExecutionRequest)(nil), "ResolveInboundExecutionRequest")
+	proto.RegisterType((*InboundExecution)(nil), "InboundExecution")
+}
+
+func init() { proto.RegisterFile("pkg/protos/admin.proto", fileDescriptor_admin_7f63af2a7318c1c8) }
+
+var fileDescriptor_admin_7f63af2a7318c1c8 = []byte{
+	// 1402 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xeb, 0x8e, 0xdb, 0xc4,
+	0x17, 0x8f, 0xe3, 0x5c, 0x4f, 0x36, 0x7b, 0x99, 0xee, 0xc5, 0x7f, 0xff, 0xb7, 0xdb, 0x68, 0xa8,
+	0xaa, 0x48, 0x50, 0x57, 0x2d, 0x48, 0x7c, 0x02, 0x69, 0xbb, 0x29, 0xdb, 0x45, 0x55, 0x8b, 0xdc,
+	0x4a, 0x45, 0x20, 0x81, 0x26, 0xf1, 0x6c, 0xd7, 0x5a, 0xc7, 0x0e, 0x9e, 0x49, 0xbb, 0x7d, 0x01,
+	0x5e, 0x01, 0xf1, 0x1a, 0xf0, 0x18, 0x7c, 0xe7, 0x1b, 0x6f, 0xc0, 0x2b, 0x20, 0xa1, 0x19, 0x4f,
+	0xec, 0xf1, 0x25, 0xd9, 0x54, 0x14, 0xbe, 0xf9, 0xfc, 0xce, 0x64, 0xce, 0xcc, 0xb9, 0xcd, 0xef,
+	0x04, 0xf6, 0x67, 0x97, 0xaf, 0xee, 0xcd, 0xe2, 0x88, 0x47, 0xec, 0x1e, 0xf1, 0xa6, 0x7e, 0xe8,
+	0x48, 0x01, 0x7b, 0xb0, 0xff, 0xc4, 0x67, 0x7c, 0x44, 0x89, 0xf7, 0x84, 0x72, 0x4e, 0x63, 0xe6,
+	0xd2, 0x1f, 0xe6, 0x94, 0x71, 0x74, 0x08, 0xdd, 0xc9, 0x05, 0x09, 0x43, 0x1a, 0x9c, 0x8d, 0x2c,
+	0x63, 0x60, 0x0c, 0xbb, 0x6e, 0x06, 0xa0, 0x7d, 0x68, 0x45, 0xe7, 0xe7, 0x8c, 0x72, 0xab, 0x3e,
+	0x30, 0x86, 0x7d, 0x57, 0x49, 0x68, 0x17, 0x9a, 0x81, 0x3f, 0xf5, 0xb9, 0x65, 0x4a, 0x38, 0x11,
+	0xf0, 0x63, 0x38, 0x28, 0x59, 0x61, 0xb3, 0x28, 0x64, 0x14, 0xdd, 0x85, 0x9e, 0x97, 0xc1, 0x96,
+	0x31, 0x30, 0x87, 0xbd, 0x07, 0x3d, 0x27, 0x5b, 0xea, 0xea, 0x7a, 0xfc, 0x01, 0xec, 0x68, 0x2a,
+	0x75, 0xd4, 0x4d, 0xa8, 0xfb, 0x9e, 0x3c, 0xa3, 0xe9, 0xd6, 0x7d, 0x0f, 0xff, 0x62, 0x02, 0x64,
+	0xab, 0x8a, 0xea, 0xfc, 0xcd, 0xea, 0xc5, 0x9b, 0x21, 0x68, 0x9c, 0xc7, 0xd1, 0x54, 0x5e, 0xa0,
+	0xeb, 0xca, 0x6f, 0xb1, 0x03, 0x8f, 0xac, 0x86, 0x44, 0xea, 0x3c, 0x42, 0xb7, 0xa1, 0xcf, 0x63,
+	0x12, 0x32, 0x32, 0xe1, 0x7e, 0x14, 0x9e, 0x8d, 0xac, 0xa6, 0x54, 0xe5, 0x41, 0xe1, 0x23, 0xc6,
+	0xe9, 0xec, 0x6c, 0x64, 0xb5, 0xa4, 0x5a, 0x49, 0x68, 0x08, 0x5b, 0xda, 0xc2, 0xc7, 0x84, 0x5d,
+	0x58, 0x6d, 0xb9, 0xa0, 0x08, 0xa3, 0x01, 0xf4, 0xc6, 0x41, 0x34, 0xb9, 0x7c, 0x3a, 0x9f, 0x8e,
+	0x69, 0x6c, 0x75, 0x06, 0xc6, 0xb0, 0xe1, 0xea, 0x10, 0xb2, 0xa1, 0x43, 0x38, 0xa7, 0xd3, 0x19,
+	0x67, 0x56, 0x57, 0xba, 0x3c, 0x95, 0x85, 0x7d, 0x1a, 0xc7, 0x51, 0xcc, 0x2c, 0x18, 0x98, 0xc2,
+	0x7e, 0x22, 0x89, 0xfb, 0xcf, 0x68, 0x3c, 0x25, 0x21, 0x0d, 0xb9, 0xd5, 0x1b, 0x18, 0xc3, 0x8e,
+	0x9b, 0x01, 0xc2, 0xe6, 0x05, 0x49, 0xe3, 0x63, 0x6d, 0x48, 0xbd, 0x0e, 0x49, 0xff, 0xc5, 0x94,
+	0x70, 0xea, 0x1d, 0x73, 0xab, 0x2f, 0xdd, 0x9a, 0x01, 0x42, 0x3b, 0x9f, 0x79, 0x4a, 0xbb, 0x99,
+	0x68, 0x53, 0x00, 0x59, 0xd0, 0x8e, 0x93, 0xa8, 0x59, 0x5b, 0x03, 0x63, 0xb8, 0xe1, 0x2e, 0x44,
+	0xec, 0xc1, 0x81, 0x4b, 0x79, 0xfc, 0x56, 0x0f, 0x6f, 0x66, 0x90, 0xcd, 0x27, 0x13, 0x4a, 0x3d,
+	0x9a, 0xc4, 0xb1, 0xe3, 0x66, 0x00, 0xfa, 0x10, 0x20, 0xcb, 0x10, 0x19, 0xcf, 0x42, 0x02, 0x69,
+	0x6a, 0xfc, 0x7f, 0xf8, 0xdf, 0xc8, 0x67, 0x13, 0x12, 0x7b, 0x65, 0x3b, 0xf8, 0x2e, 0xf4, 0x4f,
+	0xe6, 0x31, 0x8b, 0xe2, 0xb5, 0x6a, 0x00, 0x8f, 0xa0, 0x95, 0x2c, 0xbf, 0xa6, 0x56, 0x0e, 0xa1,
+	0x1b, 0xd2, 0x2b, 0xfe, 0x50, 0x84, 0x4d, 0x9e, 0xaf, 0xe1, 0x66, 0x00, 0x0e, 0x61, 0xf7, 0x39,
+	0xe5, 0xcf, 0x39, 0x89, 0x13, 0x60, 0xbd, 0xfa, 0x2b, 0x64, 0x46, 0xbd, 0x9c, 0x19, 0xfb, 0xd0,
+	0x0a, 0x08, 0x17, 0x8e, 0x36, 0xa5, 0xc7, 0x94, 0x84, 0x2f, 0xe1, 0x86, 0x4b, 0x67, 0x01, 0x79,
+	0x2b, 0xad, 0xad, 0x59, 0xee, 0x87, 0xd0, 0x15, 0x85, 0x90, 0xbb, 0x42, 0x0a, 0x88, 0xa0, 0xf2,
+	0x28, 0xd1, 0x99, 0x52, 0xb7, 0x10, 0xf1, 0x97, 0xb0, 0x9b, 0x37, 0xa6, 0x22, 0xba, 0x0f, 0x2d,
+	0x79, 0x56, 0x26, 0x4d, 0xf5, 0x5d, 0x25, 0x89, 0x74, 0x56, 0xf9, 0xc0, 0x54, 0x63, 0x49, 0x65,
+	0xfc, 0x93, 0x01, 0x5b, 0xa7, 0xf4, 0xfd, 0x3a, 0xe9, 0x10, 0xba, 0x52, 0x94, 0x45, 0x98, 0x54,
+	0x7c, 0x06, 0xa0, 0x3b, 0xb0, 0xf9, 0xc6, 0xe7, 0x17, 0xcf, 0x62, 0xff, 0x95, 0x1f, 0x9e, 0x85,
+	0xe7, 0x49, 0x0b, 0xe8, 0xb8, 0x05, 0x14, 0xff, 0x65, 0x40, 0x4f, 0x1d, 0x6b, 0x12, 0xc5, 0x5e,
+	0xd1, 0xae, 0x71, 0x8d, 0xdd, 0x7a, 0xd1, 0x2e, 0x86, 0x8d, 0x59, 0x4c, 0x5f, 0xfb, 0xd1, 0x9c,
+	0x69, 0x07, 0xcb, 0x61, 0xc2, 0x53, 0x22, 0x87, 0xa4, 0x3e, 0x69, 0x4c, 0xa9, 0x2c, 0x74, 0x1e,
+	0xe1, 0x44, 0xea, 0x92, 0xce, 0x94, 0xca, 0xa9, 0xe5, 0x17, 0xfe, 0x94, 0xca, 0xbe, 0x64, 0xba,
+	0x19, 0x20, 0xda, 0x37, 0xbf, 0x7a, 0x3a, 0x9f, 0xca, 0x86, 0xd4, 0x77, 0x13, 0x01, 0x1d, 0x01,
+	0x44, 0x99, 0x0f, 0x3a, 0x72, 0x47, 0x0d, 0xc1, 0xbf, 0x19, 0xb0, 0xf3, 0x92, 0x04, 0x97, 0xef,
+	0x92, 0x51, 0xff, 0x34, 0x36, 0x36, 0x74, 0xc6, 0x64, 0x72, 0xf9, 0x86, 0xc4, 0x9e, 0x8a, 0x4a,
+	0x2a, 0x67, 0x8f, 0x50, 0x53, 0x7b, 0x84, 0x2a, 0xa2, 0xd9, 0xaa, 0x8c, 0xe6, 0x77, 0x80, 0xf4,
+	0xcb, 0xa8, 0x8c, 0xbd, 0xad, 0x65, 0xac, 0x78, 0xa2, 0x36, 0x1c, 0x2d, 0xe2, 0x69, 0xfe, 0x62,
+	0xd8, 0x10, 0x51, 0xf8, 0x8a, 0xbc, 0xa2, 0x5a, 0x68, 0x73, 0x18, 0xfe, 0x1e, 0xf6, 0x4e, 0x29,
+	0x7f, 0x91, 0xb5, 0xfa, 0xf5, 0x1c, 0x56, 0xf1, 0x6a, 0xd4, 0x2b, 0x5f, 0x0d, 0xfc, 0x73, 0x1d,
+	0x76, 0x72, 0xdb, 0xcb, 0xa4, 0xfc, 0x77, 0xc3, 0x51, 0x71, 0xba, 0x46, 0xf5, 0x9b, 0x76, 0x07,
+	0x36, 0x5f, 0x93, 0xc0, 0xf7, 0x88, 0x40, 0x4e, 0x22, 0x8f, 0xaa, 0x14, 0x2d, 0xa0, 0x85, 0xa4,
+	0x6b, 0x15, 0x93, 0x0e, 0x7d, 0xa2, 0xb5, 0x8a, 0xb6, 0x0c, 0x89, 0xe5, 0x9c, 0xc4, 0x11, 0x63,
+	0x27, 0x17, 0xc4, 0x5f, 0xf8, 0x54, 0x85, 0x27, 0x6b, 0x22, 0x7f, 0x18, 0x70, 0x53, 0x50, 0x91,
+	0xd2, 0xca, 0x35, 0xd3, 0x76, 0xc1, 0x0e, 0xea, 0x25, 0x76, 0x60, 0xa6, 0xec, 0x60, 0x17, 0x9a,
+	0x8c, 0x13, 0x4e, 0x95, 0x07, 0x12, 0x41, 0xec, 0xcb, 0x44, 0x93, 0x97, 0x85, 0xd7, 0x4c, 0x0a,
+	0x2f, 0x05, 0x44, 0x0b, 0xa5, 0xa1, 0xa7, 0x15, 0xe5, 0x42, 0xd4, 0x98, 0x56, 0xbb, 0x9a, 0x69,
+	0x75, 0x74, 0xa6, 0x15, 0xc0, 0xd1, 0xb2, 0xeb, 0xa9, 0x44, 0xd6, 0xfd, 0x66, 0xac, 0xeb, 0x37,
+	0x61, 0x8d, 0x47, 0x9c, 0x04, 0xaa, 0x2b, 0x27, 0x02, 0xfe, 0xb5, 0x01, 0x07, 0x4b, 0x7e, 0xfb,
+	0x1e, 0xfc, 0x58, 0x62, 0x59, 0x8d, 0xd5, 0x2c, 0xab, 0x79, 0x1d, 0xcb, 0x6a, 0xad, 0xc5, 0xb2,
+	0xda, 0xe5, 0xdc, 0x4f, 0x23, 0xda, 0xd1, 0x23, 0xba, 0x0b, 0x4d, 0xc9, 0xa8, 0x24, 0xf1, 0xea,
+	0xba, 0x89, 0x50, 0xe4, 0x4f, 0x50, 0xe6, 0x4f, 0x47, 0x82, 0xb0, 0x70, 0x3a, 0x49, 0x28, 0x52,
+	0x4f, 0x86, 0x5b, 0x43, 0xe4, 0x8d, 0x68, 0xc8, 0x8f, 0xb9, 0x24, 0x5f, 0xa6, 0xab, 0x24, 0xb1,
+	0x73, 0x2c, 0xf7, 0xf0, 0x34, 0xe6, 0xa5, 0x43, 0xe2, 0xce, 0x31, 0x65, 0xf3, 0x80, 0xbf, 0x8c,
+	0x7d, 0xce, 0x69, 0x98, 0x32, 0xb0, 0x22, 0x2c, 0xaa, 0x70, 0x42, 0x82, 0x40, 0xb4, 0xcc, 0x51,
+	0x14, 0xd2, 0xe3, 0x84, 0x8e, 0x99, 0x6e, 0x01, 0x15, 0x6d, 0xf6, 0x9c, 0xf8, 0x81, 0x34, 0xb8,
+	0x2d, 0x57, 0xa4, 0x72, 0x9e, 0xe9, 0xed, 0xac, 0x60, 0x7a, 0x48, 0xfa, 0x67, 0x21, 0xe2, 0x31,
+	0x1c, 0x8a, 0x1c, 0x3d, 0x0b, 0xc7, 0xd1, 0x3c, 0xf4, 0x1e, 0x5d, 0xd1, 0xc9, 0x5c, 0xc4, 0x22,
+	0xad, 0xc0, 0xd4, 0xdb, 0x86, 0xee, 0xed, 0x77, 0x9b, 0x38, 0x5c, 0xb8, 0xb9, 0xc4, 0x86, 0x0a,
+	0xc2, 0x7d, 0x00, 0x9a, 0xa2, 0xaa, 0x10, 0x76, 0x9c, 0xe2, 0x7a, 0x57, 0x5b, 0x84, 0x7f, 0x34,
+	0xe0, 0xc8, 0xa5, 0x2c, 0x0a, 0x5e, 0xd3, 0xd2, 0x3a, 0x75, 0xf4, 0x45, 0x5a, 0x1b, 0x5a, 0x5a,
+	0x97, 0xd2, 0xb8, 0xbe, 0x3a, 0x8d, 0xcd, 0x5c, 0x1a, 0x23, 0x68, 0xf0, 0xab, 0x34, 0xf7, 0xe5,
+	0x37, 0xfe, 0xd3, 0x80, 0xed, 0xe2, 0x09, 0xd0, 0x36, 0x98, 0x97, 0xf4, 0xad, 0xb2, 0x2c, 0x3e,
+	0xff, 0x83, 0x1a, 0x4b, 0x23, 0xd5, 0xd2, 0x23, 0xb5, 0x38, 0x72, 0x3b, 0x3b, 0x72, 0x7e, 0x66,
+	0xe8, 0xac, 0x9c, 0x19, 0xba, 0x85, 0x4c, 0x7a, 0xf0, 0x7b, 0x0b, 0x9a, 0xc7, 0x62, 0x66, 0x45,
+	0x5f, 0xc0, 0x56, 0x61, 0x8e, 0x44, 0x07, 0x4e, 0xf5, 0xfc, 0x6a, 0x5b, 0xce, 0x92, 0x91, 0x13,
+	0xd7, 0xd0, 0x03, 0xe8, 0x9f, 0x52, 0x4d, 0x87, 0x90, 0x53, 0x9a, 0x2a, 0x6d, 0x7d, 0x86, 0xc0,
+	0x35, 0x74, 0x0c, 0x5b, 0x85, 0xf9, 0xa4, 0xf2, 0x57, 0x96, 0xb3, 0x64, 0x8a, 0xc1, 0x35, 0xf4,
+	0x08, 0x76, 0x4a, 0xc3, 0x47, 0xe5, 0x26, 0xb6, 0xb3, 0x7c, 0x48, 0xa9, 0xa1, 0x3b, 0xd0, 0x3d,
+	0xa5, 0x5c, 0x8d, 0x1e, 0x9b, 0x4e, 0x6e, 0x64, 0xb1, 0xdb, 0x4a, 0xc6, 0x35, 0x74, 0x1f, 0xfa,
+	0xb9, 0xc9, 0x02, 0xed, 0x39, 0x55, 0x93, 0x86, 0xfe, 0x93, 0xcf, 0x60, 0x43, 0xe7, 0xeb, 0x68,
+	0xd7, 0xa9, 0x98, 0x15, 0xec, 0x3d, 0xa7, 0x8a, 0xd4, 0xe3, 0x1a, 0xfa, 0x08, 0x3a, 0x0b, 0x86,
+	0x8e, 0xb6, 0x9d, 0x02, 0x59, 0xb7, 0x73, 0x94, 0x09, 0xd7, 0xd0, 0xa7, 0x00, 0x19, 0xd1, 0x42,
+	0xc8, 0x29, 0x51, 0x48, 0xfb, 0x86, 0x53, 0x66, 0x62, 0xb8, 0x86, 0x3e, 0x87, 0xcd, 0x3c, 0x83,
+	0x42, 0xfb, 0x4e, 0x25, 0xa5, 0xb2, 0x91, 0x53, 0x22, 0x42, 0xb8, 0x86, 0xbe, 0x4d, 0xfe, 0xf4,
+	0x28, 0x3f, 0x92, 0xe8, 0xc8, 0x59, 0x49, 0x0e, 0xec, 0x5b, 0xce, 0xea, 0xd7, 0x15, 0xd7, 0xd0,
+	0xd7, 0xb0, 0x57, 0xd9, 0x79, 0xd0, 0x4d, 0x67, 0x55, 0xd7, 0xb3, 0x8f, 0x9c, 0x95, 0x0d, 0x0b,
+	0xd7, 0xd0, 0x33, 0x38, 0x58, 0xd2, 0x7e, 0xd0, 0x2d, 0x67, 0x75, 0x63, 0xb2, 0xcb, 0xad, 0x0d,
+	0xd7, 0x1e, 0x6e, 0x7d, 0xd3, 0xd7, 0xfe, 0x16, 0x9a, 0x8d, 0xc7, 0x2d, 0xf9, 0xf9, 0xf1, 0xdf,
+	0x03, 0x00, 0x23, 0x2a, 0x36, 0x8d, 0x2e, 0x12, 0x00, 0x00,
+}
@@ -19,13 +19,21 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type NoTransactionCallRequest struct {
-	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To                   string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	TransactionID        string   `protobuf:"bytes,3,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
-	StepID               string   `protobuf:"bytes,4,opt,name=stepID,proto3" json:"stepID,omitempty"`
-	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signer               []byte   `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
-	Timestamp            int64    `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	From          string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TransactionID string `protobuf:"bytes,3,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	StepID        string `protobuf:"bytes,4,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	Payload       []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signer        []byte `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
+	Timestamp     int64  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// 签名密钥标识, 即签名公钥 SKI 的十六进制编码
+	KeyID string `protobuf:"bytes,8,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	// 签名算法, 如 SM2、ECDSA
+	Algorithm string `protobuf:"bytes,9,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// 来源链的交易包含证明
+	Proof *InclusionProof `protobuf:"bytes,10,opt,name=proof,proto3" json:"proof,omitempty"`
+	// 请求超时时间, 毫秒, 为 0 时使用调用方的截止时间或 Fabric SDK 的默认超时
+	Timeout              int64    `protobuf:"varint,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -35,7 +43,7 @@ func (m *NoTransactionCallRequest) Reset()         { *m = NoTransactionCallReque
 func (m *NoTransactionCallRequest) String() string { return proto.CompactTextString(m) }
 func (*NoTransactionCallRequest) ProtoMessage()    {}
 func (*NoTransactionCallRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{0}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{0}
 }
 func (m *NoTransactionCallRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NoTransactionCallRequest.Unmarshal(m, b)
@@ -104,6 +112,101 @@ func (m *NoTransactionCallRequest) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *NoTransactionCallRequest) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *NoTransactionCallRequest) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *NoTransactionCallRequest) GetProof() *InclusionProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *NoTransactionCallRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type InclusionProof struct {
+	// 交易所在区块的区块头
+	Header *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// 区块中全部交易信封, 拼接后的哈希即区块头的 DataHash
+	Data [][]byte `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	// 跨链交易在区块中的序号, 即 data[txIndex] 为该交易的信封
+	TxIndex uint32 `protobuf:"varint,3,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	// 交易验证码, 来自区块元数据 TRANSACTIONS_FILTER, 不受区块头哈希保护,
+	// 仅供参考, 核实时以头链同步的 TRANSACTIONS_FILTER 为准
+	ValidationCode       int32    `protobuf:"varint,4,opt,name=validationCode,proto3" json:"validationCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InclusionProof) Reset()         { *m = InclusionProof{} }
+func (m *InclusionProof) String() string { return proto.CompactTextString(m) }
+func (*InclusionProof) ProtoMessage()    {}
+func (*InclusionProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{1}
+}
+func (m *InclusionProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InclusionProof.Unmarshal(m, b)
+}
+func (m *InclusionProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InclusionProof.Marshal(b, m, deterministic)
+}
+func (dst *InclusionProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InclusionProof.Merge(dst, src)
+}
+func (m *InclusionProof) XXX_Size() int {
+	return xxx_messageInfo_InclusionProof.Size(m)
+}
+func (m *InclusionProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_InclusionProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InclusionProof proto.InternalMessageInfo
+
+func (m *InclusionProof) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *InclusionProof) GetData() [][]byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InclusionProof) GetTxIndex() uint32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *InclusionProof) GetValidationCode() int32 {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return 0
+}
+
 type FabricPayloadRequest struct {
 	ChannelName          string          `protobuf:"bytes,1,opt,name=channelName,proto3" json:"channelName,omitempty"`
 	ChainCodeName        string          `protobuf:"bytes,2,opt,name=chainCodeName,proto3" json:"chainCodeName,omitempty"`
@@ -119,7 +222,7 @@ func (m *FabricPayloadRequest) Reset()         { *m = FabricPayloadRequest{} }
 func (m *FabricPayloadRequest) String() string { return proto.CompactTextString(m) }
 func (*FabricPayloadRequest) ProtoMessage()    {}
 func (*FabricPayloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{1}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{2}
 }
 func (m *FabricPayloadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricPayloadRequest.Unmarshal(m, b)
@@ -188,7 +291,7 @@ func (m *FabricCallback) Reset()         { *m = FabricCallback{} }
 func (m *FabricCallback) String() string { return proto.CompactTextString(m) }
 func (*FabricCallback) ProtoMessage()    {}
 func (*FabricCallback) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{2}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{3}
 }
 func (m *FabricCallback) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricCallback.Unmarshal(m, b)
@@ -249,7 +352,7 @@ func (m *StartTransactionRequest) Reset()         { *m = StartTransactionRequest
 func (m *StartTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*StartTransactionRequest) ProtoMessage()    {}
 func (*StartTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{3}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{4}
 }
 func (m *StartTransactionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StartTransactionRequest.Unmarshal(m, b)
@@ -307,7 +410,7 @@ func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{}
 func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*SendTransactionRequest) ProtoMessage()    {}
 func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{4}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{5}
 }
 func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
@@ -388,7 +491,7 @@ func (m *CommitTransactionRequest) Reset()         { *m = CommitTransactionReque
 func (m *CommitTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*CommitTransactionRequest) ProtoMessage()    {}
 func (*CommitTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{5}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{6}
 }
 func (m *CommitTransactionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommitTransactionRequest.Unmarshal(m, b)
@@ -427,20 +530,29 @@ type CommonResponseMessage struct {
 	To            string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	TransactionID string `protobuf:"bytes,3,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
 	// 用于区别一个事务多个操作
-	StepID               string   `protobuf:"bytes,4,opt,name=stepID,proto3" json:"stepID,omitempty"`
-	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signer               []byte   `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
-	Callback             []byte   `protobuf:"bytes,7,opt,name=callback,proto3" json:"callback,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	StepID   string `protobuf:"bytes,4,opt,name=stepID,proto3" json:"stepID,omitempty"`
+	Payload  []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signer   []byte `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
+	Callback []byte `protobuf:"bytes,7,opt,name=callback,proto3" json:"callback,omitempty"`
+	// 签名密钥标识, 即签名公钥 SKI 的十六进制编码
+	KeyID string `protobuf:"bytes,8,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	// 签名算法, 如 SM2、ECDSA
+	Algorithm string `protobuf:"bytes,9,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// 各组织签名身份对 from、to、transactionID、stepID 与 payload 摘要的签名, 用于门限校验,
+	// 摘要的计算见 client.AttestationDigest
+	Signatures []*Signature `protobuf:"bytes,10,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	// 目的链的背书证明, 可脱离网关独立核实执行结果
+	Proof                *EndorsementProof `protobuf:"bytes,11,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CommonResponseMessage) Reset()         { *m = CommonResponseMessage{} }
 func (m *CommonResponseMessage) String() string { return proto.CompactTextString(m) }
 func (*CommonResponseMessage) ProtoMessage()    {}
 func (*CommonResponseMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hub_7f50494d4c4514e7, []int{6}
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{7}
 }
 func (m *CommonResponseMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommonResponseMessage.Unmarshal(m, b)
@@ -509,52 +621,748 @@ func (m *CommonResponseMessage) GetCallback() []byte {
 	return nil
 }
 
+func (m *CommonResponseMessage) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *CommonResponseMessage) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *CommonResponseMessage) GetSignatures() []*Signature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *CommonResponseMessage) GetProof() *EndorsementProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+type Signature struct {
+	// 签名身份名称, 一般为组织名称
+	Signer               string   `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	KeyID                string   `protobuf:"bytes,2,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	Algorithm            string   `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Signature) Reset()         { *m = Signature{} }
+func (m *Signature) String() string { return proto.CompactTextString(m) }
+func (*Signature) ProtoMessage()    {}
+func (*Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{8}
+}
+func (m *Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signature.Unmarshal(m, b)
+}
+func (m *Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signature.Marshal(b, m, deterministic)
+}
+func (dst *Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signature.Merge(dst, src)
+}
+func (m *Signature) XXX_Size() int {
+	return xxx_messageInfo_Signature.Size(m)
+}
+func (m *Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signature proto.InternalMessageInfo
+
+func (m *Signature) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+func (m *Signature) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *Signature) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *Signature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type EndorsementProof struct {
+	TxID string `protobuf:"bytes,1,opt,name=txID,proto3" json:"txID,omitempty"`
+	// 交易所在区块号, 由网关提供, 未经核实
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	// 交易验证码, 0 表示 VALID, 由网关提供, 未经提交证据核实
+	ValidationCode int32          `protobuf:"varint,3,opt,name=validationCode,proto3" json:"validationCode,omitempty"`
+	Endorsements   []*Endorsement `protobuf:"bytes,4,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
+	// 序列化的提案 common.Header, 其通道头中的交易 ID 即 txID
+	Header []byte `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"`
+	// 去除 TransientMap 后序列化的 ChaincodeProposalPayload,
+	// 与 header 一同计算提案哈希, 将背书绑定到 txID
+	ProposalPayload      []byte   `protobuf:"bytes,6,opt,name=proposalPayload,proto3" json:"proposalPayload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EndorsementProof) Reset()         { *m = EndorsementProof{} }
+func (m *EndorsementProof) String() string { return proto.CompactTextString(m) }
+func (*EndorsementProof) ProtoMessage()    {}
+func (*EndorsementProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{9}
+}
+func (m *EndorsementProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementProof.Unmarshal(m, b)
+}
+func (m *EndorsementProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementProof.Marshal(b, m, deterministic)
+}
+func (dst *EndorsementProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementProof.Merge(dst, src)
+}
+func (m *EndorsementProof) XXX_Size() int {
+	return xxx_messageInfo_EndorsementProof.Size(m)
+}
+func (m *EndorsementProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementProof proto.InternalMessageInfo
+
+func (m *EndorsementProof) GetTxID() string {
+	if m != nil {
+		return m.TxID
+	}
+	return ""
+}
+
+func (m *EndorsementProof) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *EndorsementProof) GetValidationCode() int32 {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return 0
+}
+
+func (m *EndorsementProof) GetEndorsements() []*Endorsement {
+	if m != nil {
+		return m.Endorsements
+	}
+	return nil
+}
+
+func (m *EndorsementProof) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *EndorsementProof) GetProposalPayload() []byte {
+	if m != nil {
+		return m.ProposalPayload
+	}
+	return nil
+}
+
+type Endorsement struct {
+	// 背书节点身份, 即序列化的 msp.SerializedIdentity
+	Endorser []byte `protobuf:"bytes,1,opt,name=endorser,proto3" json:"endorser,omitempty"`
+	// 背书节点对 payload 与 endorser 拼接后的签名
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// 序列化的 ProposalResponsePayload
+	Payload              []byte   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Endorsement) Reset()         { *m = Endorsement{} }
+func (m *Endorsement) String() string { return proto.CompactTextString(m) }
+func (*Endorsement) ProtoMessage()    {}
+func (*Endorsement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{10}
+}
+func (m *Endorsement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
+}
+func (m *Endorsement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endorsement.Marshal(b, m, deterministic)
+}
+func (dst *Endorsement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endorsement.Merge(dst, src)
+}
+func (m *Endorsement) XXX_Size() int {
+	return xxx_messageInfo_Endorsement.Size(m)
+}
+func (m *Endorsement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endorsement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endorsement proto.InternalMessageInfo
+
+func (m *Endorsement) GetEndorser() []byte {
+	if m != nil {
+		return m.Endorser
+	}
+	return nil
+}
+
+func (m *Endorsement) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Endorsement) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type SyncBlockHeadersRequest struct {
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	// 起始区块号
+	StartNumber uint64 `protobuf:"varint,2,opt,name=startNumber,proto3" json:"startNumber,omitempty"`
+	// 最多返回的区块头数量, 为 0 时使用服务端默认值
+	Limit                uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncBlockHeadersRequest) Reset()         { *m = SyncBlockHeadersRequest{} }
+func (m *SyncBlockHeadersRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncBlockHeadersRequest) ProtoMessage()    {}
+func (*SyncBlockHeadersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{11}
+}
+func (m *SyncBlockHeadersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncBlockHeadersRequest.Unmarshal(m, b)
+}
+func (m *SyncBlockHeadersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncBlockHeadersRequest.Marshal(b, m, deterministic)
+}
+func (dst *SyncBlockHeadersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncBlockHeadersRequest.Merge(dst, src)
+}
+func (m *SyncBlockHeadersRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncBlockHeadersRequest.Size(m)
+}
+func (m *SyncBlockHeadersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncBlockHeadersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncBlockHeadersRequest proto.InternalMessageInfo
+
+func (m *SyncBlockHeadersRequest) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *SyncBlockHeadersRequest) GetStartNumber() uint64 {
+	if m != nil {
+		return m.StartNumber
+	}
+	return 0
+}
+
+func (m *SyncBlockHeadersRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SyncBlockHeadersResponse struct {
+	ChannelID string         `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Headers   []*BlockHeader `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	// 通道当前的区块高度
+	Height               uint64   `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncBlockHeadersResponse) Reset()         { *m = SyncBlockHeadersResponse{} }
+func (m *SyncBlockHeadersResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncBlockHeadersResponse) ProtoMessage()    {}
+func (*SyncBlockHeadersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{12}
+}
+func (m *SyncBlockHeadersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncBlockHeadersResponse.Unmarshal(m, b)
+}
+func (m *SyncBlockHeadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncBlockHeadersResponse.Marshal(b, m, deterministic)
+}
+func (dst *SyncBlockHeadersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncBlockHeadersResponse.Merge(dst, src)
+}
+func (m *SyncBlockHeadersResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncBlockHeadersResponse.Size(m)
+}
+func (m *SyncBlockHeadersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncBlockHeadersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncBlockHeadersResponse proto.InternalMessageInfo
+
+func (m *SyncBlockHeadersResponse) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *SyncBlockHeadersResponse) GetHeaders() []*BlockHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *SyncBlockHeadersResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type BlockHeader struct {
+	Number       uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	PreviousHash []byte `protobuf:"bytes,2,opt,name=previousHash,proto3" json:"previousHash,omitempty"`
+	DataHash     []byte `protobuf:"bytes,3,opt,name=dataHash,proto3" json:"dataHash,omitempty"`
+	// 区块签名元数据, 即序列化的 common.Metadata, 用于核实排序节点签名
+	Signatures []byte `protobuf:"bytes,4,opt,name=signatures,proto3" json:"signatures,omitempty"`
+	// 区块元数据 TRANSACTIONS_FILTER, 即各交易的验证码, 随区块头同步后用于核实包含证明
+	TransactionsFilter   []byte   `protobuf:"bytes,5,opt,name=transactionsFilter,proto3" json:"transactionsFilter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
+func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
+func (*BlockHeader) ProtoMessage()    {}
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{13}
+}
+func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
+}
+func (m *BlockHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeader.Marshal(b, m, deterministic)
+}
+func (dst *BlockHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeader.Merge(dst, src)
+}
+func (m *BlockHeader) XXX_Size() int {
+	return xxx_messageInfo_BlockHeader.Size(m)
+}
+func (m *BlockHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeader proto.InternalMessageInfo
+
+func (m *BlockHeader) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetPreviousHash() []byte {
+	if m != nil {
+		return m.PreviousHash
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetDataHash() []byte {
+	if m != nil {
+		return m.DataHash
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetSignatures() []byte {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *BlockHeader) GetTransactionsFilter() []byte {
+	if m != nil {
+		return m.TransactionsFilter
+	}
+	return nil
+}
+
+type StatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
+func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()    {}
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{14}
+}
+func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusRequest.Unmarshal(m, b)
+}
+func (m *StatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *StatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequest.Merge(dst, src)
+}
+func (m *StatusRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusRequest.Size(m)
+}
+func (m *StatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
+
+type StatusResponse struct {
+	Remotes              []*RemoteHubStatus `protobuf:"bytes,1,rep,name=remotes,proto3" json:"remotes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
+func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()    {}
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{15}
+}
+func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusResponse.Unmarshal(m, b)
+}
+func (m *StatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *StatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponse.Merge(dst, src)
+}
+func (m *StatusResponse) XXX_Size() int {
+	return xxx_messageInfo_StatusResponse.Size(m)
+}
+func (m *StatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponse proto.InternalMessageInfo
+
+func (m *StatusResponse) GetRemotes() []*RemoteHubStatus {
+	if m != nil {
+		return m.Remotes
+	}
+	return nil
+}
+
+type RemoteHubStatus struct {
+	// 远端通道ID
+	ChannelID string `protobuf:"bytes,1,opt,name=channelID,proto3" json:"channelID,omitempty"`
+	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// 连接状态, 如 READY、TRANSIENT_FAILURE
+	Connectivity string `protobuf:"bytes,3,opt,name=connectivity,proto3" json:"connectivity,omitempty"`
+	// 熔断状态: closed、open、half-open, 未配置熔断器时为空
+	Breaker string `protobuf:"bytes,4,opt,name=breaker,proto3" json:"breaker,omitempty"`
+	// 连续失败次数
+	Failures uint32 `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	// 远端网关的各节点状态, address 与 connectivity 为最近一次选择的节点
+	Endpoints            []*RemoteEndpointStatus `protobuf:"bytes,6,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *RemoteHubStatus) Reset()         { *m = RemoteHubStatus{} }
+func (m *RemoteHubStatus) String() string { return proto.CompactTextString(m) }
+func (*RemoteHubStatus) ProtoMessage()    {}
+func (*RemoteHubStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{16}
+}
+func (m *RemoteHubStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoteHubStatus.Unmarshal(m, b)
+}
+func (m *RemoteHubStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoteHubStatus.Marshal(b, m, deterministic)
+}
+func (dst *RemoteHubStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteHubStatus.Merge(dst, src)
+}
+func (m *RemoteHubStatus) XXX_Size() int {
+	return xxx_messageInfo_RemoteHubStatus.Size(m)
+}
+func (m *RemoteHubStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteHubStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteHubStatus proto.InternalMessageInfo
+
+func (m *RemoteHubStatus) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+func (m *RemoteHubStatus) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RemoteHubStatus) GetConnectivity() string {
+	if m != nil {
+		return m.Connectivity
+	}
+	return ""
+}
+
+func (m *RemoteHubStatus) GetBreaker() string {
+	if m != nil {
+		return m.Breaker
+	}
+	return ""
+}
+
+func (m *RemoteHubStatus) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *RemoteHubStatus) GetEndpoints() []*RemoteEndpointStatus {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+type RemoteEndpointStatus struct {
+	Address      string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Connectivity string `protobuf:"bytes,2,opt,name=connectivity,proto3" json:"connectivity,omitempty"`
+	// 是否参与选择, 调用失败的节点会被摘除一段时间
+	Healthy  bool  `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Priority int32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Weight   int32 `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	// 连续调用失败次数
+	Failures             uint32   `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoteEndpointStatus) Reset()         { *m = RemoteEndpointStatus{} }
+func (m *RemoteEndpointStatus) String() string { return proto.CompactTextString(m) }
+func (*RemoteEndpointStatus) ProtoMessage()    {}
+func (*RemoteEndpointStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hub_a1a1e2e0d8252bed, []int{17}
+}
+func (m *RemoteEndpointStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoteEndpointStatus.Unmarshal(m, b)
+}
+func (m *RemoteEndpointStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoteEndpointStatus.Marshal(b, m, deterministic)
+}
+func (dst *RemoteEndpointStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteEndpointStatus.Merge(dst, src)
+}
+func (m *RemoteEndpointStatus) XXX_Size() int {
+	return xxx_messageInfo_RemoteEndpointStatus.Size(m)
+}
+func (m *RemoteEndpointStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteEndpointStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteEndpointStatus proto.InternalMessageInfo
+
+func (m *RemoteEndpointStatus) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RemoteEndpointStatus) GetConnectivity() string {
+	if m != nil {
+		return m.Connectivity
+	}
+	return ""
+}
+
+func (m *RemoteEndpointStatus) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *RemoteEndpointStatus) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *RemoteEndpointStatus) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *RemoteEndpointStatus) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*NoTransactionCallRequest)(nil), "NoTransactionCallRequest")
+	proto.RegisterType((*InclusionProof)(nil), "InclusionProof")
 	proto.RegisterType((*FabricPayloadRequest)(nil), "FabricPayloadRequest")
 	proto.RegisterType((*FabricCallback)(nil), "FabricCallback")
 	proto.RegisterType((*StartTransactionRequest)(nil), "StartTransactionRequest")
 	proto.RegisterType((*SendTransactionRequest)(nil), "SendTransactionRequest")
 	proto.RegisterType((*CommitTransactionRequest)(nil), "CommitTransactionRequest")
 	proto.RegisterType((*CommonResponseMessage)(nil), "CommonResponseMessage")
+	proto.RegisterType((*Signature)(nil), "Signature")
+	proto.RegisterType((*EndorsementProof)(nil), "EndorsementProof")
+	proto.RegisterType((*Endorsement)(nil), "Endorsement")
+	proto.RegisterType((*SyncBlockHeadersRequest)(nil), "SyncBlockHeadersRequest")
+	proto.RegisterType((*SyncBlockHeadersResponse)(nil), "SyncBlockHeadersResponse")
+	proto.RegisterType((*BlockHeader)(nil), "BlockHeader")
+	proto.RegisterType((*StatusRequest)(nil), "StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "StatusResponse")
+	proto.RegisterType((*RemoteHubStatus)(nil), "RemoteHubStatus")
+	proto.RegisterType((*RemoteEndpointStatus)(nil), "RemoteEndpointStatus")
 }
 
-func init() { proto.RegisterFile("pkg/protos/hub.proto", fileDescriptor_hub_7f50494d4c4514e7) }
-
-var fileDescriptor_hub_7f50494d4c4514e7 = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x55, 0x41, 0x6e, 0xd3, 0x40,
-	0x14, 0xc5, 0x76, 0x9a, 0x90, 0xdf, 0x26, 0xa1, 0x43, 0x9b, 0x4e, 0xab, 0x0a, 0x59, 0x16, 0x42,
-	0x91, 0x90, 0x5c, 0x54, 0xb8, 0x00, 0x38, 0x8a, 0x92, 0x05, 0x15, 0x72, 0x58, 0xb1, 0x40, 0x1a,
-	0x3b, 0xd3, 0xc4, 0xaa, 0xed, 0x71, 0x3d, 0xe3, 0x05, 0x0b, 0xce, 0xc3, 0x19, 0xb8, 0x00, 0x6c,
-	0x38, 0x0a, 0x87, 0x40, 0x9e, 0xd8, 0xb1, 0x1d, 0x3b, 0x01, 0x16, 0x48, 0xec, 0xfe, 0x7f, 0x6f,
-	0xe6, 0xcf, 0x7f, 0x7f, 0x9e, 0xc7, 0x70, 0x12, 0xdd, 0x2d, 0xaf, 0xa2, 0x98, 0x09, 0xc6, 0xaf,
-	0x56, 0x89, 0x63, 0xca, 0xd0, 0xf8, 0xa1, 0x00, 0xbe, 0x61, 0xef, 0x63, 0x12, 0x72, 0xe2, 0x0a,
-	0x8f, 0x85, 0x16, 0xf1, 0x7d, 0x9b, 0xde, 0x27, 0x94, 0x0b, 0x84, 0xa0, 0x75, 0x1b, 0xb3, 0x00,
-	0x2b, 0xba, 0x32, 0xea, 0xda, 0x32, 0x46, 0x7d, 0x50, 0x05, 0xc3, 0xaa, 0x44, 0x54, 0xc1, 0xd0,
-	0x53, 0xe8, 0x89, 0x62, 0xf7, 0x6c, 0x8c, 0x35, 0x49, 0x55, 0x41, 0x34, 0x84, 0x36, 0x17, 0x34,
-	0x9a, 0x8d, 0x71, 0x4b, 0xd2, 0x59, 0x86, 0x30, 0x74, 0x22, 0xf2, 0xc9, 0x67, 0x64, 0x81, 0x0f,
-	0x74, 0x65, 0x74, 0x64, 0xe7, 0xa9, 0xdc, 0xe1, 0x2d, 0x43, 0x1a, 0xe3, 0xb6, 0x24, 0xb2, 0x0c,
-	0x5d, 0x42, 0x57, 0x78, 0x01, 0xe5, 0x82, 0x04, 0x11, 0xee, 0xe8, 0xca, 0x48, 0xb3, 0x0b, 0xc0,
-	0xf8, 0xaa, 0xc0, 0xc9, 0x84, 0x38, 0xb1, 0xe7, 0xbe, 0x5b, 0xd7, 0xc9, 0xa5, 0xe8, 0x70, 0xe8,
-	0xae, 0x48, 0x18, 0x52, 0xff, 0x86, 0x04, 0x34, 0x53, 0x54, 0x86, 0x52, 0x21, 0xee, 0x8a, 0x78,
-	0xa1, 0xc5, 0x16, 0x54, 0xae, 0x59, 0x6b, 0xac, 0x82, 0x69, 0xc3, 0xb7, 0xa1, 0x2b, 0xf9, 0xb5,
-	0xd0, 0x3c, 0x4d, 0x87, 0x45, 0xe2, 0x25, 0xc7, 0x2d, 0x5d, 0x4b, 0x87, 0x95, 0xc6, 0xe8, 0x39,
-	0x3c, 0x74, 0x89, 0xef, 0x3b, 0xc4, 0xbd, 0x93, 0xfa, 0x0e, 0xaf, 0x07, 0xe6, 0xba, 0x3d, 0x2b,
-	0x83, 0xed, 0xcd, 0x02, 0xe3, 0x9b, 0x02, 0xfd, 0x2a, 0x89, 0x5e, 0xc0, 0xe3, 0x9c, 0xb6, 0x6a,
-	0xdd, 0x37, 0x51, 0xe8, 0x15, 0x9c, 0x96, 0xe0, 0x9a, 0x9a, 0x66, 0x12, 0x8d, 0x60, 0x90, 0x13,
-	0x93, 0x8a, 0xba, 0x6d, 0x18, 0x19, 0x70, 0x94, 0x43, 0xaf, 0x0b, 0xb5, 0x15, 0xcc, 0xf8, 0x0c,
-	0x67, 0x73, 0x41, 0x62, 0x51, 0x72, 0x55, 0x7e, 0x0d, 0x97, 0xd0, 0xcd, 0x66, 0x3e, 0x1b, 0x67,
-	0x32, 0x0a, 0xa0, 0xee, 0x25, 0xb5, 0xc9, 0x4b, 0x4f, 0x00, 0x36, 0x77, 0xc2, 0xb1, 0x26, 0x1b,
-	0x28, 0x21, 0xc6, 0x4f, 0x05, 0x86, 0x73, 0x1a, 0x2e, 0xfe, 0xfa, 0x78, 0x04, 0xad, 0x24, 0xf1,
-	0x16, 0xd9, 0xa9, 0x32, 0xfe, 0x43, 0x7b, 0x3f, 0x83, 0x7e, 0x09, 0x98, 0xd3, 0x7b, 0x69, 0xf3,
-	0x9e, 0xbd, 0x85, 0xd6, 0x3d, 0x76, 0xf0, 0x1b, 0x8f, 0xb5, 0x9b, 0x3d, 0xd6, 0x29, 0x3c, 0x66,
-	0x7c, 0x04, 0x6c, 0xb1, 0x20, 0xf0, 0xfe, 0xd1, 0xb8, 0x8d, 0xef, 0x0a, 0x9c, 0xa6, 0x07, 0xa4,
-	0x55, 0x79, 0xc4, 0x42, 0x4e, 0xdf, 0x52, 0xce, 0xc9, 0x92, 0xfe, 0x97, 0xcf, 0xc3, 0x45, 0xe9,
-	0x8b, 0xeb, 0x48, 0x66, 0x93, 0x5f, 0x7f, 0x51, 0x41, 0x9b, 0x26, 0x0e, 0x9a, 0xc2, 0x71, 0xed,
-	0xc9, 0x43, 0xe7, 0xe6, 0xae, 0x67, 0xf0, 0x62, 0x68, 0x36, 0xea, 0x37, 0x1e, 0xa0, 0x09, 0x3c,
-	0xda, 0x76, 0x3a, 0xc2, 0xe6, 0x0e, 0xf3, 0xef, 0xa9, 0x33, 0x86, 0xc1, 0x96, 0x63, 0xd1, 0x99,
-	0xd9, 0xec, 0xe1, 0x3d, 0x55, 0xa6, 0x70, 0x5c, 0x73, 0x02, 0x3a, 0x37, 0x77, 0xb9, 0x63, 0x77,
-	0xa5, 0x37, 0x83, 0x0f, 0xbd, 0xd2, 0xdf, 0x22, 0x72, 0x9c, 0xb6, 0x0c, 0x5f, 0xfe, 0x0a, 0x00,
-	0x00, 0xff, 0xff, 0x8a, 0x20, 0x09, 0xec, 0x45, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("pkg/protos/hub.proto", fileDescriptor_hub_a1a1e2e0d8252bed) }
+
+var fileDescriptor_hub_a1a1e2e0d8252bed = []byte{
+	// 1176 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xcd, 0x6e, 0xe3, 0xb6,
+	0x13, 0x5f, 0xf9, 0x33, 0x1e, 0x3b, 0x76, 0xc2, 0x7f, 0x3e, 0x98, 0x20, 0xf8, 0xc3, 0x10, 0xb6,
+	0xa9, 0xb1, 0x0b, 0x68, 0x17, 0xd9, 0x1e, 0x7b, 0xe9, 0x26, 0x1b, 0xd8, 0x87, 0x06, 0x0b, 0xb9,
+	0xa7, 0x1e, 0x0a, 0xd0, 0x16, 0x63, 0x0b, 0x91, 0x45, 0xad, 0x48, 0xa5, 0xc9, 0xa1, 0x6f, 0xd0,
+	0x77, 0xe8, 0x53, 0xf4, 0xd0, 0x4b, 0x81, 0x5e, 0x7a, 0xee, 0xb1, 0x0f, 0xd0, 0x63, 0x1f, 0xa2,
+	0x20, 0x45, 0xc9, 0x94, 0xac, 0x24, 0xdb, 0x02, 0x05, 0x7a, 0xe3, 0xfc, 0x86, 0xe2, 0xcc, 0xfc,
+	0xe6, 0x83, 0x14, 0xec, 0x45, 0x37, 0x8b, 0x57, 0x51, 0xcc, 0x04, 0xe3, 0xaf, 0x96, 0xc9, 0xcc,
+	0x51, 0x4b, 0xfb, 0x97, 0x1a, 0xe0, 0x2b, 0xf6, 0x55, 0x4c, 0x42, 0x4e, 0xe6, 0xc2, 0x67, 0xe1,
+	0x39, 0x09, 0x02, 0x97, 0x7e, 0x48, 0x28, 0x17, 0x08, 0x41, 0xe3, 0x3a, 0x66, 0x2b, 0x6c, 0x0d,
+	0xad, 0x51, 0xc7, 0x55, 0x6b, 0xd4, 0x87, 0x9a, 0x60, 0xb8, 0xa6, 0x90, 0x9a, 0x60, 0xe8, 0x39,
+	0x6c, 0x8b, 0xf5, 0xd7, 0x93, 0x0b, 0x5c, 0x57, 0xaa, 0x22, 0x88, 0x0e, 0xa0, 0xc5, 0x05, 0x8d,
+	0x26, 0x17, 0xb8, 0xa1, 0xd4, 0x5a, 0x42, 0x18, 0xda, 0x11, 0xb9, 0x0f, 0x18, 0xf1, 0x70, 0x73,
+	0x68, 0x8d, 0x7a, 0x6e, 0x26, 0xaa, 0x2f, 0xfc, 0x45, 0x48, 0x63, 0xdc, 0x52, 0x0a, 0x2d, 0xa1,
+	0x13, 0xe8, 0x08, 0x7f, 0x45, 0xb9, 0x20, 0xab, 0x08, 0xb7, 0x87, 0xd6, 0xa8, 0xee, 0xae, 0x01,
+	0xb4, 0x07, 0xcd, 0x1b, 0x7a, 0x3f, 0xb9, 0xc0, 0x5b, 0xca, 0x4c, 0x2a, 0xc8, 0x6f, 0x48, 0xb0,
+	0x60, 0xb1, 0x2f, 0x96, 0x2b, 0xdc, 0x51, 0x9a, 0x35, 0x80, 0x3e, 0x81, 0x66, 0x14, 0x33, 0x76,
+	0x8d, 0x61, 0x68, 0x8d, 0xba, 0x67, 0x03, 0x67, 0x12, 0xce, 0x83, 0x84, 0xfb, 0x2c, 0x7c, 0x2f,
+	0x61, 0x37, 0xd5, 0x4a, 0x57, 0xa5, 0x1d, 0x96, 0x08, 0xdc, 0x55, 0x66, 0x33, 0xd1, 0xfe, 0xde,
+	0x82, 0x7e, 0xf1, 0x1b, 0xf4, 0x1c, 0x5a, 0x4b, 0x4a, 0x3c, 0x1a, 0x2b, 0xee, 0xba, 0x67, 0x3d,
+	0xe7, 0x6d, 0xc0, 0xe6, 0x37, 0x63, 0x85, 0xb9, 0x5a, 0x27, 0xf9, 0xf5, 0x88, 0x20, 0xb8, 0x36,
+	0xac, 0x8f, 0x7a, 0xae, 0x5a, 0x2b, 0x33, 0x77, 0x93, 0xd0, 0xa3, 0x77, 0x8a, 0xc9, 0x6d, 0x37,
+	0x13, 0xd1, 0x29, 0xf4, 0x6f, 0x49, 0xe0, 0x7b, 0x44, 0xa5, 0x89, 0x79, 0x54, 0x71, 0xd9, 0x74,
+	0x4b, 0xa8, 0xfd, 0x93, 0x05, 0x7b, 0x97, 0x64, 0x16, 0xfb, 0xf3, 0xf7, 0x29, 0x97, 0x59, 0x3a,
+	0x87, 0xd0, 0x9d, 0x2f, 0x49, 0x18, 0xd2, 0xe0, 0x8a, 0xac, 0xa8, 0xce, 0xaa, 0x09, 0xc9, 0x64,
+	0xce, 0x97, 0xc4, 0x57, 0xe7, 0xa8, 0x3d, 0x69, 0x9e, 0x8b, 0xa0, 0x74, 0xf1, 0x3a, 0x9c, 0x2b,
+	0x7d, 0x9a, 0xec, 0x4c, 0x94, 0x01, 0x91, 0x78, 0xc1, 0x71, 0x63, 0x58, 0x97, 0x05, 0x23, 0xd7,
+	0xe8, 0x25, 0x6c, 0xcd, 0x49, 0x10, 0xcc, 0xc8, 0xfc, 0x06, 0x37, 0x35, 0xc3, 0xa9, 0x7b, 0xe7,
+	0x1a, 0x76, 0xf3, 0x0d, 0xf6, 0xaf, 0x16, 0xf4, 0x8b, 0x4a, 0xf4, 0x1a, 0xfe, 0x97, 0xa9, 0xcf,
+	0x37, 0xbc, 0xaf, 0x52, 0xa1, 0xcf, 0x60, 0xdf, 0x80, 0x37, 0xa2, 0xa9, 0x56, 0xa2, 0x11, 0x0c,
+	0x32, 0xc5, 0x65, 0x21, 0xba, 0x32, 0x8c, 0x6c, 0xe8, 0x65, 0xd0, 0x17, 0xeb, 0x68, 0x0b, 0x98,
+	0xfd, 0x1d, 0x1c, 0x4e, 0x05, 0x89, 0x85, 0xd1, 0x59, 0x59, 0x1a, 0x4e, 0xa0, 0xa3, 0x39, 0x9f,
+	0x5c, 0xe8, 0x30, 0xd6, 0xc0, 0x66, 0x3f, 0xd5, 0xaa, 0xfa, 0xe9, 0xff, 0x00, 0x79, 0x4e, 0x38,
+	0xae, 0x2b, 0x07, 0x0c, 0xc4, 0xfe, 0xd3, 0x82, 0x83, 0x29, 0x0d, 0xbd, 0xbf, 0x6d, 0x1e, 0x41,
+	0x23, 0x49, 0x7c, 0x4f, 0x5b, 0x55, 0xeb, 0x8f, 0x6c, 0xf1, 0x53, 0xe8, 0x1b, 0xc0, 0x94, 0x7e,
+	0x50, 0xe5, 0xb9, 0xed, 0x96, 0xd0, 0xcd, 0x1a, 0x6b, 0x3e, 0x51, 0x63, 0xad, 0xea, 0x1a, 0x6b,
+	0xaf, 0x6b, 0xcc, 0xfe, 0x06, 0xf0, 0x39, 0x5b, 0xad, 0xfc, 0x7f, 0x89, 0x6e, 0xfb, 0xb7, 0x1a,
+	0xec, 0x4b, 0x03, 0xf2, 0x54, 0x1e, 0xb1, 0x90, 0xd3, 0x2f, 0x29, 0xe7, 0x64, 0x41, 0xff, 0x93,
+	0x23, 0xf2, 0xd8, 0xe8, 0xb8, 0xb6, 0xd2, 0xe4, 0xf2, 0x3f, 0x1a, 0x90, 0x2f, 0x00, 0xe4, 0xc9,
+	0x44, 0x24, 0x31, 0xe5, 0x18, 0x86, 0xf5, 0x51, 0xf7, 0x0c, 0x9c, 0x69, 0x06, 0xb9, 0x86, 0x16,
+	0x7d, 0x9a, 0x0d, 0xd3, 0xae, 0x6a, 0xf5, 0x5d, 0xe7, 0x5d, 0xe8, 0xb1, 0x98, 0xd3, 0x15, 0x0d,
+	0x85, 0x39, 0x4e, 0xed, 0x04, 0x3a, 0xf9, 0x09, 0x46, 0x24, 0x96, 0x8e, 0x3d, 0x8d, 0x24, 0xf7,
+	0xb6, 0xf6, 0xa0, 0xb7, 0xf5, 0xb2, 0xb7, 0x27, 0xd0, 0xc9, 0xfd, 0x51, 0x54, 0xf6, 0xdc, 0x35,
+	0x60, 0xff, 0x61, 0xc1, 0x4e, 0xd9, 0x25, 0x99, 0x44, 0x71, 0x97, 0x57, 0x87, 0x5a, 0xcb, 0x61,
+	0x39, 0x93, 0x23, 0xfb, 0x2a, 0x59, 0xcd, 0x68, 0xac, 0x1c, 0x68, 0xb8, 0x26, 0x54, 0x31, 0x8f,
+	0xeb, 0x55, 0xf3, 0x18, 0xbd, 0x86, 0x1e, 0x5d, 0x5b, 0x4c, 0xc7, 0x85, 0xbc, 0x11, 0x0c, 0x37,
+	0xdc, 0xc2, 0x0e, 0x49, 0x87, 0xbe, 0x3d, 0xd2, 0x8c, 0x6b, 0x49, 0x8e, 0xa8, 0x28, 0x66, 0x11,
+	0xe3, 0x24, 0xd0, 0xa3, 0x5d, 0x67, 0xbe, 0x0c, 0xdb, 0x04, 0xba, 0xc6, 0xf1, 0xb2, 0x22, 0xb4,
+	0x81, 0x94, 0xe1, 0x9e, 0x9b, 0xcb, 0x45, 0xbe, 0x6a, 0x25, 0xbe, 0xcc, 0xea, 0xab, 0x17, 0xaa,
+	0xcf, 0x66, 0x70, 0x38, 0xbd, 0x0f, 0xe7, 0xc6, 0xbd, 0xc6, 0x3f, 0xae, 0xe5, 0x86, 0xd0, 0xe5,
+	0x72, 0x34, 0x16, 0x99, 0x35, 0x20, 0x99, 0xf6, 0xc0, 0x5f, 0xf9, 0x42, 0xdf, 0x80, 0xa9, 0x60,
+	0xdf, 0x01, 0xde, 0x34, 0x98, 0x76, 0xe3, 0x13, 0x16, 0x4f, 0xa1, 0x9d, 0x32, 0xc8, 0x71, 0x4d,
+	0x93, 0x6f, 0x5e, 0xc7, 0x99, 0x32, 0xe5, 0xdd, 0x5f, 0x2c, 0x53, 0xc3, 0x0d, 0x57, 0x4b, 0xf6,
+	0x8f, 0x16, 0x74, 0x8d, 0x0f, 0xe4, 0xbe, 0x30, 0x75, 0xde, 0x4a, 0xf7, 0xa5, 0x92, 0xbc, 0x18,
+	0xa2, 0x98, 0xde, 0xfa, 0x2c, 0xe1, 0x63, 0xc2, 0x97, 0x9a, 0xcd, 0x02, 0x26, 0x53, 0x21, 0xef,
+	0x79, 0xa5, 0x4f, 0x19, 0xcd, 0x65, 0x39, 0xd5, 0x8d, 0x46, 0x4b, 0x6b, 0xd7, 0x40, 0x90, 0x03,
+	0xc8, 0x98, 0x19, 0xfc, 0xd2, 0x0f, 0x44, 0x5e, 0x23, 0x15, 0x1a, 0x7b, 0x00, 0xdb, 0x53, 0x41,
+	0x44, 0x92, 0x25, 0xc6, 0xfe, 0x1c, 0xfa, 0x19, 0xa0, 0x89, 0x7b, 0x01, 0xed, 0x98, 0xae, 0x98,
+	0xa0, 0x1c, 0x5b, 0x8a, 0x9a, 0x1d, 0xc7, 0x55, 0xf2, 0x38, 0x99, 0xe9, 0xad, 0xd9, 0x06, 0xfb,
+	0x77, 0x0b, 0x06, 0x25, 0xe5, 0x13, 0xc4, 0x63, 0x68, 0x13, 0xcf, 0x8b, 0x29, 0xe7, 0xba, 0x83,
+	0x33, 0x51, 0xdd, 0xa1, 0x2c, 0x0c, 0xe9, 0x5c, 0xf8, 0xb7, 0xbe, 0xb8, 0xd7, 0x6d, 0x5c, 0xc0,
+	0xe4, 0xd7, 0xb3, 0x98, 0x92, 0x1b, 0x1a, 0xeb, 0x91, 0x98, 0x89, 0x92, 0xc4, 0x6b, 0xe2, 0x07,
+	0x8a, 0xa6, 0xa6, 0xaa, 0x91, 0x5c, 0x46, 0x6f, 0xa0, 0x43, 0x43, 0x2f, 0x62, 0xbe, 0xec, 0xb5,
+	0x96, 0x8a, 0x69, 0x5f, 0xc7, 0xf4, 0x4e, 0xe3, 0x3a, 0xb0, 0xf5, 0x3e, 0xfb, 0x67, 0x0b, 0xf6,
+	0xaa, 0xf6, 0x98, 0x11, 0x58, 0x8f, 0x47, 0x50, 0xab, 0x8e, 0x60, 0x49, 0x49, 0x20, 0x96, 0x69,
+	0x80, 0x5b, 0x6e, 0x26, 0xca, 0x08, 0xa2, 0xd8, 0x97, 0x23, 0xeb, 0x5e, 0x3f, 0xe3, 0x72, 0x59,
+	0x96, 0xd7, 0xb7, 0x69, 0x19, 0x36, 0x95, 0x46, 0x4b, 0x85, 0xa8, 0x5b, 0xc5, 0xa8, 0xcf, 0x7e,
+	0xa8, 0x43, 0x7d, 0x9c, 0xcc, 0xd0, 0x18, 0x76, 0x37, 0x9e, 0xf3, 0xe8, 0xc8, 0x79, 0xe8, 0x89,
+	0x7f, 0x7c, 0xe0, 0x54, 0xde, 0x6b, 0xf6, 0x33, 0x74, 0x09, 0x3b, 0xe5, 0x17, 0x0c, 0xc2, 0xce,
+	0x03, 0x8f, 0x9a, 0x47, 0xce, 0xb9, 0x80, 0x41, 0xe9, 0x25, 0x82, 0x0e, 0x9d, 0xea, 0xb7, 0xc9,
+	0x23, 0xa7, 0x8c, 0x61, 0x77, 0xe3, 0x86, 0x47, 0x47, 0xce, 0x43, 0xb7, 0xfe, 0x23, 0x27, 0x4d,
+	0x60, 0xa7, 0x3c, 0x46, 0x64, 0x5c, 0xd5, 0xa3, 0xec, 0xf8, 0xc8, 0x79, 0x68, 0xe6, 0xd8, 0xcf,
+	0xd0, 0x4b, 0x68, 0xe9, 0x32, 0xe9, 0x3b, 0x85, 0x46, 0x3b, 0x1e, 0x38, 0xc5, 0x3e, 0xb3, 0x9f,
+	0xbd, 0x1d, 0x7c, 0xbd, 0x6d, 0xfc, 0x81, 0x45, 0xb3, 0x59, 0x4b, 0x2d, 0xdf, 0xfc, 0x35, 0x00,
+	0x94, 0xbc, 0xfb, 0x7d, 0x99, 0x0d, 0x00, 0x00,
 }
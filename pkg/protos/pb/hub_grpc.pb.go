@@ -25,6 +25,10 @@ type HubClient interface {
 	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*CommonResponseMessage, error)
 	// 提交事务
 	CommitTransaction(ctx context.Context, in *CommitTransactionRequest, opts ...grpc.CallOption) (*CommonResponseMessage, error)
+	// 同步本地通道的区块头
+	SyncBlockHeaders(ctx context.Context, in *SyncBlockHeadersRequest, opts ...grpc.CallOption) (*SyncBlockHeadersResponse, error)
+	// 查询网关到各远端网关的连接与熔断状态
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 }
 
 type hubClient struct {
@@ -71,6 +75,24 @@ func (c *hubClient) CommitTransaction(ctx context.Context, in *CommitTransaction
 	return out, nil
 }
 
+func (c *hubClient) SyncBlockHeaders(ctx context.Context, in *SyncBlockHeadersRequest, opts ...grpc.CallOption) (*SyncBlockHeadersResponse, error) {
+	out := new(SyncBlockHeadersResponse)
+	err := c.cc.Invoke(ctx, "/Hub/SyncBlockHeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/Hub/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HubServer is the server API for Hub service.
 // All implementations must embed UnimplementedHubServer
 // for forward compatibility
@@ -83,6 +105,10 @@ type HubServer interface {
 	SendTransaction(context.Context, *SendTransactionRequest) (*CommonResponseMessage, error)
 	// 提交事务
 	CommitTransaction(context.Context, *CommitTransactionRequest) (*CommonResponseMessage, error)
+	// 同步本地通道的区块头
+	SyncBlockHeaders(context.Context, *SyncBlockHeadersRequest) (*SyncBlockHeadersResponse, error)
+	// 查询网关到各远端网关的连接与熔断状态
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
 	mustEmbedUnimplementedHubServer()
 }
 
@@ -102,6 +128,12 @@ func (UnimplementedHubServer) SendTransaction(context.Context, *SendTransactionR
 func (UnimplementedHubServer) CommitTransaction(context.Context, *CommitTransactionRequest) (*CommonResponseMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CommitTransaction not implemented")
 }
+func (UnimplementedHubServer) SyncBlockHeaders(context.Context, *SyncBlockHeadersRequest) (*SyncBlockHeadersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncBlockHeaders not implemented")
+}
+func (UnimplementedHubServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
 func (UnimplementedHubServer) mustEmbedUnimplementedHubServer() {}
 
 // UnsafeHubServer may be embedded to opt out of forward compatibility for this service.
@@ -187,6 +219,42 @@ func _Hub_CommitTransaction_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_SyncBlockHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncBlockHeadersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).SyncBlockHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Hub/SyncBlockHeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).SyncBlockHeaders(ctx, req.(*SyncBlockHeadersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Hub/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Hub",
 	HandlerType: (*HubServer)(nil),
@@ -207,6 +275,14 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CommitTransaction",
 			Handler:    _Hub_CommitTransaction_Handler,
 		},
+		{
+			MethodName: "SyncBlockHeaders",
+			Handler:    _Hub_SyncBlockHeaders_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Hub_Status_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/protos/hub.proto",
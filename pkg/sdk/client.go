@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/pkg/errors"
+)
+
+const (
+	// 路由合约方法
+	FncChainCodeInvoke   = "ChainCodeInvoke"
+	FncQueryInvokeResult = "QueryInvokeResult"
+
+	DefaultRouterChainCodeName = "router"
+	DefaultStepID              = "1"
+	DefaultPollInterval        = 2 * time.Second
+)
+
+// Executor 路由合约所在通道的调用接口, 由 fabric.Channel 实现
+type Executor interface {
+	ChannelExecute(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error)
+	ChannelQuery(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error)
+}
+
+var _ Executor = (*fabric.Channel)(nil)
+
+// Target 跨链调用的目的链
+type Target struct {
+	// 目的链通道ID, 即网关配置中的 channel id
+	ChannelID string
+	// 目的链 fabric 通道名称
+	ChannelName string
+}
+
+// Invocation 已提交到路由合约的跨链调用
+type Invocation struct {
+	From          string
+	To            string
+	TransactionID string
+	StepID        string
+	// 提交路由合约的 fabric 交易ID
+	FabricTxID string
+}
+
+// Client 通过本地路由合约发起跨链调用, 网关监听路由合约交易并负责签名与转发
+type Client struct {
+	channel             Executor
+	from                string
+	routerChainCodeName string
+	nodeID              int64
+	node                *snowflake.Node
+	verifiers           map[string]*sw.SimpleCSP
+	waiter              Waiter
+}
+
+type Option func(c *Client)
+
+func WithRouterChainCode(name string) Option {
+	return func(c *Client) {
+		c.routerChainCodeName = name
+	}
+}
+
+// WithVerifier 设置目的链网关的验签公钥, 配置后等待结果时核实结果签名
+func WithVerifier(to string, csp *sw.SimpleCSP) Option {
+	return func(c *Client) {
+		c.verifiers[to] = csp
+	}
+}
+
+func WithWaiter(waiter Waiter) Option {
+	return func(c *Client) {
+		c.waiter = waiter
+	}
+}
+
+// WithNodeID 设置生成交易ID的 snowflake 节点号, 多个应用实例需各不相同
+func WithNodeID(nodeID int64) Option {
+	return func(c *Client) {
+		c.nodeID = nodeID
+	}
+}
+
+// NewClient from 为本地通道ID
+func NewClient(ch Executor, from string, opts ...Option) (*Client, error) {
+	if ch == nil {
+		return nil, errors.New("channel is required")
+	}
+	if from == "" {
+		return nil, errors.New("from channel id is required")
+	}
+	c := &Client{
+		channel:             ch,
+		from:                from,
+		routerChainCodeName: DefaultRouterChainCodeName,
+		nodeID:              1,
+		verifiers:           make(map[string]*sw.SimpleCSP),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	node, err := snowflake.NewNode(c.nodeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create id generator")
+	}
+	c.node = node
+	if c.waiter == nil {
+		c.waiter = NewStateWaiter(ch, c.routerChainCodeName, DefaultPollInterval)
+	}
+	return c, nil
+}
+
+type invokeOptions struct {
+	transactionID string
+	stepID        string
+	callback      *pb.FabricCallback
+}
+
+type InvokeOption func(o *invokeOptions)
+
+func WithTransactionID(transactionID string) InvokeOption {
+	return func(o *invokeOptions) {
+		o.transactionID = transactionID
+	}
+}
+
+func WithStepID(stepID string) InvokeOption {
+	return func(o *invokeOptions) {
+		o.stepID = stepID
+	}
+}
+
+// WithCallback 目的链执行完成后, 网关在本地通道调用的回调合约
+func WithCallback(callback *pb.FabricCallback) InvokeOption {
+	return func(o *invokeOptions) {
+		o.callback = callback
+	}
+}
+
+// Invoke 通过路由合约提交跨链调用, 交易提交后返回, 结果需通过 Wait 获取
+func (c *Client) Invoke(ctx context.Context, target Target, chaincode, fn string, args []string, opts ...InvokeOption) (*Invocation, error) {
+	if target.ChannelID == "" || target.ChannelName == "" {
+		return nil, errors.New("target channel id and name are required")
+	}
+	if target.ChannelID == c.from {
+		return nil, errors.New("target channel id is equal to from channel id")
+	}
+	o := &invokeOptions{stepID: DefaultStepID}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.transactionID == "" {
+		o.transactionID = c.node.Generate().String()
+	}
+	if args == nil {
+		args = []string{}
+	}
+	payload, err := json.Marshal(&pb.FabricPayloadRequest{
+		ChannelName:   target.ChannelName,
+		ChainCodeName: chaincode,
+		FncName:       fn,
+		Args:          args,
+		Callback:      o.callback,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal payload")
+	}
+
+	invocation := &Invocation{
+		From:          c.from,
+		To:            target.ChannelID,
+		TransactionID: o.transactionID,
+		StepID:        o.stepID,
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	// 签名参数为空, 由网关使用本地通道的密钥补签
+	resp, err := c.channel.ChannelExecute(ctx, channel.Request{
+		ChaincodeID: c.routerChainCodeName,
+		Fcn:         FncChainCodeInvoke,
+		Args: [][]byte{
+			[]byte(invocation.From),
+			[]byte(invocation.To),
+			[]byte(invocation.TransactionID),
+			[]byte(invocation.StepID),
+			payload,
+			{},
+		},
+	})
+	if err != nil {
+		return nil, &InvokeError{TransactionID: invocation.TransactionID, StepID: invocation.StepID, Err: err}
+	}
+	invocation.FabricTxID = string(resp.TransactionID)
+	return invocation, nil
+}
+
+// Wait 等待跨链调用的结果, 配置了目的链验签公钥时核实结果签名
+func (c *Client) Wait(ctx context.Context, invocation *Invocation) (*Result, error) {
+	result, err := c.waiter.Wait(ctx, invocation)
+	if err != nil {
+		return nil, err
+	}
+	if verifier, ok := c.verifiers[invocation.To]; ok {
+		if err = result.Verify(verifier); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Call 提交跨链调用并等待结果, 返回目的链合约的执行结果
+func (c *Client) Call(ctx context.Context, target Target, chaincode, fn string, args []string, opts ...InvokeOption) (*ExecuteResult, error) {
+	invocation, err := c.Invoke(ctx, target, chaincode, fn, args, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.Wait(ctx, invocation)
+	if err != nil {
+		return nil, err
+	}
+	return result.Decode()
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/internal/testutil"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRouter 模拟路由合约的状态
+type testRouter struct {
+	lock     sync.Mutex
+	requests map[string][][]byte
+	results  map[string]Result
+}
+
+func newTestRouter() *testRouter {
+	return &testRouter{requests: make(map[string][][]byte), results: make(map[string]Result)}
+}
+
+func (r *testRouter) ChannelExecute(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if request.Fcn != FncChainCodeInvoke || len(request.Args) != 6 {
+		return channel.Response{}, errors.New("invalid request")
+	}
+	key := fmt.Sprintf("%s-%s", request.Args[2], request.Args[3])
+	if _, ok := r.requests[key]; ok {
+		return channel.Response{}, errors.New("transactionID is already existed")
+	}
+	r.requests[key] = request.Args
+	return channel.Response{TransactionID: fab.TransactionID("fabric-" + key)}, nil
+}
+
+func (r *testRouter) ChannelQuery(ctx context.Context, request channel.Request, options ...channel.RequestOption) (channel.Response, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	payload, err := json.Marshal(r.results[fmt.Sprintf("%s-%s", request.Args[0], request.Args[1])])
+	return channel.Response{Payload: payload}, err
+}
+
+// reply 模拟网关回写目的链签名的执行结果
+func (r *testRouter) reply(invocation *Invocation, csp *sw.SimpleCSP, result ExecuteResult, message string) {
+	payload, _ := json.Marshal(result)
+	signature, _ := csp.Sign(payload)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results[fmt.Sprintf("%s-%s", invocation.TransactionID, invocation.StepID)] = Result{
+		From:          invocation.From,
+		To:            invocation.To,
+		TransactionID: invocation.TransactionID,
+		StepID:        invocation.StepID,
+		Payload:       string(payload),
+		Signer:        base64.StdEncoding.EncodeToString(signature),
+		Message:       message,
+	}
+}
+
+func TestClient(t *testing.T) {
+	router := newTestRouter()
+	remote := testutil.NewTestCSP(t)
+	target := Target{ChannelID: "2", ChannelName: "mychannel"}
+	c, err := NewClient(router, "1",
+		WithVerifier(target.ChannelID, remote),
+		WithWaiter(NewStateWaiter(router, DefaultRouterChainCodeName, 10*time.Millisecond)))
+	assert.Nil(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		invocation, err := c.Invoke(context.Background(), target, "fabcar", "QueryCar", []string{"CAR1"},
+			WithCallback(&pb.FabricCallback{CallbackChainCodeName: "app", CallbackFncName: "OnResult"}))
+		assert.Nil(t, err)
+		assert.Equal(t, "fabric-"+invocation.TransactionID+"-1", invocation.FabricTxID)
+		args := router.requests[invocation.TransactionID+"-1"]
+		assert.Equal(t, "1", string(args[0]))
+		assert.Equal(t, "2", string(args[1]))
+		var payload pb.FabricPayloadRequest
+		assert.Nil(t, json.Unmarshal(args[4], &payload))
+		assert.Equal(t, "mychannel", payload.ChannelName)
+		assert.Equal(t, []string{"CAR1"}, payload.Args)
+		assert.Equal(t, "OnResult", payload.Callback.CallbackFncName)
+
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			router.reply(invocation, remote, ExecuteResult{TransactionID: "remote", ChaincodeStatus: 200, Payload: []byte("car")}, "")
+		}()
+		result, err := c.Wait(context.Background(), invocation)
+		assert.Nil(t, err)
+		executeResult, err := result.Decode()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("car"), executeResult.Payload)
+
+		// 重复的交易ID
+		_, err = c.Invoke(context.Background(), target, "fabcar", "QueryCar", nil, WithTransactionID(invocation.TransactionID))
+		var invokeErr *InvokeError
+		assert.True(t, errors.As(err, &invokeErr))
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		invocation, err := c.Invoke(context.Background(), target, "fabcar", "QueryCar", nil)
+		assert.Nil(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		_, err = c.Wait(ctx, invocation)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		router.reply(invocation, remote, ExecuteResult{}, "the to channel id is invalid")
+		result, err := c.Wait(context.Background(), invocation)
+		assert.Nil(t, err)
+		_, err = result.Decode()
+		var remoteErr *RemoteError
+		assert.True(t, errors.As(err, &remoteErr))
+
+		router.reply(invocation, remote, ExecuteResult{TxValidationCode: int32(peer.TxValidationCode_MVCC_READ_CONFLICT)}, "")
+		result, err = c.Wait(context.Background(), invocation)
+		assert.Nil(t, err)
+		_, err = result.Decode()
+		var chaincodeErr *ChaincodeError
+		assert.True(t, errors.As(err, &chaincodeErr))
+
+		// 非目的链网关签名
+		router.reply(invocation, testutil.NewTestCSP(t), ExecuteResult{}, "")
+		_, err = c.Wait(context.Background(), invocation)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidSignature 结果签名与目的链网关的公钥不匹配
+var ErrInvalidSignature = errors.New("signature of the cross chain result is invalid")
+
+// InvokeError 提交路由合约交易失败, 跨链调用未发起
+type InvokeError struct {
+	TransactionID string
+	StepID        string
+	Err           error
+}
+
+func (e *InvokeError) Error() string {
+	return fmt.Sprintf("failed to invoke router for %s-%s: %v", e.TransactionID, e.StepID, e.Err)
+}
+
+func (e *InvokeError) Unwrap() error {
+	return e.Err
+}
+
+// RemoteError 网关转发或目的链执行失败, Message 为网关回写的错误信息
+type RemoteError struct {
+	TransactionID string
+	StepID        string
+	Message       string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("cross chain call %s-%s failed: %s", e.TransactionID, e.StepID, e.Message)
+}
+
+// ChaincodeError 目的链交易未通过验证或合约返回错误状态
+type ChaincodeError struct {
+	TxID             string
+	TxValidationCode int32
+	ChaincodeStatus  int32
+}
+
+func (e *ChaincodeError) Error() string {
+	return fmt.Sprintf("remote transaction %s failed, validation code: %s, chaincode status: %d",
+		e.TxID, peer.TxValidationCode(e.TxValidationCode), e.ChaincodeStatus)
+}
@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// 合约返回状态大于等于该值时视为错误, 与 shim.ERRORTHRESHOLD 一致
+const chaincodeErrorThreshold = 400
+
+// Result 网关回写到路由合约的跨链结果, 即路由合约的 ChainCodeInvokeResult
+type Result struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	TransactionID string `json:"transactionId"`
+	StepID        string `json:"stepID"`
+	// 目的链网关签名的执行结果
+	Payload string `json:"payload"`
+	// 目的链网关对 Payload 的签名, base64 编码
+	Signer string `json:"signer"`
+	// 跨链调用失败时的错误信息
+	Message string `json:"message"`
+}
+
+// ExecuteResult 目的链 channel.Response 的 JSON 编码
+type ExecuteResult struct {
+	TransactionID    string
+	TxValidationCode int32
+	ChaincodeStatus  int32
+	// 目的链合约的返回值
+	Payload []byte
+}
+
+// Verify 使用目的链网关的公钥核实结果签名
+func (r *Result) Verify(csp *sw.SimpleCSP) error {
+	signature, err := base64.StdEncoding.DecodeString(r.Signer)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signer")
+	}
+	valid, err := csp.VerifyWithKeyID("", "", signature, []byte(r.Payload))
+	if err != nil {
+		return errors.Wrap(err, "failed to verify signer")
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Decode 解码执行结果, 跨链调用失败时返回 RemoteError, 目的链执行失败时返回 ChaincodeError
+func (r *Result) Decode() (*ExecuteResult, error) {
+	if r.Message != "" {
+		return nil, &RemoteError{TransactionID: r.TransactionID, StepID: r.StepID, Message: r.Message}
+	}
+	return DecodePayload([]byte(r.Payload))
+}
+
+// DecodeResponse 解码网关响应中的执行结果, 调用方需先核实响应签名
+func DecodeResponse(resp *pb.CommonResponseMessage) (*ExecuteResult, error) {
+	return DecodePayload(resp.Payload)
+}
+
+// DecodePayload 解码网关签名的执行结果
+func DecodePayload(payload []byte) (*ExecuteResult, error) {
+	var result ExecuteResult
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal execute result")
+	}
+	if result.TxValidationCode != int32(peer.TxValidationCode_VALID) || result.ChaincodeStatus >= chaincodeErrorThreshold {
+		return &result, &ChaincodeError{
+			TxID:             result.TransactionID,
+			TxValidationCode: result.TxValidationCode,
+			ChaincodeStatus:  result.ChaincodeStatus,
+		}
+	}
+	return &result, nil
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Waiter 等待跨链调用的结果写回路由合约
+type Waiter interface {
+	Wait(ctx context.Context, invocation *Invocation) (*Result, error)
+}
+
+// StateWaiter 轮询路由合约的 QueryInvokeResult 获取结果
+type StateWaiter struct {
+	channel             Executor
+	routerChainCodeName string
+	interval            time.Duration
+}
+
+func NewStateWaiter(ch Executor, routerChainCodeName string, interval time.Duration) *StateWaiter {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &StateWaiter{
+		channel:             ch,
+		routerChainCodeName: routerChainCodeName,
+		interval:            interval,
+	}
+}
+
+// Wait 结果未写回前持续轮询, 直到 ctx 取消或超时
+func (w *StateWaiter) Wait(ctx context.Context, invocation *Invocation) (*Result, error) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		result, err := w.Query(ctx, invocation)
+		if err != nil {
+			logrus.Warnf("failed to query invoke result of %s-%s: %v", invocation.TransactionID, invocation.StepID, err)
+		} else if result != nil {
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "failed to wait for the result of %s-%s", invocation.TransactionID, invocation.StepID)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Query 查询一次结果, 未写回时返回 nil
+func (w *StateWaiter) Query(ctx context.Context, invocation *Invocation) (*Result, error) {
+	resp, err := w.channel.ChannelQuery(ctx, channel.Request{
+		ChaincodeID: w.routerChainCodeName,
+		Fcn:         FncQueryInvokeResult,
+		Args:        [][]byte{[]byte(invocation.TransactionID), []byte(invocation.StepID)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query router")
+	}
+	var result Result
+	if err = json.Unmarshal(resp.Payload, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal invoke result")
+	}
+	// 路由合约在结果未写回时返回空结构
+	if result.TransactionID == "" {
+		return nil, nil
+	}
+	return &result, nil
+}
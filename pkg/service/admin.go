@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/adopter"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/deadletter"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/pkg/errors"
+)
+
+// MaxReplayBlocks 单次重新处理的最大区块数
+const MaxReplayBlocks = 1000
+
+// AdminService 网关管理接口, 需独立监听, 不对远端网关开放
+type AdminService struct {
+	pb.UnimplementedAdminServer
+
+	dbPath string
+
+	// 各本地通道的跨链任务, 按通道名称索引, 用于重新处理死信及管理处理进度
+	tasks map[string]*adopter.CrossChainTask
+
+	// 网关服务, 用于核实执行结果未知的远端网关跨链请求
+	hub *HubService
+}
+
+func NewAdminService(dbPath string, options ...AdminOption) *AdminService {
+	service := &AdminService{dbPath: dbPath}
+	for _, option := range options {
+		option(service)
+	}
+	return service
+}
+
+type AdminOption func(s *AdminService)
+
+func WithCrossChainTasks(tasks map[string]*adopter.CrossChainTask) AdminOption {
+	return func(s *AdminService) {
+		s.tasks = tasks
+	}
+}
+
+func WithHubService(hub *HubService) AdminOption {
+	return func(s *AdminService) {
+		s.hub = hub
+	}
+}
+
+func (s *AdminService) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	letters, err := deadletter.NewController(s.dbPath).FetchDeadLetters(req.ChannelID, int(req.Offset), int(req.Limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch dead letters")
+	}
+	resp := &pb.ListDeadLettersResponse{}
+	for i := range letters {
+		resp.DeadLetters = append(resp.DeadLetters, toPBDeadLetter(&letters[i], false))
+	}
+	return resp, nil
+}
+
+func (s *AdminService) GetDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.DeadLetter, error) {
+	letter, err := s.fetchDeadLetter(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toPBDeadLetter(letter, true), nil
+}
+
+// RetryDeadLetter 由死信所属通道的跨链任务重新处理, 成功后删除死信, 失败时累计错误记录
+func (s *AdminService) RetryDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.RetryDeadLetterResponse, error) {
+	controller := deadletter.NewController(s.dbPath)
+	letter, err := s.fetchDeadLetter(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.fetchTask(letter.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = task.RetryDeadLetter(ctx, letter)
+	if err == nil {
+		if err = controller.Delete(letter.PrimaryID); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete dead letter %d", letter.PrimaryID)
+		}
+		return &pb.RetryDeadLetterResponse{Succeeded: true}, nil
+	}
+	var failure *adopter.HandleError
+	if !errors.As(err, &failure) {
+		return nil, err
+	}
+	return &pb.RetryDeadLetterResponse{DeadLetter: toPBDeadLetter(letter, false)}, nil
+}
+
+func (s *AdminService) DiscardDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.DiscardDeadLetterResponse, error) {
+	letter, err := s.fetchDeadLetter(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err = deadletter.NewController(s.dbPath).Delete(letter.PrimaryID); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete dead letter %d", letter.PrimaryID)
+	}
+	return &pb.DiscardDeadLetterResponse{}, nil
+}
+
+func (s *AdminService) GetCursor(ctx context.Context, req *pb.CursorRequest) (*pb.Cursor, error) {
+	task, err := s.fetchTask(req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := task.NextBlock()
+	if err != nil {
+		return nil, cursorError(err)
+	}
+	return &pb.Cursor{ChannelID: req.ChannelID, NextBlock: next}, nil
+}
+
+// SetStartBlock 设置下一个处理的区块, 可跳过历史区块或回退到已处理的区块重新处理
+func (s *AdminService) SetStartBlock(ctx context.Context, req *pb.SetStartBlockRequest) (*pb.Cursor, error) {
+	task, err := s.fetchTask(req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Latest && req.BlockNumber == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the block number should be greater than 0")
+	}
+	next, err := task.SetStartBlock(req.BlockNumber, req.Latest)
+	if err != nil {
+		return nil, cursorError(err)
+	}
+	return &pb.Cursor{ChannelID: req.ChannelID, NextBlock: next}, nil
+}
+
+// ReplayBlocks 重新处理区块范围内的跨链请求, 已完成的请求不会重复调用远端网关
+func (s *AdminService) ReplayBlocks(ctx context.Context, req *pb.ReplayBlocksRequest) (*pb.ReplayBlocksResponse, error) {
+	task, err := s.fetchTask(req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if req.FromBlock == 0 || req.FromBlock > req.ToBlock {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block range [%d, %d]", req.FromBlock, req.ToBlock)
+	}
+	if req.ToBlock-req.FromBlock >= MaxReplayBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d blocks can be replayed at once", MaxReplayBlocks)
+	}
+	blocks, requests, err := task.Replay(ctx, req.FromBlock, req.ToBlock)
+	if err != nil {
+		return nil, cursorError(err)
+	}
+	return &pb.ReplayBlocksResponse{Blocks: uint32(blocks), Requests: uint32(requests)}, nil
+}
+
+func (s *AdminService) fetchTask(channelID string) (*adopter.CrossChainTask, error) {
+	task, ok := s.tasks[channelID]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "the channel %s is not served", channelID)
+	}
+	return task, nil
+}
+
+func cursorError(err error) error {
+	if err == adopter.ErrBlockCursorNotSupported {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return err
+}
+
+func (s *AdminService) fetchDeadLetter(id int64) (*database.DeadLetter, error) {
+	letter, err := deadletter.NewController(s.dbPath).FetchDeadLetterByID(id)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "the dead letter %d is not found", id)
+		}
+		return nil, errors.Wrapf(err, "failed to fetch dead letter %d", id)
+	}
+	return letter, nil
+}
+
+func toPBDeadLetter(letter *database.DeadLetter, withRequest bool) *pb.DeadLetter {
+	msg := &pb.DeadLetter{
+		Id:              letter.PrimaryID,
+		ChannelID:       letter.ChannelID,
+		From:            letter.From,
+		To:              letter.To,
+		TransactionID:   letter.TransactionID,
+		StepID:          letter.StepID,
+		TransactionHash: letter.TransactionHash,
+		BlockNumber:     letter.BlockNumber,
+		Attempts:        uint32(letter.Attempts),
+		Errors:          letter.Errors,
+		Permanent:       letter.Permanent,
+		HasResponse:     len(letter.Response) != 0,
+		CreatedAt:       letter.CreatedAt.Unix(),
+		UpdatedAt:       letter.UpdatedAt.Unix(),
+	}
+	if withRequest {
+		msg.Request = letter.Request
+	}
+	return msg
+}
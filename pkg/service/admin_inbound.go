@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/inbound"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/pkg/errors"
+)
+
+func (s *AdminService) ListInboundExecutions(ctx context.Context, req *pb.ListInboundExecutionsRequest) (*pb.ListInboundExecutionsResponse, error) {
+	executions, err := inbound.NewController(s.dbPath).FetchExecutions(database.InboundState(req.State), int(req.Offset), int(req.Limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch inbound executions")
+	}
+	resp := &pb.ListInboundExecutionsResponse{}
+	for i := range executions {
+		resp.Executions = append(resp.Executions, toPBInboundExecution(&executions[i]))
+	}
+	return resp, nil
+}
+
+// ResolveInboundExecution 核实执行结果未知的请求, 由网关服务查询交易并更新执行记录
+func (s *AdminService) ResolveInboundExecution(ctx context.Context, req *pb.ResolveInboundExecutionRequest) (*pb.InboundExecution, error) {
+	if s.hub == nil {
+		return nil, status.Error(codes.Unimplemented, "the hub service is not served")
+	}
+	execution, err := s.hub.ResolveExecution(ctx, inbound.Key(req.From, req.TransactionID, req.StepID), req.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return toPBInboundExecution(execution), nil
+}
+
+func toPBInboundExecution(execution *database.InboundExecution) *pb.InboundExecution {
+	msg := &pb.InboundExecution{
+		Key:           execution.Key,
+		From:          execution.From,
+		To:            execution.To,
+		TransactionID: execution.TransactionID,
+		StepID:        execution.StepID,
+		State:         string(execution.State),
+		CreatedAt:     execution.CreatedAt.Unix(),
+		UpdatedAt:     execution.UpdatedAt.Unix(),
+	}
+	// 执行结果为序列化的 channel.Response, 只取交易ID
+	var result struct {
+		TransactionID string
+	}
+	if len(execution.Result) != 0 && json.Unmarshal(execution.Result, &result) == nil {
+		msg.TxID = result.TransactionID
+	}
+	return msg
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/transaction"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultQueryLimit 未设置分页大小时每页返回的记录数
+	DefaultQueryLimit = 20
+	// MaxQueryLimit 每页返回的最大记录数
+	MaxQueryLimit = 100
+)
+
+func (s *AdminService) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.BlockRecord, error) {
+	b, err := fetchBlock(s.blockController(req.ChannelID), req.BlockNumber, req.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return toPBBlock(b, req.WithOriginInfo), nil
+}
+
+// WalkBlocks 沿后驱或前驱哈希查询区块, 遇到未保存或已清理的区块时结束
+func (s *AdminService) WalkBlocks(ctx context.Context, req *pb.WalkBlocksRequest) (*pb.WalkBlocksResponse, error) {
+	controller := s.blockController(req.ChannelID)
+	current, err := fetchBlock(controller, req.BlockNumber, req.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	limit := queryLimit(req.Limit)
+	resp := &pb.WalkBlocksResponse{}
+	for current != nil && len(resp.Blocks) < limit {
+		resp.Blocks = append(resp.Blocks, toPBBlock(current, req.WithOriginInfo))
+		if current, err = adjacentBlock(controller, current, req.Backward); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch adjacent block")
+		}
+	}
+	if current != nil {
+		resp.NextPageHash = current.BlockHash
+	}
+	return resp, nil
+}
+
+// GetTransaction 查询跨链交易, 包含解码后的交易及其发起的跨链请求. 通道ID为空时
+// 查询按通道划分前保存的交易, 返回各通道中该交易发起的跨链请求
+func (s *AdminService) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.TransactionRecord, error) {
+	if req.TransactionHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "the transaction hash is required")
+	}
+	tx, err := s.transactionController(req.ChannelID).FetchTransactionByTransactionHash(req.TransactionHash)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "the transaction %s is not found", req.TransactionHash)
+		}
+		return nil, errors.Wrapf(err, "failed to fetch transaction %s", req.TransactionHash)
+	}
+	records, err := outbox.NewController(s.dbPath).FetchByTransactionHash(req.ChannelID, req.TransactionHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch cross chain requests of %s", req.TransactionHash)
+	}
+
+	resp := &pb.TransactionRecord{
+		ChannelID:       req.ChannelID,
+		BlockNumber:     tx.BlockNumber,
+		BlockHash:       tx.BlockHash,
+		TransactionHash: tx.TransactionHash,
+		ValidationCode:  peer.TxValidationCode(tx.ValidationCode).String(),
+	}
+	if len(tx.OriginInfo) != 0 {
+		if resp.OriginInfo, err = decodeTransactionOrigin(tx.OriginInfo, tx.TransactionHash); err != nil {
+			return nil, err
+		}
+	}
+	for i := range records {
+		resp.Requests = append(resp.Requests, toPBCrossChainRequest(&records[i]))
+	}
+	return resp, nil
+}
+
+func (s *AdminService) ListCrossChainRequests(ctx context.Context, req *pb.ListCrossChainRequestsRequest) (*pb.ListCrossChainRequestsResponse, error) {
+	switch database.OutboxState(req.State) {
+	case "", database.OutboxDetected, database.OutboxSent, database.OutboxResponded,
+		database.OutboxResultWritten, database.OutboxCallbackDone, database.OutboxFailed:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown state %s", req.State)
+	}
+	filter := &outbox.Filter{
+		ChannelID: req.ChannelID,
+		From:      req.From,
+		To:        req.To,
+		State:     database.OutboxState(req.State),
+	}
+	if req.StartTime > 0 {
+		filter.StartTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		filter.EndTime = time.Unix(req.EndTime, 0)
+	}
+	records, total, err := outbox.NewController(s.dbPath).FetchOutboxes(filter, int(req.Offset), queryLimit(req.Limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch cross chain requests")
+	}
+	resp := &pb.ListCrossChainRequestsResponse{Total: uint32(total)}
+	for i := range records {
+		resp.Requests = append(resp.Requests, toPBCrossChainRequest(&records[i]))
+	}
+	return resp, nil
+}
+
+// blockController 通道ID为空时查询按通道划分前保存的区块
+func (s *AdminService) blockController(channelID string) *block.Controller {
+	if channelID == "" {
+		return block.NewLegacyController(s.dbPath)
+	}
+	return block.NewController(s.dbPath, channelID)
+}
+
+// transactionController 通道ID为空时查询按通道划分前保存的交易
+func (s *AdminService) transactionController(channelID string) *transaction.Controller {
+	if channelID == "" {
+		return transaction.NewLegacyController(s.dbPath)
+	}
+	return transaction.NewController(s.dbPath, channelID)
+}
+
+// fetchBlock 区块哈希为空时按区块号查询
+func fetchBlock(controller *block.Controller, blockNumber uint64, blockHash string) (*database.Block, error) {
+	var (
+		b   *database.Block
+		err error
+	)
+	if blockHash != "" {
+		b, err = controller.FetchBlockByHash(blockHash)
+	} else {
+		b, err = controller.FetchBlockByNumber(blockNumber)
+	}
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, status.Error(codes.NotFound, "the block is not found")
+		}
+		return nil, errors.Wrap(err, "failed to fetch block")
+	}
+	return b, nil
+}
+
+// adjacentBlock 返回相邻的区块, 不存在时返回 nil. 未记录后驱哈希时按区块号查询并核对前驱哈希
+func adjacentBlock(controller *block.Controller, current *database.Block, backward bool) (*database.Block, error) {
+	var (
+		b   *database.Block
+		err error
+	)
+	switch {
+	case backward:
+		if current.BlockNumber == 0 || current.PreviousHash == "" {
+			return nil, nil
+		}
+		b, err = controller.FetchBlockByHash(current.PreviousHash)
+	case current.NextHash != "":
+		b, err = controller.FetchBlockByHash(current.NextHash)
+	default:
+		b, err = controller.FetchBlockByNumber(current.BlockNumber + 1)
+		if err == nil && b.PreviousHash != current.BlockHash {
+			return nil, nil
+		}
+	}
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+	return b, err
+}
+
+func queryLimit(limit uint32) int {
+	if limit == 0 {
+		return DefaultQueryLimit
+	}
+	if limit > MaxQueryLimit {
+		return MaxQueryLimit
+	}
+	return int(limit)
+}
+
+// decodeTransactionOrigin 解码交易的源信息, 兼容保存整个区块的旧记录
+func decodeTransactionOrigin(originInfo []byte, txHash string) (string, error) {
+	raw := originInfo
+	rawBlock := &common.Block{}
+	if err := proto.Unmarshal(originInfo, rawBlock); err == nil && rawBlock.Header != nil && rawBlock.Data != nil {
+		raw = nil
+		for _, data := range rawBlock.Data.Data {
+			envelope, err := fabric.UnmarshalEnvelope(data)
+			if err == nil && envelope.Payload != nil && envelope.Payload.Header != nil &&
+				envelope.Payload.Header.ChannelHeader != nil && envelope.Payload.Header.ChannelHeader.TxId == txHash {
+				raw = data
+				break
+			}
+		}
+		if raw == nil {
+			return "", errors.Errorf("the transaction %s is not found in origin block", txHash)
+		}
+	}
+	envelope, err := fabric.UnmarshalEnvelope(raw)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal origin envelope")
+	}
+	envelope.OriginData = nil
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func toPBBlock(b *database.Block, withOriginInfo bool) *pb.BlockRecord {
+	msg := &pb.BlockRecord{
+		BlockNumber:  b.BlockNumber,
+		BlockHash:    b.BlockHash,
+		PreviousHash: b.PreviousHash,
+		NextHash:     b.NextHash,
+		DataHash:     b.DataHash,
+		BlockTime:    b.BlockTime.Unix(),
+		TxNum:        uint32(b.TxNum),
+	}
+	if withOriginInfo {
+		msg.OriginInfo = string(b.OriginInfo)
+	}
+	return msg
+}
+
+func toPBCrossChainRequest(record *database.Outbox) *pb.CrossChainRequestRecord {
+	msg := &pb.CrossChainRequestRecord{
+		ChannelID:       record.ChannelID,
+		From:            record.From,
+		To:              record.To,
+		TransactionID:   record.TransactionID,
+		StepID:          record.StepID,
+		TransactionHash: record.TransactionHash,
+		BlockNumber:     record.BlockNumber,
+		State:           string(record.State),
+		Error:           record.Error,
+		HasResponse:     len(record.Response) != 0,
+		DetectedAt:      unixTime(record.DetectedAt),
+		SentAt:          unixTime(record.SentAt),
+		RespondedAt:     unixTime(record.RespondedAt),
+		ResultWrittenAt: unixTime(record.ResultWrittenAt),
+		CallbackDoneAt:  unixTime(record.CallbackDoneAt),
+		FailedAt:        unixTime(record.FailedAt),
+		UpdatedAt:       unixTime(record.UpdatedAt),
+	}
+	request := &pb.NoTransactionCallRequest{}
+	if err := proto.Unmarshal(record.Request, request); err == nil {
+		// 包含证明体积较大, 不返回
+		request.Proof = nil
+		if data, err := json.Marshal(request); err == nil {
+			msg.Request = string(data)
+		}
+	}
+	return msg
+}
+
+// unixTime 零值时间返回 0
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/block"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/outbox"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/transaction"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminWalkBlocks(t *testing.T) {
+	s := NewAdminService(t.TempDir())
+	controller := block.NewController(s.dbPath, "mychannel")
+	for i := 1; i <= 5; i++ {
+		err := controller.CreateBlock(&database.Block{
+			BlockNumber:  uint64(i),
+			BlockHash:    fmt.Sprintf("h%d", i),
+			PreviousHash: fmt.Sprintf("h%d", i-1),
+			DataHash:     fmt.Sprintf("d%d", i),
+		})
+		assert.Nil(t, err)
+	}
+
+	_, err := s.GetBlock(context.Background(), &pb.GetBlockRequest{ChannelID: "other", BlockNumber: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	b, err := s.GetBlock(context.Background(), &pb.GetBlockRequest{ChannelID: "mychannel", BlockHash: "h2"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), b.BlockNumber)
+	assert.Equal(t, "h3", b.NextHash)
+
+	resp, err := s.WalkBlocks(context.Background(), &pb.WalkBlocksRequest{ChannelID: "mychannel", BlockNumber: 2, Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Blocks, 2)
+	assert.Equal(t, "h4", resp.NextPageHash)
+	resp, err = s.WalkBlocks(context.Background(), &pb.WalkBlocksRequest{ChannelID: "mychannel", BlockHash: resp.NextPageHash, Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Blocks, 2)
+	assert.Equal(t, "", resp.NextPageHash)
+
+	resp, err = s.WalkBlocks(context.Background(), &pb.WalkBlocksRequest{ChannelID: "mychannel", BlockNumber: 3, Backward: true})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Blocks, 3)
+	assert.Equal(t, uint64(1), resp.Blocks[2].BlockNumber)
+}
+
+func TestAdminListCrossChainRequests(t *testing.T) {
+	s := NewAdminService(t.TempDir())
+	controller := outbox.NewController(s.dbPath)
+	for i := 0; i < 5; i++ {
+		to := "b"
+		if i%2 == 0 {
+			to = "c"
+		}
+		err := controller.Create(&database.Outbox{
+			Key:           outbox.Key("mychannel", fmt.Sprint(i), "1"),
+			ChannelID:     "mychannel",
+			From:          "a",
+			To:            to,
+			TransactionID: fmt.Sprint(i),
+		})
+		assert.Nil(t, err)
+	}
+
+	resp, err := s.ListCrossChainRequests(context.Background(), &pb.ListCrossChainRequestsRequest{To: "c", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), resp.Total)
+	assert.Len(t, resp.Requests, 2)
+	assert.Equal(t, "4", resp.Requests[0].TransactionID)
+	assert.Equal(t, string(database.OutboxDetected), resp.Requests[0].State)
+
+	_, err = s.ListCrossChainRequests(context.Background(), &pb.ListCrossChainRequestsRequest{State: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 按发现时间过滤, 包含起始时间, 不包含结束时间
+	db := database.OpenDB(s.dbPath, outbox.DBName, new(database.Outbox))
+	base := time.Unix(1600000000, 0)
+	for i := 0; i < 5; i++ {
+		record, err := controller.FetchByKey(outbox.Key("mychannel", fmt.Sprint(i), "1"))
+		assert.Nil(t, err)
+		record.DetectedAt = base.Add(time.Duration(i) * time.Hour)
+		assert.Nil(t, db.Save(record))
+	}
+	resp, err = s.ListCrossChainRequests(context.Background(), &pb.ListCrossChainRequestsRequest{
+		StartTime: base.Add(time.Hour).Unix(),
+		EndTime:   base.Add(3 * time.Hour).Unix(),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), resp.Total)
+	assert.Equal(t, "2", resp.Requests[0].TransactionID)
+	assert.Equal(t, "1", resp.Requests[1].TransactionID)
+	resp, err = s.ListCrossChainRequests(context.Background(), &pb.ListCrossChainRequestsRequest{
+		StartTime: base.Add(3 * time.Hour).Unix(),
+		To:        "c",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), resp.Total)
+	assert.Equal(t, "4", resp.Requests[0].TransactionID)
+}
+
+func TestAdminGetTransaction(t *testing.T) {
+	s := NewAdminService(t.TempDir())
+	tx1, tx2 := newTestTxEnvelope(t, "tx1"), newTestTxEnvelope(t, "tx2")
+	assert.Nil(t, transaction.NewController(s.dbPath, "mychannel").Create(1, "h1", "tx2", tx2))
+	assert.Nil(t, outbox.NewController(s.dbPath).Create(&database.Outbox{
+		Key:             outbox.Key("mychannel", "t1", "1"),
+		ChannelID:       "mychannel",
+		TransactionID:   "t1",
+		StepID:          "1",
+		TransactionHash: "tx2",
+	}))
+
+	// 源信息为交易 Envelope
+	tx, err := s.GetTransaction(context.Background(), &pb.GetTransactionRequest{ChannelID: "mychannel", TransactionHash: "tx2"})
+	assert.Nil(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID.String(), tx.ValidationCode)
+	assert.Equal(t, "tx2", decodedTxID(t, tx.OriginInfo))
+	assert.Len(t, tx.Requests, 1)
+	assert.Equal(t, "t1", tx.Requests[0].TransactionID)
+	_, err = s.GetTransaction(context.Background(), &pb.GetTransactionRequest{ChannelID: "other", TransactionHash: "tx2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// 按通道划分前保存的交易, 源信息为整个区块
+	rawBlock, err := proto.Marshal(&common.Block{
+		Header: &common.BlockHeader{Number: 1},
+		Data:   &common.BlockData{Data: [][]byte{tx1, tx2}},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, transaction.NewLegacyController(s.dbPath).Create(1, "h1", "tx2", rawBlock))
+	tx, err = s.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionHash: "tx2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "tx2", decodedTxID(t, tx.OriginInfo))
+	assert.Len(t, tx.Requests, 1)
+
+	// 区块中不包含该交易
+	assert.Nil(t, transaction.NewLegacyController(s.dbPath).Create(1, "h1", "tx3", rawBlock))
+	_, err = s.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionHash: "tx3"})
+	assert.NotNil(t, err)
+}
+
+func TestAdminLegacyBlocks(t *testing.T) {
+	s := NewAdminService(t.TempDir())
+	legacy := block.NewLegacyController(s.dbPath)
+	for i := 1; i <= 2; i++ {
+		assert.Nil(t, legacy.CreateBlock(&database.Block{
+			BlockNumber:  uint64(i),
+			BlockHash:    fmt.Sprintf("h%d", i),
+			PreviousHash: fmt.Sprintf("h%d", i-1),
+			DataHash:     fmt.Sprintf("d%d", i),
+		}))
+	}
+
+	// 通道ID为空时查询按通道划分前保存的区块
+	b, err := s.GetBlock(context.Background(), &pb.GetBlockRequest{BlockNumber: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "h1", b.BlockHash)
+	resp, err := s.WalkBlocks(context.Background(), &pb.WalkBlocksRequest{BlockHash: "h1"})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Blocks, 2)
+	_, err = s.GetBlock(context.Background(), &pb.GetBlockRequest{ChannelID: "mychannel", BlockNumber: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// newTestTxEnvelope 构造交易 ID 为 txID 的交易 Envelope
+func newTestTxEnvelope(t *testing.T, txID string) []byte {
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: "mychannel",
+		TxId:      txID,
+		Timestamp: ptypes.TimestampNow(),
+	})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: channelHeader}})
+	assert.Nil(t, err)
+	envelope, err := proto.Marshal(&common.Envelope{Payload: payload, Signature: []byte("signature")})
+	assert.Nil(t, err)
+	return envelope
+}
+
+// decodedTxID 返回解码后的交易 Envelope 的交易 ID
+func decodedTxID(t *testing.T, originInfo string) string {
+	var envelope fabric.Envelope
+	assert.Nil(t, json.Unmarshal([]byte(originInfo), &envelope))
+	if envelope.Payload == nil || envelope.Payload.Header == nil || envelope.Payload.Header.ChannelHeader == nil {
+		return ""
+	}
+	return envelope.Payload.Header.ChannelHeader.TxId
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/asdine/storm/v3"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/lightclient"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/inbound"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	sdkstatus "github.com/fabric-creed/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// keyLocks 按键互斥, 同一请求的重复调用等待前一次执行结束
+type keyLocks struct {
+	lock  sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// acquire 获取键的锁, 返回释放函数
+func (l *keyLocks) acquire(key string) func() {
+	l.lock.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyLock)
+	}
+	kl, ok := l.locks[key]
+	if !ok {
+		kl = &keyLock{}
+		l.locks[key] = kl
+	}
+	kl.refs++
+	l.lock.Unlock()
+
+	kl.Lock()
+	return func() {
+		kl.Unlock()
+		l.lock.Lock()
+		if kl.refs--; kl.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.lock.Unlock()
+	}
+}
+
+// beginExecution 记录请求开始执行. 重复请求返回已保存的签名响应, 合约已执行而响应未保存时
+// 返回执行记录以重新构造响应, 相同键但 payload 不同的请求被拒绝. 执行结果未知的请求
+// 需经管理接口核实后才能重新执行或返回响应
+func (s *HubService) beginExecution(req *pb.NoTransactionCallRequest) (*database.InboundExecution, *pb.CommonResponseMessage, error) {
+	ctl := inbound.NewController(s.dbPath)
+	key := inbound.Key(req.From, req.TransactionID, req.StepID)
+	hash := sha256.Sum256(req.Payload)
+	payloadHash := hex.EncodeToString(hash[:])
+
+	execution, err := ctl.FetchByKey(key)
+	if err == nil {
+		if execution.PayloadHash != payloadHash {
+			return nil, nil, status.Errorf(codes.AlreadyExists, "the request %s is already used by a different payload", key)
+		}
+		switch execution.State {
+		case database.InboundExecuting:
+			return nil, nil, status.Errorf(codes.Aborted,
+				"the outcome of the request %s is unknown, it should be resolved via the admin server", key)
+		case database.InboundAborted:
+			// 已核实交易未生效, 重新执行
+			execution.State = database.InboundExecuting
+			if err = ctl.Update(execution); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to update the execution of %s", key)
+			}
+			return execution, nil, nil
+		case database.InboundCommitted:
+			// 合约已执行而签名响应未保存, 由执行结果重新构造响应
+			if len(execution.Result) == 0 {
+				return nil, nil, status.Errorf(codes.DataLoss, "the request %s is executed but its result is lost, check the chain", key)
+			}
+			logrus.Infof("the request %s is already executed, build the response from the saved result", key)
+			return execution, nil, nil
+		}
+		resp := &pb.CommonResponseMessage{}
+		if err = proto.Unmarshal(execution.Response, resp); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal the response of %s", key)
+		}
+		logrus.Infof("the request %s is already executed, return the saved response", key)
+		return nil, resp, nil
+	}
+	if err != storm.ErrNotFound {
+		return nil, nil, errors.Wrapf(err, "failed to fetch the execution of %s", key)
+	}
+
+	execution = &database.InboundExecution{
+		Key:           key,
+		From:          req.From,
+		To:            req.To,
+		TransactionID: req.TransactionID,
+		StepID:        req.StepID,
+		PayloadHash:   payloadHash,
+		Payload:       req.Payload,
+		State:         database.InboundExecuting,
+	}
+	if err = ctl.Create(execution); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create the execution of %s", key)
+	}
+	return execution, nil, nil
+}
+
+// abortExecution 执行失败时删除记录以便重试. 交易可能已提交时保留执行中的记录, 避免重复执行
+func (s *HubService) abortExecution(ctx context.Context, execution *database.InboundExecution, err error) {
+	if executionOutcomeUnknown(ctx, err) {
+		logrus.Warnf("the outcome of the request %s is unknown, resolve it via the admin server, err:%s", execution.Key, err.Error())
+		return
+	}
+	if err := inbound.NewController(s.dbPath).Delete(execution); err != nil {
+		logrus.Errorf("failed to delete the execution of %s, err:%s", execution.Key, err.Error())
+	}
+}
+
+// executionOutcomeUnknown 判断合约执行失败时交易是否可能已提交. 背书失败、排序服务拒绝
+// 与交易验证无效时交易未生效; ctx 取消或超时、等待提交事件超时、发送至排序服务失败
+// 以及无法识别的错误均视为结果未知
+func executionOutcomeUnknown(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	s, ok := sdkstatus.FromError(err)
+	if !ok {
+		return true
+	}
+	switch s.Group {
+	case sdkstatus.EndorserClientStatus, sdkstatus.EndorserServerStatus, sdkstatus.ChaincodeStatus,
+		sdkstatus.DiscoveryServerStatus, sdkstatus.OrdererServerStatus, sdkstatus.EventServerStatus:
+		return false
+	case sdkstatus.ClientStatus:
+		return s.Code == sdkstatus.Timeout.ToInt32()
+	}
+	return true
+}
+
+// commitExecution 合约已执行, 保存执行结果与背书证明, 构造或保存签名响应失败时
+// 重复请求据此重新构造响应. 执行结果无法序列化时同样标记为已执行, 避免重复执行,
+// 保存失败时只记录日志
+func (s *HubService) commitExecution(execution *database.InboundExecution, resp channel.Response, proof *pb.EndorsementProof) error {
+	result, err := json.Marshal(resp)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal resp:[%v]", resp)
+	} else {
+		execution.Result = result
+	}
+	if proof != nil {
+		data, err := proto.Marshal(proof)
+		if err != nil {
+			logrus.Errorf("failed to marshal the endorsement proof of %s, err:%s", execution.Key, err.Error())
+		} else {
+			execution.Proof = data
+		}
+	}
+	execution.State = database.InboundCommitted
+	if err := inbound.NewController(s.dbPath).Update(execution); err != nil {
+		logrus.Errorf("failed to save the result of %s, err:%s", execution.Key, err.Error())
+	}
+	return err
+}
+
+// ResolveExecution 核实执行结果未知的请求. txID 为空表示已确认链上没有该请求的交易,
+// 记录标记为 aborted; 否则查询 txID 对应的交易, 核实其为该请求的代理合约调用后,
+// 交易有效时保存执行结果, 重复请求据此构造响应, 交易无效时标记为 aborted, 重复请求重新执行
+func (s *HubService) ResolveExecution(ctx context.Context, key, txID string) (*database.InboundExecution, error) {
+	unlock := s.executionLocks.acquire(key)
+	defer unlock()
+	ctl := inbound.NewController(s.dbPath)
+	execution, err := ctl.FetchByKey(key)
+	if err != nil {
+		if err == storm.ErrNotFound {
+			return nil, status.Errorf(codes.NotFound, "the execution of %s is not found", key)
+		}
+		return nil, errors.Wrapf(err, "failed to fetch the execution of %s", key)
+	}
+	if execution.State != database.InboundExecuting {
+		return nil, status.Errorf(codes.FailedPrecondition, "the request %s is %s", key, execution.State)
+	}
+	if txID == "" {
+		return execution, s.markAborted(execution)
+	}
+
+	if len(execution.Payload) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"the payload of the request %s is not saved, resolve it without tx id after checking the chain", key)
+	}
+	fabricPayload := &pb.FabricPayloadRequest{}
+	if err = json.Unmarshal(execution.Payload, fabricPayload); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the payload of %s", key)
+	}
+	fabricClient, ok := s.fabricManager[execution.To]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "the channel %s is not served", execution.To)
+	}
+	ledger, err := fabricClient.Ledger(fabricPayload.ChannelName, s.channelManager[execution.To].IsGM)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ledger by %s", fabricPayload.ChannelName)
+	}
+	tx, err := ledger.QueryRawTransaction(txID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query transaction %s", txID)
+	}
+	var blockNumber uint64
+	if tx.ValidationCode == int32(peer.TxValidationCode_VALID) {
+		block, err := ledger.QueryBlockByTxID(txID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query block of transaction %s", txID)
+		}
+		if block != nil && block.Header != nil {
+			blockNumber = block.Header.Number
+		}
+	}
+	return execution, s.settleExecution(execution, fabricPayload, tx, txID, blockNumber)
+}
+
+// settleExecution 由链上交易确定执行结果, 交易须以请求的参数调用本链的代理合约
+func (s *HubService) settleExecution(execution *database.InboundExecution, fabricPayload *pb.FabricPayloadRequest,
+	tx *peer.ProcessedTransaction, txID string, blockNumber uint64) error {
+	if tx == nil || tx.TransactionEnvelope == nil {
+		return errors.Errorf("the transaction %s is not found", txID)
+	}
+	invocation, err := lightclient.ParseChaincodeInvocation(tx.TransactionEnvelope)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to parse transaction %s", txID)
+	}
+	args, err := client.ProxyArgs(fabricPayload)
+	if err != nil {
+		return err
+	}
+	args = append([][]byte{[]byte(client.FncNoTransactionCall)}, args...)
+	if invocation.TxID != txID || invocation.ChannelID != fabricPayload.ChannelName ||
+		invocation.ChaincodeName != s.channelManager[execution.To].ProxyChainCodeName || !equalArgs(invocation.Args, args) {
+		return status.Errorf(codes.InvalidArgument, "the transaction %s is not the execution of %s", txID, execution.Key)
+	}
+	if tx.ValidationCode != int32(peer.TxValidationCode_VALID) {
+		logrus.Infof("the transaction %s of %s is invalid, validation code: %s",
+			txID, execution.Key, peer.TxValidationCode(tx.ValidationCode))
+		return s.markAborted(execution)
+	}
+
+	response, err := chaincodeResponse(invocation.Endorsement)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get chaincode response of transaction %s", txID)
+	}
+	proof := invocation.Endorsement
+	proof.BlockNumber = blockNumber
+	return s.commitExecution(execution, channel.Response{
+		TransactionID:    fab.TransactionID(txID),
+		TxValidationCode: peer.TxValidationCode_VALID,
+		ChaincodeStatus:  response.Status,
+		Payload:          response.Payload,
+	}, proof)
+}
+
+// markAborted 交易未生效, 标记执行记录以便重复请求重新执行
+func (s *HubService) markAborted(execution *database.InboundExecution) error {
+	execution.State = database.InboundAborted
+	if err := inbound.NewController(s.dbPath).Update(execution); err != nil {
+		return errors.Wrapf(err, "failed to update the execution of %s", execution.Key)
+	}
+	return nil
+}
+
+// chaincodeResponse 取出背书结果中的合约响应
+func chaincodeResponse(proof *pb.EndorsementProof) (*peer.Response, error) {
+	if len(proof.Endorsements) == 0 {
+		return nil, errors.New("endorsement is missing")
+	}
+	prp := &peer.ProposalResponsePayload{}
+	if err := proto.Unmarshal(proof.Endorsements[0].Payload, prp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposal response payload")
+	}
+	action := &peer.ChaincodeAction{}
+	if err := proto.Unmarshal(prp.Extension, action); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode action")
+	}
+	if action.Response == nil {
+		return nil, errors.New("chaincode response is missing")
+	}
+	return action.Response, nil
+}
+
+func equalArgs(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// savedProof 返回保存的背书证明, 无法解析时只记录日志
+func savedProof(execution *database.InboundExecution) *pb.EndorsementProof {
+	if len(execution.Proof) == 0 {
+		return nil
+	}
+	proof := &pb.EndorsementProof{}
+	if err := proto.Unmarshal(execution.Proof, proof); err != nil {
+		logrus.Errorf("failed to unmarshal the endorsement proof of %s, err:%s", execution.Key, err.Error())
+		return nil
+	}
+	return proof
+}
+
+// completeExecution 保存签名响应, 合约已执行, 保存失败时只记录日志
+func (s *HubService) completeExecution(execution *database.InboundExecution, resp *pb.CommonResponseMessage) {
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		logrus.Errorf("failed to marshal the response of %s, err:%s", execution.Key, err.Error())
+		return
+	}
+	execution.Response = data
+	execution.State = database.InboundExecuted
+	if err = inbound.NewController(s.dbPath).Update(execution); err != nil {
+		logrus.Errorf("failed to save the response of %s, err:%s", execution.Key, err.Error())
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/fabric-creed/fabric-hub/config"
+	"github.com/fabric-creed/fabric-hub/internal/testutil"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/inbound"
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/fabric-creed/fabric-protos-go/peer"
+	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	sdkstatus "github.com/fabric-creed/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/fabric-creed/grpc/codes"
+	"github.com/fabric-creed/grpc/status"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundExecution(t *testing.T) {
+	s := NewHubService(WithDBPath(t.TempDir()))
+	req := &pb.NoTransactionCallRequest{From: "a", To: "b", TransactionID: "tx", StepID: "1", Payload: []byte("payload")}
+
+	execution, saved, err := s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Nil(t, saved)
+
+	// 背书失败时交易未提交, 可重新执行
+	s.abortExecution(context.Background(), execution,
+		sdkstatus.New(sdkstatus.EndorserServerStatus, 500, "endorsement failure", nil))
+	execution, _, err = s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.NotNil(t, execution)
+
+	// 等待提交事件超时, 执行结果未知时拒绝重复执行
+	s.abortExecution(context.Background(), execution,
+		sdkstatus.New(sdkstatus.ClientStatus, sdkstatus.Timeout.ToInt32(), "Execute didn't receive block event", nil))
+	_, _, err = s.beginExecution(req)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	s.completeExecution(execution, &pb.CommonResponseMessage{TransactionID: "tx", Signer: []byte("sig")})
+	execution, saved, err = s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Nil(t, execution)
+	assert.Equal(t, []byte("sig"), saved.Signer)
+
+	// 相同键不同 payload 的请求被拒绝
+	_, _, err = s.beginExecution(&pb.NoTransactionCallRequest{From: "a", TransactionID: "tx", StepID: "1", Payload: []byte("other")})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestInboundCommittedExecution(t *testing.T) {
+	csp := testutil.NewTestCSP(t)
+	s := NewHubService(WithDBPath(t.TempDir()), WithCSP(map[string]*sw.SimpleCSP{"b": csp}))
+	req := &pb.NoTransactionCallRequest{From: "a", To: "b", TransactionID: "tx", StepID: "1", Payload: []byte("payload")}
+	fabricPayload := &pb.FabricPayloadRequest{}
+
+	execution, _, err := s.beginExecution(req)
+	assert.Nil(t, err)
+	resp := channel.Response{TransactionID: "chain-tx", Payload: []byte("result")}
+	assert.Nil(t, s.commitExecution(execution, resp, &pb.EndorsementProof{TxID: "chain-tx", BlockNumber: 3}))
+
+	// 合约已执行而响应未保存时, 重复请求由执行结果重新构造响应, 不再执行合约
+	execution, saved, err := s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Nil(t, saved)
+	assert.Equal(t, database.InboundCommitted, execution.State)
+	message, err := s.responseMessage(req, fabricPayload, execution)
+	assert.Nil(t, err)
+	result, err := json.Marshal(resp)
+	assert.Nil(t, err)
+	assert.Equal(t, result, message.Payload)
+	assert.Equal(t, uint64(3), message.Proof.BlockNumber)
+	valid, err := csp.VerifyWithKeyID(message.KeyID, message.Algorithm, message.Signer, message.Payload)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	s.completeExecution(execution, message)
+	_, saved, err = s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Equal(t, message.Signer, saved.Signer)
+
+	// 执行结果丢失时拒绝重复执行
+	lost := &pb.NoTransactionCallRequest{From: "a", To: "b", TransactionID: "lost", StepID: "1", Payload: []byte("payload")}
+	execution, _, err = s.beginExecution(lost)
+	assert.Nil(t, err)
+	execution.State = database.InboundCommitted
+	assert.Nil(t, inbound.NewController(s.dbPath).Update(execution))
+	_, _, err = s.beginExecution(lost)
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+}
+
+func TestExecutionOutcomeUnknown(t *testing.T) {
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.False(t, executionOutcomeUnknown(ctx, sdkstatus.New(sdkstatus.ChaincodeStatus, 500, "failed", nil)))
+	assert.False(t, executionOutcomeUnknown(ctx, errors.Wrap(
+		sdkstatus.New(sdkstatus.EventServerStatus, int32(peer.TxValidationCode_MVCC_READ_CONFLICT), "received invalid transaction", nil),
+		"failed to execute")))
+	assert.True(t, executionOutcomeUnknown(ctx,
+		sdkstatus.New(sdkstatus.ClientStatus, sdkstatus.Timeout.ToInt32(), "Execute didn't receive block event", nil)))
+	assert.True(t, executionOutcomeUnknown(ctx,
+		sdkstatus.New(sdkstatus.OrdererClientStatus, sdkstatus.ConnectionFailed.ToInt32(), "broken pipe", nil)))
+	assert.True(t, executionOutcomeUnknown(ctx, errors.New("unknown")))
+	assert.True(t, executionOutcomeUnknown(canceled, sdkstatus.New(sdkstatus.ChaincodeStatus, 500, "failed", nil)))
+}
+
+// newTestProxyTx 构造以 args 调用代理合约的交易
+func newTestProxyTx(t *testing.T, txID string, code peer.TxValidationCode, args [][]byte, result []byte) *peer.ProcessedTransaction {
+	spec, err := proto.Marshal(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+		ChaincodeId: &peer.ChaincodeID{Name: "proxy"},
+		Input:       &peer.ChaincodeInput{Args: args},
+	}})
+	assert.Nil(t, err)
+	proposalPayload, err := proto.Marshal(&peer.ChaincodeProposalPayload{Input: spec})
+	assert.Nil(t, err)
+	extension, err := proto.Marshal(&peer.ChaincodeAction{Response: &peer.Response{Status: 200, Payload: result}})
+	assert.Nil(t, err)
+	responsePayload, err := proto.Marshal(&peer.ProposalResponsePayload{ProposalHash: []byte("hash"), Extension: extension})
+	assert.Nil(t, err)
+	actionPayload, err := proto.Marshal(&peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: proposalPayload,
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: responsePayload,
+			Endorsements:            []*peer.Endorsement{{Endorser: []byte("endorser"), Signature: []byte("sig")}},
+		},
+	})
+	assert.Nil(t, err)
+	tx, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: actionPayload}}})
+	assert.Nil(t, err)
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: "mychannel",
+		TxId:      txID,
+	})
+	assert.Nil(t, err)
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: []byte("creator")})
+	assert.Nil(t, err)
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader},
+		Data:   tx,
+	})
+	assert.Nil(t, err)
+	return &peer.ProcessedTransaction{
+		TransactionEnvelope: &common.Envelope{Payload: payload, Signature: []byte("signature")},
+		ValidationCode:      int32(code),
+	}
+}
+
+func TestResolveExecution(t *testing.T) {
+	s := NewHubService(WithDBPath(t.TempDir()),
+		WithChannelManager(map[string]config.Channel{"b": {ProxyChainCodeName: "proxy"}}))
+	admin := NewAdminService(s.dbPath, WithHubService(s))
+	ctx := context.Background()
+	fabricPayload := &pb.FabricPayloadRequest{ChannelName: "mychannel", ChainCodeName: "cc", FncName: "set", Args: []string{"k", "v"}}
+	payload, err := json.Marshal(fabricPayload)
+	assert.Nil(t, err)
+	req := &pb.NoTransactionCallRequest{From: "a", To: "b", TransactionID: "tx", StepID: "1", Payload: payload}
+	key := inbound.Key(req.From, req.TransactionID, req.StepID)
+	args, err := client.ProxyArgs(fabricPayload)
+	assert.Nil(t, err)
+	args = append([][]byte{[]byte(client.FncNoTransactionCall)}, args...)
+	unknown := sdkstatus.New(sdkstatus.ClientStatus, sdkstatus.Timeout.ToInt32(), "Execute didn't receive block event", nil)
+
+	execution, _, err := s.beginExecution(req)
+	assert.Nil(t, err)
+	s.abortExecution(ctx, execution, unknown)
+	resp, err := admin.ListInboundExecutions(ctx, &pb.ListInboundExecutionsRequest{State: string(database.InboundExecuting)})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Executions, 1)
+	assert.Equal(t, key, resp.Executions[0].Key)
+
+	// 确认链上没有该请求的交易, 重复请求重新执行
+	resolved, err := admin.ResolveInboundExecution(ctx, &pb.ResolveInboundExecutionRequest{From: "a", TransactionID: "tx", StepID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, string(database.InboundAborted), resolved.State)
+	execution, _, err = s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Equal(t, database.InboundExecuting, execution.State)
+	s.abortExecution(ctx, execution, unknown)
+
+	// 交易不是该请求的执行
+	other := append([][]byte{}, args...)
+	other[3] = []byte(`["k","other"]`)
+	err = s.settleExecution(execution, fabricPayload, newTestProxyTx(t, "chain-tx", peer.TxValidationCode_VALID, other, nil), "chain-tx", 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = s.settleExecution(execution, fabricPayload, newTestProxyTx(t, "other-tx", peer.TxValidationCode_VALID, args, nil), "chain-tx", 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, _, err = s.beginExecution(req)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	// 交易无效时重新执行
+	err = s.settleExecution(execution, fabricPayload,
+		newTestProxyTx(t, "chain-tx", peer.TxValidationCode_MVCC_READ_CONFLICT, args, nil), "chain-tx", 0)
+	assert.Nil(t, err)
+	execution, _, err = s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Equal(t, database.InboundExecuting, execution.State)
+	s.abortExecution(ctx, execution, unknown)
+
+	// 交易有效时保存执行结果, 重复请求据此构造响应
+	err = s.settleExecution(execution, fabricPayload,
+		newTestProxyTx(t, "chain-tx", peer.TxValidationCode_VALID, args, []byte("result")), "chain-tx", 5)
+	assert.Nil(t, err)
+	execution, saved, err := s.beginExecution(req)
+	assert.Nil(t, err)
+	assert.Nil(t, saved)
+	assert.Equal(t, database.InboundCommitted, execution.State)
+	var result struct {
+		TransactionID    string
+		TxValidationCode int32
+		Payload          []byte
+	}
+	assert.Nil(t, json.Unmarshal(execution.Result, &result))
+	assert.Equal(t, "chain-tx", result.TransactionID)
+	assert.Equal(t, int32(peer.TxValidationCode_VALID), result.TxValidationCode)
+	assert.Equal(t, []byte("result"), result.Payload)
+	proof := savedProof(execution)
+	assert.Equal(t, uint64(5), proof.BlockNumber)
+	assert.Equal(t, "chain-tx", proof.TxID)
+	assert.Len(t, proof.Endorsements, 1)
+	assert.Equal(t, "chain-tx", toPBInboundExecution(execution).TxID)
+
+	// 已核实的记录不可再次核实
+	_, err = admin.ResolveInboundExecution(ctx, &pb.ResolveInboundExecutionRequest{From: "a", TransactionID: "tx", StepID: "1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = admin.ResolveInboundExecution(ctx, &pb.ResolveInboundExecutionRequest{From: "a", TransactionID: "none", StepID: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -1,24 +1,36 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"github.com/fabric-creed/fabric-hub/pkg/client"
+	"github.com/fabric-creed/fabric-hub/pkg/database"
+	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/lightclient"
+	"github.com/fabric-creed/fabric-hub/pkg/modules/inbound"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/peer"
 	"github.com/fabric-creed/fabric-sdk-go/pkg/client/channel"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
-)
-
-const (
-	FncNoTransactionCall = "NoTransactionCall"
+	"github.com/sirupsen/logrus"
+	"time"
 )
 
 func (s *HubService) NoTransactionCall(ctx context.Context, req *pb.NoTransactionCallRequest) (*pb.CommonResponseMessage, error) {
 	if req.From == req.To {
 		return nil, errors.Errorf("from channel id is equal to channel id ")
 	}
+	// 请求中的超时时间与调用方的截止时间取较早者, 转发至远端网关或 Fabric SDK
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.Timeout)*time.Millisecond)
+		defer cancel()
+	}
 
 	if _, ok := s.channelManager[req.From]; ok {
-		return s.hubClientManager[req.To].NoTransactionCall(req)
+		return s.hubClientManager[req.To].NoTransactionCall(ctx, req)
 	}
 
 	if _, ok := s.channelManager[req.To]; !ok {
@@ -29,19 +41,22 @@ func (s *HubService) NoTransactionCall(ctx context.Context, req *pb.NoTransactio
 	if !ok {
 		return nil, errors.New("the from channel id is invalid")
 	}
-	toCSP, ok := s.csp[req.To]
-	if !ok {
+	if _, ok := s.csp[req.To]; !ok {
 		return nil, errors.New("the to channel id is invalid")
 	}
 
-	// 使用来源链的公钥核实消息签名
-	valid, err := fromCSP.Verify(req.Signer, req.Payload)
+	// 使用来源链的公钥核实消息签名, 按 keyID 选择轮换期间的有效公钥
+	valid, err := fromCSP.VerifyWithKeyID(req.KeyID, req.Algorithm, req.Signer, req.Payload)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to verify signer")
 	}
 	if !valid {
 		return nil, errors.New("signer is invalid")
 	}
+	// 核实请求确实由来源链上有效提交的交易发起
+	if err = s.verifyInclusion(req); err != nil {
+		return nil, errors.Wrap(err, "failed to verify inclusion proof")
+	}
 	var fabricPayload = &pb.FabricPayloadRequest{}
 	err = json.Unmarshal(req.Payload, fabricPayload)
 	if err != nil {
@@ -52,33 +67,54 @@ func (s *HubService) NoTransactionCall(ctx context.Context, req *pb.NoTransactio
 		return nil, errors.Wrapf(err, "failed to get channel by %s", fabricPayload.GetChannelName())
 	}
 
-	var args [][]byte
-	args = append(args, []byte(fabricPayload.ChainCodeName))
-	args = append(args, []byte(fabricPayload.FncName))
-	if fabricPayload.Args == nil {
-		fabricPayload.Args = []string{}
-	}
-	ccArgs, err := json.Marshal(fabricPayload.Args)
+	args, err := client.ProxyArgs(fabricPayload)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to marshal args:[%v]", fabricPayload.Args)
+		return nil, err
 	}
-	args = append(args, ccArgs)
 
-	resp, err := channelClient.ChannelExecute(channel.Request{
-		ChaincodeID: s.channelManager[req.To].ProxyChainCodeName,
-		Fcn:         FncNoTransactionCall,
-		Args:        args,
-		IsInit:      false,
-	})
+	// 重复请求返回已保存的响应, 不重复执行合约. 执行记录保存在本节点,
+	// 远端网关切换至同一链的其他网关节点时仍可能重复执行
+	unlock := s.executionLocks.acquire(inbound.Key(req.From, req.TransactionID, req.StepID))
+	defer unlock()
+	execution, saved, err := s.beginExecution(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to call channel execute")
+		return nil, err
+	}
+	if saved != nil {
+		return saved, nil
+	}
+
+	if execution.State == database.InboundExecuting {
+		resp, err := channelClient.ChannelExecute(ctx, channel.Request{
+			ChaincodeID: s.channelManager[req.To].ProxyChainCodeName,
+			Fcn:         client.FncNoTransactionCall,
+			Args:        args,
+			IsInit:      false,
+		})
+		if err != nil {
+			s.abortExecution(ctx, execution, err)
+			return nil, errors.Wrap(err, "failed to call channel execute")
+		}
+		// 合约已执行, 先保存执行结果, 之后的步骤失败时由重复请求重新构造响应
+		err = s.commitExecution(execution, resp, s.endorsementProof(req.To, fabricPayload.GetChannelName(), resp))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	payload, err := json.Marshal(resp)
+	message, err := s.responseMessage(req, fabricPayload, execution)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to marshal resp:[%v]", resp)
+		return nil, err
 	}
+	s.completeExecution(execution, message)
+	return message, nil
+}
 
+// responseMessage 由保存的执行结果构造签名响应
+func (s *HubService) responseMessage(req *pb.NoTransactionCallRequest, fabricPayload *pb.FabricPayloadRequest,
+	execution *database.InboundExecution) (*pb.CommonResponseMessage, error) {
+	toCSP := s.csp[req.To]
+	payload := execution.Result
 	// 用该链的私钥对payload进行签名
 	sig, err := toCSP.Sign(payload)
 	if err != nil {
@@ -88,7 +124,7 @@ func (s *HubService) NoTransactionCall(ctx context.Context, req *pb.NoTransactio
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal callback")
 	}
-	return &pb.CommonResponseMessage{
+	message := &pb.CommonResponseMessage{
 		From:          req.From,
 		To:            req.To,
 		TransactionID: req.TransactionID,
@@ -96,5 +132,114 @@ func (s *HubService) NoTransactionCall(ctx context.Context, req *pb.NoTransactio
 		Payload:       payload,
 		Signer:        sig,
 		Callback:      callback,
-	}, nil
+		KeyID:         toCSP.KeyID(),
+		Algorithm:     toCSP.Algorithm(),
+		Proof:         savedProof(execution),
+	}
+	// 各组织签名身份对请求与结果进行背书
+	if message.Signatures, err = client.Attest(s.attestors[req.To], message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// endorsementProof 由各节点的提案响应构造背书证明, 交易已提交,
+// 查询区块号失败时只记录日志
+func (s *HubService) endorsementProof(to, channelName string, resp channel.Response) *pb.EndorsementProof {
+	proof := &pb.EndorsementProof{
+		TxID:           string(resp.TransactionID),
+		ValidationCode: int32(resp.TxValidationCode),
+	}
+	if resp.Proposal != nil && resp.Proposal.Proposal != nil {
+		payload, err := proposalPayloadForTx(resp.Proposal.Payload)
+		if err != nil {
+			logrus.Warnf("failed to get proposal payload of transaction %s: %v", proof.TxID, err)
+		} else {
+			proof.Header = resp.Proposal.Header
+			proof.ProposalPayload = payload
+		}
+	}
+	for _, response := range resp.Responses {
+		if response == nil || response.ProposalResponse == nil || response.Endorsement == nil {
+			continue
+		}
+		proof.Endorsements = append(proof.Endorsements, &pb.Endorsement{
+			Endorser:  response.Endorsement.Endorser,
+			Signature: response.Endorsement.Signature,
+			Payload:   response.Payload,
+		})
+	}
+
+	ledger, err := s.fabricManager[to].Ledger(channelName, s.channelManager[to].IsGM)
+	if err != nil {
+		logrus.Warnf("failed to get ledger by %s: %v", channelName, err)
+		return proof
+	}
+	block, err := ledger.QueryBlockByTxID(proof.TxID)
+	if err != nil {
+		logrus.Warnf("failed to query block of transaction %s: %v", proof.TxID, err)
+		return proof
+	}
+	if block != nil && block.Header != nil {
+		proof.BlockNumber = block.Header.Number
+	}
+	return proof
+}
+
+// proposalPayloadForTx 去除提案内容中的 TransientMap, 与写入交易的提案内容一致
+func proposalPayloadForTx(raw []byte) ([]byte, error) {
+	payload := &peer.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(raw, payload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chaincode proposal payload")
+	}
+	return proto.Marshal(&peer.ChaincodeProposalPayload{Input: payload.Input})
+}
+
+// verifyInclusion 来源链配置了区块头链时, 核实请求对应的路由合约交易已包含在已核实的区块中,
+// 交易签名与背书满足来源链的 MSP 与背书策略, 且交易参数与请求一致
+func (s *HubService) verifyInclusion(req *pb.NoTransactionCallRequest) error {
+	chain, ok := s.headerChains[req.From]
+	if !ok {
+		return nil
+	}
+	verifier, ok := s.endorsementVerifiers[req.From]
+	if !ok {
+		return errors.New("the msp of the from channel id is not configured")
+	}
+	envelope, err := chain.VerifyInclusion(req.Proof)
+	if err != nil {
+		return err
+	}
+	invocation, err := lightclient.ParseChaincodeInvocation(envelope)
+	if err != nil {
+		return err
+	}
+	if invocation.ChaincodeName != chain.RouterChainCodeName {
+		return errors.Errorf("transaction %s invokes chaincode %s instead of the router chaincode",
+			invocation.TxID, invocation.ChaincodeName)
+	}
+	if _, err = verifier.VerifySignature(invocation.Creator, envelope.Payload, envelope.Signature); err != nil {
+		return errors.Wrap(err, "failed to verify transaction creator")
+	}
+	if err = verifier.Verify(invocation.Endorsement, nil); err != nil {
+		return err
+	}
+
+	// 跨链请求由路由合约的事件发出. Fabric 只保留交易最外层合约设置的事件,
+	// 交易须直接调用路由合约, 经其他合约调用路由合约发起的请求无法核实
+	if invocation.Event == nil || invocation.Event.EventName != fabric.CrossChainRequestEvent ||
+		invocation.Event.ChaincodeId != chain.RouterChainCodeName {
+		return errors.Errorf("transaction %s is not a cross chain invocation", invocation.TxID)
+	}
+	request, err := fabric.UnmarshalCrossChainRequest(invocation.Event.Payload)
+	if err != nil {
+		return errors.WithMessagef(err, "transaction %s", invocation.TxID)
+	}
+	// 签名可能为空, 由来源网关补签, 因此不参与核对
+	if request.From != req.From || request.To != req.To ||
+		request.TransactionID != req.TransactionID || request.StepID != req.StepID ||
+		!bytes.Equal([]byte(request.Payload), req.Payload) {
+		return errors.Errorf("transaction %s does not match the request", invocation.TxID)
+	}
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/fabric-creed/fabric-hub/pkg/client"
 	"github.com/fabric-creed/fabric-hub/pkg/common/sw"
 	"github.com/fabric-creed/fabric-hub/pkg/fabric"
+	"github.com/fabric-creed/fabric-hub/pkg/lightclient"
 	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
 )
 
@@ -18,6 +19,18 @@ type HubService struct {
 	fabricManager map[string]*fabric.Client
 
 	hubClientManager map[string]*client.HubClient
+
+	attestors map[string][]*client.Attestor
+
+	// 来源链的区块头链与 MSP, 用于核实跨链请求的包含证明
+	headerChains map[string]*lightclient.HeaderChain
+
+	endorsementVerifiers map[string]*client.EndorsementVerifier
+
+	// 远端网关跨链请求的执行记录所在目录
+	dbPath string
+
+	executionLocks keyLocks
 }
 
 func NewHubService(options ...Option) *HubService {
@@ -52,3 +65,27 @@ func WithHubClient(hubClient map[string]*client.HubClient) Option {
 		s.hubClientManager = hubClient
 	}
 }
+
+func WithAttestors(attestors map[string][]*client.Attestor) Option {
+	return func(s *HubService) {
+		s.attestors = attestors
+	}
+}
+
+func WithHeaderChains(headerChains map[string]*lightclient.HeaderChain) Option {
+	return func(s *HubService) {
+		s.headerChains = headerChains
+	}
+}
+
+func WithEndorsementVerifiers(verifiers map[string]*client.EndorsementVerifier) Option {
+	return func(s *HubService) {
+		s.endorsementVerifiers = verifiers
+	}
+}
+
+func WithDBPath(dbPath string) Option {
+	return func(s *HubService) {
+		s.dbPath = dbPath
+	}
+}
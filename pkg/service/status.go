@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+)
+
+// Status 返回到各远端网关的连接与熔断状态, 按通道ID排序
+func (s *HubService) Status(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
+	resp := &pb.StatusResponse{}
+	for channelID, hubClient := range s.hubClientManager {
+		status := hubClient.Status()
+		status.ChannelID = channelID
+		resp.Remotes = append(resp.Remotes, status)
+	}
+	sort.Slice(resp.Remotes, func(i, j int) bool {
+		return resp.Remotes[i].ChannelID < resp.Remotes[j].ChannelID
+	})
+	return resp, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/fabric-creed/fabric-hub/pkg/protos/pb"
+	"github.com/fabric-creed/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSyncHeaderLimit = 100
+	maxSyncHeaderLimit     = 500
+)
+
+// SyncBlockHeaders 返回本地通道从 StartNumber 开始的区块头, 供远端网关维护轻节点头链
+func (s *HubService) SyncBlockHeaders(ctx context.Context, req *pb.SyncBlockHeadersRequest) (*pb.SyncBlockHeadersResponse, error) {
+	channel, ok := s.channelManager[req.ChannelID]
+	if !ok {
+		return nil, errors.New("the channel id:[" + req.ChannelID + "] is invalid")
+	}
+	fabricClient, ok := s.fabricManager[req.ChannelID]
+	if !ok {
+		return nil, errors.New("the fabric client of channel id:[" + req.ChannelID + "] is not found")
+	}
+	ledger, err := fabricClient.Ledger(channel.Name, channel.IsGM)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ledger by %s", channel.Name)
+	}
+	height, err := ledger.QueryHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := uint64(req.Limit)
+	if limit == 0 {
+		limit = defaultSyncHeaderLimit
+	}
+	if limit > maxSyncHeaderLimit {
+		limit = maxSyncHeaderLimit
+	}
+	resp := &pb.SyncBlockHeadersResponse{
+		ChannelID: req.ChannelID,
+		Height:    height,
+	}
+	for number := req.StartNumber; number < height && number < req.StartNumber+limit; number++ {
+		block, err := ledger.QueryRawBlock(number)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query block %d", number)
+		}
+		if block.Header == nil {
+			return nil, errors.Errorf("the header of block %d is nil", number)
+		}
+		header := &pb.BlockHeader{
+			Number:       block.Header.Number,
+			PreviousHash: block.Header.PreviousHash,
+			DataHash:     block.Header.DataHash,
+		}
+		if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_SIGNATURES) {
+			header.Signatures = block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES]
+		}
+		if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+			header.TransactionsFilter = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+		}
+		resp.Headers = append(resp.Headers, header)
+	}
+	return resp, nil
+}